@@ -11,6 +11,7 @@ import (
 	"github.com/HatiCode/league-buddy/internal/analysis"
 	"github.com/HatiCode/league-buddy/internal/coaching"
 	"github.com/HatiCode/league-buddy/internal/models"
+	"github.com/HatiCode/league-buddy/internal/riot"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +23,9 @@ var (
 	coachModel       string
 	coachMaxTokens   int64
 	coachTemperature float64
+	coachConcurrency int
+	coachRules       string
+	coachQueue       string
 )
 
 var coachCmd = &cobra.Command{
@@ -32,6 +36,9 @@ var coachCmd = &cobra.Command{
 		if coachRiotID == "" {
 			return fmt.Errorf("--riot-id is required (format: gameName#tagLine)")
 		}
+		if err := validateMatchCount(coachMatchCount); err != nil {
+			return err
+		}
 
 		parts := strings.SplitN(coachRiotID, "#", 2)
 		if len(parts) != 2 {
@@ -39,6 +46,20 @@ var coachCmd = &cobra.Command{
 		}
 		gameName, tagLine := parts[0], parts[1]
 
+		queue, err := models.QueueGroupByName(coachQueue)
+		if err != nil {
+			return err
+		}
+
+		var rules *analysis.RuleSet
+		if coachRules != "" {
+			r, err := analysis.LoadRules(coachRules)
+			if err != nil {
+				return fmt.Errorf("failed to load insight rules: %w", err)
+			}
+			rules = r
+		}
+
 		ctx := context.Background()
 		start := time.Now()
 
@@ -51,17 +72,17 @@ var coachCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to get league entries: %w", err)
 		}
-		var soloEntry *models.LeagueEntry
+		var leagueEntry *models.LeagueEntry
 		for i := range entries {
-			if entries[i].QueueType == models.QueueRankedSolo {
-				soloEntry = &entries[i]
+			if entries[i].QueueType == queue.LeagueQueue {
+				leagueEntry = &entries[i]
 				break
 			}
 		}
 
 		var previousMatchIDs map[string]bool
 		if dataStore != nil {
-			prevSession, err := dataStore.GetLatestCoachingSession(ctx, account.PUUID)
+			prevSession, err := dataStore.GetLatestCoachingSession(ctx, account.PUUID, queue.Name)
 			if err != nil {
 				return fmt.Errorf("failed to get previous session: %w", err)
 			}
@@ -76,12 +97,12 @@ var coachCmd = &cobra.Command{
 			}
 		}
 
-		allMatchIDs, err := riotClient.GetMatchIDs(ctx, platform, account.PUUID, coachMatchCount, models.QueueIDRankedSolo)
+		allMatchIDs, err := riot.GetMatchIDsInQueues(ctx, riotClient, platform, account.PUUID, queue.QueueIDs, riot.MatchIDsOptions{Count: coachMatchCount})
 		if err != nil {
 			return fmt.Errorf("failed to get match IDs: %w", err)
 		}
 		if len(allMatchIDs) == 0 {
-			return fmt.Errorf("no matches found for this summoner")
+			return fmt.Errorf("no %s matches found for this summoner", queue.Label)
 		}
 
 		var matchIDs []string
@@ -95,34 +116,30 @@ var coachCmd = &cobra.Command{
 			return nil
 		}
 
-		var matches []models.Match
-		timelines := make(map[string]*models.Timeline)
-		for _, id := range matchIDs {
-			match, err := riotClient.GetMatch(ctx, platform, id)
-			if err != nil {
-				cmd.PrintErrf("Warning: failed to fetch match %s: %v\n", id, err)
-				continue
-			}
-			matches = append(matches, *match)
-
-			tl, err := riotClient.GetMatchTimeline(ctx, platform, id)
-			if err == nil {
-				timelines[id] = tl
-			}
-		}
+		matches, timelines := fetchMatchesWithTimelines(ctx, cmd, matchIDs, coachConcurrency)
 		if len(matches) == 0 {
 			return fmt.Errorf("failed to fetch any match details")
 		}
 		riotDuration := time.Since(start)
 
 		analysisStart := time.Now()
+		// Benchmarks come from ranked Summoner's Rift games and only fit those queues.
+		var benchmarks *analysis.Benchmarks
+		if queue.Ranked() {
+			if benchmarks, err = loadBenchmarks(ctx); err != nil {
+				return err
+			}
+		}
 		playerAnalysis, err := analysis.AnalyzePlayer(analysis.PlayerAnalysisParams{
-			PUUID:     account.PUUID,
-			GameName:  account.GameName,
-			TagLine:   account.TagLine,
-			Matches:   matches,
-			Timelines: timelines,
-			League:    soloEntry,
+			PUUID:      account.PUUID,
+			GameName:   account.GameName,
+			TagLine:    account.TagLine,
+			Matches:    matches,
+			Timelines:  timelines,
+			League:     leagueEntry,
+			Queue:      queue.Name,
+			Benchmarks: benchmarks,
+			Rules:      rules,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to analyze matches: %w", err)
@@ -183,6 +200,46 @@ type coachTimingInfo struct {
 	Total    string `json:"total"`
 }
 
+// fetchMatchesWithTimelines fetches matches and timelines in parallel, warning about
+// and skipping matches that could not be fetched.
+func fetchMatchesWithTimelines(ctx context.Context, cmd *cobra.Command, matchIDs []string, concurrency int) ([]models.Match, map[string]*models.Timeline) {
+	var matches []models.Match
+	timelines := make(map[string]*models.Timeline)
+	for _, r := range riot.FetchMatchesWithTimelines(ctx, matchFetcher, platform, matchIDs, concurrency) {
+		if r.Err != nil {
+			cmd.PrintErrf("Warning: failed to fetch match %s: %v\n", r.MatchID, r.Err)
+			continue
+		}
+		matches = append(matches, *r.Match)
+		if r.Timeline != nil {
+			timelines[r.MatchID] = r.Timeline
+		}
+	}
+	return matches, timelines
+}
+
+// benchmarkMatchLimit caps how many stored matches feed the rank benchmarks.
+const benchmarkMatchLimit = 500
+
+// loadBenchmarks builds rank benchmarks from the stored matches, or returns
+// nil without a database so insights fall back to fixed thresholds.
+func loadBenchmarks(ctx context.Context) (*analysis.Benchmarks, error) {
+	if dataStore == nil {
+		return nil, nil
+	}
+
+	stored, err := dataStore.GetTieredFullMatches(ctx, benchmarkMatchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load benchmark matches: %w", err)
+	}
+
+	matches := make([]analysis.BenchmarkMatch, len(stored))
+	for i, m := range stored {
+		matches[i] = analysis.BenchmarkMatch{Tier: m.Tier, Match: m.Match}
+	}
+	return analysis.BuildBenchmarks(matches), nil
+}
+
 func createLLMClient() (coaching.LLMClient, error) {
 	key := coachLLMKey
 	if key == "" {
@@ -219,11 +276,14 @@ func createLLMClient() (coaching.LLMClient, error) {
 
 func init() {
 	coachCmd.Flags().StringVar(&coachRiotID, "riot-id", "", "Riot ID (format: gameName#tagLine, e.g., Faker#KR1)")
-	coachCmd.Flags().IntVar(&coachMatchCount, "match-count", 10, "Number of recent matches to analyze")
+	coachCmd.Flags().IntVar(&coachMatchCount, "match-count", 10, "Number of recent matches to analyze (at most 100)")
 	coachCmd.Flags().StringVar(&coachProvider, "provider", "claude", "LLM provider (claude, openai)")
 	coachCmd.Flags().StringVar(&coachLLMKey, "llm-key", "", "LLM API key (or set ANTHROPIC_API_KEY/OPENAI_API_KEY env var)")
 	coachCmd.Flags().StringVar(&coachModel, "model", "", "LLM model (defaults based on provider)")
 	coachCmd.Flags().Int64Var(&coachMaxTokens, "max-tokens", 0, "Max response tokens (default: provider default)")
 	coachCmd.Flags().Float64Var(&coachTemperature, "temperature", 0, "LLM temperature (default: provider default)")
+	coachCmd.Flags().IntVar(&coachConcurrency, "concurrency", riot.DefaultFetchConcurrency, "Number of matches to fetch in parallel")
+	coachCmd.Flags().StringVar(&coachRules, "rules", "", "JSON file of insight rules replacing the built-in ones")
+	coachCmd.Flags().StringVar(&coachQueue, "queue", models.QueueGroupSolo, "Queue to coach (solo, flex, normal, aram, or all for every Summoner's Rift queue)")
 	rootCmd.AddCommand(coachCmd)
 }
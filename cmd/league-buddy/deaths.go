@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/HatiCode/league-buddy/internal/analysis"
+	"github.com/HatiCode/league-buddy/internal/models"
+	"github.com/HatiCode/league-buddy/internal/riot"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deathsRiotID      string
+	deathsMatchCount  int
+	deathsFormat      string
+	deathsConcurrency int
+)
+
+// Heatmap size in characters; terminal cells are about twice as tall as wide.
+const (
+	heatmapCols = 48
+	heatmapRows = 24
+)
+
+// heatmapShades goes from no deaths to the most deaths in a single cell.
+const heatmapShades = " .:-=+*#%@"
+
+var deathsCmd = &cobra.Command{
+	Use:   "deaths",
+	Short: "Show where and how you die",
+	Long:  `Classify deaths in recent ranked matches by map zone, by solo vs. outnumbered, and by shutdown gold given up. Use --format map for an ASCII heatmap of death locations.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if deathsRiotID == "" {
+			return fmt.Errorf("--riot-id is required (format: gameName#tagLine)")
+		}
+		if deathsFormat != "json" && deathsFormat != "map" {
+			return fmt.Errorf("unsupported format: %q (use json or map)", deathsFormat)
+		}
+		if err := validateMatchCount(deathsMatchCount); err != nil {
+			return err
+		}
+
+		parts := strings.SplitN(deathsRiotID, "#", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid Riot ID format, expected gameName#tagLine")
+		}
+		gameName, tagLine := parts[0], parts[1]
+
+		ctx := context.Background()
+
+		account, err := riotClient.GetAccountByRiotID(ctx, region, gameName, tagLine)
+		if err != nil {
+			return fmt.Errorf("failed to get account: %w", err)
+		}
+
+		matchIDs, err := riotClient.GetMatchIDs(ctx, platform, account.PUUID, riot.MatchIDsOptions{Count: deathsMatchCount, Queue: models.QueueIDRankedSolo})
+		if err != nil {
+			return fmt.Errorf("failed to get match IDs: %w", err)
+		}
+		if len(matchIDs) == 0 {
+			return fmt.Errorf("no matches found for this summoner")
+		}
+
+		matches, timelines := fetchMatchesWithTimelines(ctx, cmd, matchIDs, deathsConcurrency)
+		if len(matches) == 0 {
+			return fmt.Errorf("failed to fetch any match details")
+		}
+
+		playerAnalysis, err := analysis.AnalyzePlayer(analysis.PlayerAnalysisParams{
+			PUUID:     account.PUUID,
+			GameName:  account.GameName,
+			TagLine:   account.TagLine,
+			Matches:   matches,
+			Timelines: timelines,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to analyze matches: %w", err)
+		}
+		if playerAnalysis.Deaths == nil {
+			return fmt.Errorf("no match timelines available for death analysis")
+		}
+
+		if deathsFormat == "map" {
+			renderDeathMap(playerAnalysis)
+			return nil
+		}
+
+		var deaths []analysis.DeathEvent
+		for _, m := range playerAnalysis.Matches {
+			if m.Deaths != nil {
+				deaths = append(deaths, m.Deaths.Deaths...)
+			}
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Summary *analysis.DeathSummary `json:"summary"`
+			Deaths  []analysis.DeathEvent  `json:"deaths"`
+		}{
+			Summary: playerAnalysis.Deaths,
+			Deaths:  deaths,
+		})
+	},
+}
+
+func renderDeathMap(a *analysis.PlayerAnalysis) {
+	var positions []models.Position
+	for _, m := range a.Matches {
+		if m.Deaths == nil {
+			continue
+		}
+		for _, d := range m.Deaths.Deaths {
+			if d.Zone != "" {
+				positions = append(positions, d.Position)
+			}
+		}
+	}
+
+	grid := analysis.DeathHeatmap(positions, heatmapCols, heatmapRows)
+	peak := 0
+	for _, row := range grid {
+		for _, n := range row {
+			peak = max(peak, n)
+		}
+	}
+
+	s := a.Deaths
+	fmt.Printf("Deaths across %d games (red base top-right, blue base bottom-left)\n", s.GamesWithTimeline)
+	fmt.Println("+" + strings.Repeat("-", heatmapCols) + "+")
+	for _, row := range grid {
+		var line strings.Builder
+		for _, n := range row {
+			line.WriteByte(heatmapShade(n, peak))
+		}
+		fmt.Println("|" + line.String() + "|")
+	}
+	fmt.Println("+" + strings.Repeat("-", heatmapCols) + "+")
+
+	fmt.Printf("\n%d deaths: %d solo, %d outnumbered, %d to turrets/minions/monsters, %d shutdowns (%d gold)\n",
+		s.TotalDeaths, s.SoloDeaths, s.OutnumberedDeaths, s.ExecutedDeaths, s.ShutdownsGiven, s.ShutdownGold)
+	for _, z := range s.ByZone {
+		fmt.Printf("  %-13s %3d  %3.0f%%\n", z.Zone, z.Deaths, z.Share*100)
+	}
+}
+
+func heatmapShade(n, peak int) byte {
+	if n == 0 || peak == 0 {
+		return heatmapShades[0]
+	}
+	last := len(heatmapShades) - 1
+	return heatmapShades[(n*last+peak-1)/peak]
+}
+
+func init() {
+	deathsCmd.Flags().StringVar(&deathsRiotID, "riot-id", "", "Riot ID (format: gameName#tagLine, e.g., Faker#KR1)")
+	deathsCmd.Flags().IntVar(&deathsMatchCount, "match-count", 20, "Number of recent matches to analyze (at most 100)")
+	deathsCmd.Flags().StringVar(&deathsFormat, "format", "json", "Output format (json, map)")
+	deathsCmd.Flags().IntVar(&deathsConcurrency, "concurrency", riot.DefaultFetchConcurrency, "Number of matches to fetch in parallel")
+	rootCmd.AddCommand(deathsCmd)
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/HatiCode/league-buddy/internal/analysis"
+	"github.com/HatiCode/league-buddy/internal/coaching"
+	"github.com/HatiCode/league-buddy/internal/models"
+	"github.com/HatiCode/league-buddy/internal/riot"
+	"github.com/spf13/cobra"
+)
+
+var (
+	liveRiotID string
+	liveFormat string
+	livePlan   bool
+)
+
+var liveCmd = &cobra.Command{
+	Use:   "live",
+	Short: "Scout the players in your current game",
+	Long: `Look up the game a player is in and print a scouting report on all 10 participants:
+rank, hot streak, main role and, from stored matches, their record and habits on the champion
+they picked. Riot only exposes games once loading starts, not champion select.
+Use --plan to ask the LLM for a game plan (uses the same --provider and --llm-key as coach).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if liveRiotID == "" {
+			return fmt.Errorf("--riot-id is required (format: gameName#tagLine)")
+		}
+		if liveFormat != "text" && liveFormat != "json" {
+			return fmt.Errorf("unsupported format: %q (use text or json)", liveFormat)
+		}
+
+		parts := strings.SplitN(liveRiotID, "#", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid Riot ID format, expected gameName#tagLine")
+		}
+		gameName, tagLine := parts[0], parts[1]
+
+		ctx := context.Background()
+
+		account, err := riotClient.GetAccountByRiotID(ctx, region, gameName, tagLine)
+		if err != nil {
+			return fmt.Errorf("failed to get account: %w", err)
+		}
+
+		game, err := riotClient.GetActiveGame(ctx, platform, account.PUUID)
+		if errors.Is(err, riot.ErrNotFound) {
+			return fmt.Errorf("%s#%s is not in a game", account.GameName, account.TagLine)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get active game: %w", err)
+		}
+
+		inputs := make([]analysis.ScoutInput, 0, len(game.Participants))
+		for _, p := range game.Participants {
+			in := analysis.ScoutInput{Participant: p}
+			if p.Bot || p.PUUID == "" {
+				inputs = append(inputs, in)
+				continue
+			}
+
+			entries, err := riotClient.GetLeagueEntries(ctx, platform, p.PUUID)
+			if err != nil {
+				cmd.PrintErrf("Warning: failed to get league entries for %s: %v\n", p.RiotID, err)
+			}
+			for i := range entries {
+				if entries[i].QueueType == models.QueueRankedSolo {
+					in.League = &entries[i]
+					break
+				}
+			}
+
+			if dataStore != nil {
+				matches, err := dataStore.GetFullMatchesForPUUID(ctx, p.PUUID)
+				if err != nil {
+					return fmt.Errorf("failed to get stored matches: %w", err)
+				}
+				in.Matches = matches
+			}
+			inputs = append(inputs, in)
+		}
+
+		report := analysis.BuildScoutingReport(game, account.PUUID, inputs)
+
+		var plan string
+		if livePlan {
+			llmClient, err := createLLMClient()
+			if err != nil {
+				return err
+			}
+			plan, err = coaching.NewService(llmClient, nil).GamePlan(ctx, report)
+			if err != nil {
+				return fmt.Errorf("game plan failed: %w", err)
+			}
+		}
+
+		if liveFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(struct {
+				Report *analysis.ScoutingReport `json:"report"`
+				Plan   string                   `json:"plan,omitempty"`
+			}{
+				Report: report,
+				Plan:   plan,
+			})
+		}
+
+		renderScoutingReport(report)
+		if dataStore == nil {
+			fmt.Println("\nNo database configured: champion records and habits need stored matches (--db-url).")
+		}
+		if plan != "" {
+			fmt.Printf("\nGame plan\n%s\n", plan)
+		}
+		return nil
+	},
+}
+
+func renderScoutingReport(r *analysis.ScoutingReport) {
+	fmt.Printf("Live game %d: queue %d (%s), %d:%02d in\n",
+		r.GameID, r.QueueID, r.GameMode, r.GameLength/60, r.GameLength%60)
+
+	renderScoutTeam("Your team", r.Allies)
+	renderScoutTeam("Enemy team", r.Enemies)
+
+	if o := r.LaneOpponent; o != nil {
+		fmt.Printf("\nLane opponent: %s on %s\n", scoutLabel(*o), o.ChampionName)
+		if len(o.Tendencies) == 0 {
+			fmt.Println("  No notable tendencies on record")
+		}
+		for _, t := range o.Tendencies {
+			fmt.Printf("  - %s\n", t)
+		}
+	}
+}
+
+func renderScoutTeam(label string, scouts []analysis.PlayerScout) {
+	fmt.Printf("\n%s\n", label)
+	for _, s := range scouts {
+		rank := "unranked"
+		if s.Tier != "" {
+			rank = fmt.Sprintf("%s %s %d LP, %dW %dL", s.Tier, s.Rank, s.LeaguePoints, s.Wins, s.Losses)
+		}
+		role := s.MainRole
+		if role == "" {
+			role = "?"
+		}
+		fmt.Printf("  %-24s %-14s %-8s %s\n", scoutLabel(s), s.ChampionName, role, rank)
+		if s.ChampionGames > 0 {
+			fmt.Printf("  %-24s %d games on champion, %.0f%% WR, %.1f KDA\n", "", s.ChampionGames, s.ChampionWinRate*100, s.AvgKDA)
+		}
+		for _, t := range s.Tendencies {
+			fmt.Printf("  %-24s - %s\n", "", t)
+		}
+	}
+}
+
+func scoutLabel(s analysis.PlayerScout) string {
+	if s.RiotID == "" {
+		return "Unknown player"
+	}
+	return s.RiotID
+}
+
+func init() {
+	liveCmd.Flags().StringVar(&liveRiotID, "riot-id", "", "Riot ID (format: gameName#tagLine, e.g., Faker#KR1)")
+	liveCmd.Flags().StringVar(&liveFormat, "format", "text", "Output format (text, json)")
+	liveCmd.Flags().BoolVar(&livePlan, "plan", false, "Ask the LLM for a game plan")
+	// The game plan shares the coach command's LLM settings.
+	liveCmd.Flags().StringVar(&coachProvider, "provider", "claude", "LLM provider (claude, openai)")
+	liveCmd.Flags().StringVar(&coachLLMKey, "llm-key", "", "LLM API key (or set ANTHROPIC_API_KEY/OPENAI_API_KEY env var)")
+	liveCmd.Flags().StringVar(&coachModel, "model", "", "LLM model (defaults based on provider)")
+	rootCmd.AddCommand(liveCmd)
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/HatiCode/league-buddy/internal/riot"
 	"github.com/HatiCode/league-buddy/internal/store"
 	"github.com/spf13/cobra"
 )
@@ -42,7 +43,7 @@ var matchCmd = &cobra.Command{
 
 		// Step 2: Get latest match ID
 		matchIDsStart := time.Now()
-		matchIDs, err := riotClient.GetMatchIDs(ctx, platform, account.PUUID, 1, 0)
+		matchIDs, err := riotClient.GetMatchIDs(ctx, platform, account.PUUID, riot.MatchIDsOptions{Count: 1})
 		if err != nil {
 			return fmt.Errorf("failed to get match IDs: %w", err)
 		}
@@ -53,7 +54,7 @@ var matchCmd = &cobra.Command{
 
 		// Step 3: Get match details
 		matchStart := time.Now()
-		match, err := riotClient.GetMatch(ctx, platform, matchIDs[0])
+		match, err := matchFetcher.GetMatch(ctx, platform, matchIDs[0])
 		if err != nil {
 			return fmt.Errorf("failed to get match: %w", err)
 		}
@@ -67,6 +68,16 @@ var matchCmd = &cobra.Command{
 			if err := dataStore.SaveMatch(ctx, matchEntity, participants); err != nil {
 				return fmt.Errorf("failed to save match: %w", err)
 			}
+			if err := dataStore.SaveFullMatch(ctx, match); err != nil {
+				return fmt.Errorf("failed to save full match: %w", err)
+			}
+			timeline, err := matchFetcher.GetMatchTimeline(ctx, platform, matchIDs[0])
+			if err != nil {
+				return fmt.Errorf("failed to get timeline: %w", err)
+			}
+			if err := dataStore.SaveTimeline(ctx, matchIDs[0], timeline); err != nil {
+				return fmt.Errorf("failed to save timeline: %w", err)
+			}
 		}
 
 		// Output combined info
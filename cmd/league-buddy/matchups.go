@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/HatiCode/league-buddy/internal/analysis"
+	"github.com/HatiCode/league-buddy/internal/models"
+	"github.com/HatiCode/league-buddy/internal/riot"
+	"github.com/spf13/cobra"
+)
+
+var (
+	matchupsRiotID      string
+	matchupsMatchCount  int
+	matchupsFormat      string
+	matchupsMinGames    int
+	matchupsConcurrency int
+)
+
+var matchupsCmd = &cobra.Command{
+	Use:   "matchups",
+	Short: "Show how you perform against each lane opponent",
+	Long:  `Aggregate recent ranked matches per (your champion, enemy laner) pair: record, win rate, average gold/CS/XP diffs at 10 and 15 minutes, and lane kills and deaths.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if matchupsRiotID == "" {
+			return fmt.Errorf("--riot-id is required (format: gameName#tagLine)")
+		}
+		if matchupsFormat != "json" && matchupsFormat != "table" {
+			return fmt.Errorf("unsupported format: %q (use json or table)", matchupsFormat)
+		}
+		if err := validateMatchCount(matchupsMatchCount); err != nil {
+			return err
+		}
+
+		parts := strings.SplitN(matchupsRiotID, "#", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid Riot ID format, expected gameName#tagLine")
+		}
+		gameName, tagLine := parts[0], parts[1]
+
+		ctx := context.Background()
+
+		account, err := riotClient.GetAccountByRiotID(ctx, region, gameName, tagLine)
+		if err != nil {
+			return fmt.Errorf("failed to get account: %w", err)
+		}
+
+		matchIDs, err := riotClient.GetMatchIDs(ctx, platform, account.PUUID, riot.MatchIDsOptions{Count: matchupsMatchCount, Queue: models.QueueIDRankedSolo})
+		if err != nil {
+			return fmt.Errorf("failed to get match IDs: %w", err)
+		}
+		if len(matchIDs) == 0 {
+			return fmt.Errorf("no matches found for this summoner")
+		}
+
+		matches, timelines := fetchMatchesWithTimelines(ctx, cmd, matchIDs, matchupsConcurrency)
+		if len(matches) == 0 {
+			return fmt.Errorf("failed to fetch any match details")
+		}
+
+		playerAnalysis, err := analysis.AnalyzePlayer(analysis.PlayerAnalysisParams{
+			PUUID:     account.PUUID,
+			GameName:  account.GameName,
+			TagLine:   account.TagLine,
+			Matches:   matches,
+			Timelines: timelines,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to analyze matches: %w", err)
+		}
+
+		var matchups []analysis.MatchupStats
+		for _, m := range playerAnalysis.Matchups {
+			if m.GamesPlayed >= matchupsMinGames {
+				matchups = append(matchups, m)
+			}
+		}
+
+		if matchupsFormat == "table" {
+			return renderMatchupsTable(playerAnalysis.TotalMatches, matchups)
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			PUUID        string                  `json:"puuid"`
+			TotalMatches int                     `json:"totalMatches"`
+			Matchups     []analysis.MatchupStats `json:"matchups"`
+		}{
+			PUUID:        account.PUUID,
+			TotalMatches: playerAnalysis.TotalMatches,
+			Matchups:     matchups,
+		})
+	},
+}
+
+func renderMatchupsTable(totalMatches int, matchups []analysis.MatchupStats) error {
+	fmt.Printf("Matchups across %d matches\n\n", totalMatches)
+	if len(matchups) == 0 {
+		fmt.Println("No matchups found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MATCHUP\tGAMES\tWR\tGOLD@10\tGOLD@15\tCS@10\tCS@15\tXP@10\tXP@15\tLANE K/D")
+	for _, m := range matchups {
+		if m.GamesWithTimeline == 0 {
+			fmt.Fprintf(w, "%s vs %s\t%d\t%.0f%%\t-\t-\t-\t-\t-\t-\t-\n",
+				m.ChampionName, m.OpponentChampionName, m.GamesPlayed, m.WinRate*100)
+			continue
+		}
+		fmt.Fprintf(w, "%s vs %s\t%d\t%.0f%%\t%+.0f\t%+.0f\t%+.1f\t%+.1f\t%+.0f\t%+.0f\t%d/%d\n",
+			m.ChampionName, m.OpponentChampionName, m.GamesPlayed, m.WinRate*100,
+			m.AvgGoldDiffAt10, m.AvgGoldDiffAt15, m.AvgCSDiffAt10, m.AvgCSDiffAt15,
+			m.AvgXPDiffAt10, m.AvgXPDiffAt15, m.LaneKills, m.LaneDeaths)
+	}
+	return w.Flush()
+}
+
+func init() {
+	matchupsCmd.Flags().StringVar(&matchupsRiotID, "riot-id", "", "Riot ID (format: gameName#tagLine, e.g., Faker#KR1)")
+	matchupsCmd.Flags().IntVar(&matchupsMatchCount, "match-count", 20, "Number of recent matches to analyze (at most 100)")
+	matchupsCmd.Flags().StringVar(&matchupsFormat, "format", "json", "Output format (json, table)")
+	matchupsCmd.Flags().IntVar(&matchupsMinGames, "min-games", 1, "Minimum games for a matchup to be listed")
+	matchupsCmd.Flags().IntVar(&matchupsConcurrency, "concurrency", riot.DefaultFetchConcurrency, "Number of matches to fetch in parallel")
+	rootCmd.AddCommand(matchupsCmd)
+}
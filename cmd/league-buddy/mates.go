@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/HatiCode/league-buddy/internal/analysis"
+	"github.com/HatiCode/league-buddy/internal/models"
+	"github.com/HatiCode/league-buddy/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	matesRiotID   string
+	matesFormat   string
+	matesMinGames int
+)
+
+var matesCmd = &cobra.Command{
+	Use:   "mates",
+	Short: "Find the teammates you climb best with",
+	Long:  `Scan stored matches for recurring teammates and rank them by games together, win rate together vs. apart, and role pairing. Requires a database connection.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if matesRiotID == "" {
+			return fmt.Errorf("--riot-id is required (format: gameName#tagLine)")
+		}
+		if matesFormat != "json" && matesFormat != "table" {
+			return fmt.Errorf("unsupported format: %q (use json or table)", matesFormat)
+		}
+
+		parts := strings.SplitN(matesRiotID, "#", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid Riot ID format, expected gameName#tagLine")
+		}
+		gameName, tagLine := parts[0], parts[1]
+
+		if dataStore == nil {
+			return fmt.Errorf("database is required for teammate analysis (use --db-url or set DATABASE_URL)")
+		}
+
+		ctx := context.Background()
+
+		account, err := riotClient.GetAccountByRiotID(ctx, region, gameName, tagLine)
+		if err != nil {
+			return fmt.Errorf("failed to get account: %w", err)
+		}
+
+		matches, err := loadStoredMatches(ctx, account.PUUID)
+		if err != nil {
+			return err
+		}
+		if len(matches) == 0 {
+			cmd.Println("No stored matches found. Save matches with 'league-buddy get match --save' first.")
+			return nil
+		}
+
+		report, err := analysis.AnalyzeMates(matches, account.PUUID, matesMinGames)
+		if err != nil {
+			return fmt.Errorf("failed to analyze teammates: %w", err)
+		}
+
+		if matesFormat == "table" {
+			return renderMatesTable(report)
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	},
+}
+
+// loadStoredMatches loads every stored match the player took part in, preferring the
+// full stored payload and rebuilding from the match tables when there is none.
+func loadStoredMatches(ctx context.Context, puuid string) ([]models.Match, error) {
+	stored, err := dataStore.GetMatchesForPUUID(ctx, puuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored matches: %w", err)
+	}
+
+	full, err := dataStore.GetFullMatchesForPUUID(ctx, puuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get full matches: %w", err)
+	}
+	fullByID := make(map[string]models.Match, len(full))
+	for _, m := range full {
+		fullByID[m.Metadata.MatchID] = m
+	}
+
+	matches := make([]models.Match, 0, len(stored))
+	for i := range stored {
+		if m, ok := fullByID[stored[i].MatchID]; ok {
+			matches = append(matches, m)
+			continue
+		}
+		participants, err := dataStore.GetParticipants(ctx, stored[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get participants for %s: %w", stored[i].MatchID, err)
+		}
+		matches = append(matches, *store.MatchToAPI(&stored[i], participants))
+	}
+	return matches, nil
+}
+
+func renderMatesTable(report *analysis.MatesReport) error {
+	fmt.Printf("Teammates across %d matches (overall %.0f%% WR)\n\n", report.TotalMatches, report.OverallWinRate*100)
+	if len(report.Mates) == 0 {
+		fmt.Println("No recurring teammates found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MATE\tGAMES\tWR TOGETHER\tWR APART\tDELTA\tBEST PAIRING")
+	for _, m := range report.Mates {
+		name := m.GameName
+		if m.TagLine != "" {
+			name += "#" + m.TagLine
+		}
+		pairing := "-"
+		if m.BestPairing != nil {
+			pairing = fmt.Sprintf("%s+%s (%d games, %.0f%%)",
+				m.BestPairing.PlayerRole, m.BestPairing.MateRole, m.BestPairing.Games, m.BestPairing.WinRate*100)
+		}
+		apart, delta := "-", "-"
+		if m.GamesApart > 0 {
+			apart, delta = fmt.Sprintf("%.0f%%", m.WinRateApart*100), fmt.Sprintf("%+.0f%%", m.WinRateDelta*100)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%.0f%%\t%s\t%s\t%s\n",
+			name, m.GamesTogether, m.WinRateTogether*100, apart, delta, pairing)
+	}
+	return w.Flush()
+}
+
+func init() {
+	matesCmd.Flags().StringVar(&matesRiotID, "riot-id", "", "Riot ID (format: gameName#tagLine, e.g., Faker#KR1)")
+	matesCmd.Flags().StringVar(&matesFormat, "format", "json", "Output format (json, table)")
+	matesCmd.Flags().IntVar(&matesMinGames, "min-games", analysis.DefaultMinGamesTogether, "Minimum games together for a teammate to be listed")
+	rootCmd.AddCommand(matesCmd)
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/HatiCode/league-buddy/internal/coaching"
+	"github.com/HatiCode/league-buddy/internal/models"
 	"github.com/guptarohit/asciigraph"
 	"github.com/spf13/cobra"
 )
@@ -15,12 +16,13 @@ import (
 var (
 	progressRiotID string
 	progressGraph  bool
+	progressQueue  string
 )
 
 var progressCmd = &cobra.Command{
 	Use:   "progress",
 	Short: "Show coaching progress trend over time",
-	Long:  `Load all coaching sessions for a player and output trend data as JSON or ASCII graphs. Requires a database connection.`,
+	Long:  `Load all coaching sessions for a player in one queue and output trend data as JSON or ASCII graphs. Requires a database connection.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if progressRiotID == "" {
 			return fmt.Errorf("--riot-id is required (format: gameName#tagLine)")
@@ -32,6 +34,11 @@ var progressCmd = &cobra.Command{
 		}
 		gameName, tagLine := parts[0], parts[1]
 
+		queue, err := models.QueueGroupByName(progressQueue)
+		if err != nil {
+			return err
+		}
+
 		if dataStore == nil {
 			return fmt.Errorf("database is required for progress tracking (use --db-url or set DATABASE_URL)")
 		}
@@ -44,13 +51,13 @@ var progressCmd = &cobra.Command{
 		}
 
 		svc := coaching.NewService(nil, dataStore)
-		progress, err := svc.GetProgress(ctx, account.PUUID)
+		progress, err := svc.GetProgress(ctx, account.PUUID, queue.Name)
 		if err != nil {
 			return fmt.Errorf("failed to get progress: %w", err)
 		}
 
 		if progress.Sessions == 0 {
-			cmd.Println("No coaching sessions found. Run 'league-buddy coach' first.")
+			cmd.Printf("No %s coaching sessions found. Run 'league-buddy coach --queue %s' first.\n", queue.Label, queue.Name)
 			return nil
 		}
 
@@ -124,10 +131,61 @@ func renderGraphs(progress *coaching.PlayerProgress) {
 		fmt.Printf("  %s: "+m.format+" -> "+m.format+" (%s"+m.format+")\n\n",
 			m.name, first, last, direction, delta)
 	}
+
+	renderGameFlow(progress)
+}
+
+// maxGameFlowGraphs caps how many of the latest session's gold curves are drawn.
+const maxGameFlowGraphs = 3
+
+func renderGameFlow(progress *coaching.PlayerProgress) {
+	for _, tp := range progress.Trend {
+		if tp.GameFlow == nil {
+			continue
+		}
+		fmt.Printf("Game flow (%s): threw %d/%d games with a 3k+ lead, came back in %d/%d games 3k+ behind\n",
+			tp.SessionDate.Format("Jan 02"), tp.GameFlow.Throws, tp.GameFlow.GamesAhead,
+			tp.GameFlow.Comebacks, tp.GameFlow.GamesBehind)
+	}
+
+	games := progress.RecentGames
+	if len(games) > maxGameFlowGraphs {
+		games = games[:maxGameFlowGraphs]
+	}
+	for _, g := range games {
+		if len(g.Curve.Points) < 2 {
+			continue
+		}
+		values := make([]float64, len(g.Curve.Points))
+		for i, p := range g.Curve.Points {
+			values[i] = float64(p.TeamGoldDiff)
+		}
+
+		result := "Loss"
+		if g.Win {
+			result = "Win"
+		}
+		caption := fmt.Sprintf("%s %s (%s): peak lead %+d at %d min, worst deficit %+d at %d min",
+			g.ChampionName, g.MatchID, result, g.Curve.MaxLead, g.Curve.MaxLeadAt, g.Curve.MaxDeficit, g.Curve.MaxDeficitAt)
+		if g.Curve.Throw {
+			caption += " -- thrown lead"
+		} else if g.Curve.Comeback {
+			caption += " -- comeback"
+		}
+
+		fmt.Println()
+		fmt.Println("Team gold diff per minute")
+		fmt.Println(asciigraph.Plot(values,
+			asciigraph.Height(8),
+			asciigraph.Width(40),
+			asciigraph.Caption(caption),
+		))
+	}
 }
 
 func init() {
 	progressCmd.Flags().StringVar(&progressRiotID, "riot-id", "", "Riot ID (format: gameName#tagLine, e.g., Faker#KR1)")
 	progressCmd.Flags().BoolVar(&progressGraph, "graph", false, "Render ASCII graphs instead of JSON")
+	progressCmd.Flags().StringVar(&progressQueue, "queue", models.QueueGroupSolo, "Queue whose coaching sessions to show (solo, flex, normal, aram, all)")
 	rootCmd.AddCommand(progressCmd)
 }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+)
+
+// replayFixtures holds Riot API responses for Faker#KR1 and one ranked match,
+// so commands run end to end without network access.
+const replayFixtures = "testdata/replay"
+
+// runReplay runs the CLI against the recorded fixtures and returns its stdout.
+func runReplay(t *testing.T, args ...string) []byte {
+	t.Helper()
+	t.Setenv("RIOT_API_KEY", "")
+	t.Setenv("DATABASE_URL", "")
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- data
+	}()
+
+	rootCmd.SetArgs(append([]string{"--replay", replayFixtures, "--platform", "kr"}, args...))
+	runErr := rootCmd.Execute()
+	_ = w.Close()
+	data := <-out
+	if runErr != nil {
+		t.Fatalf("%v: %v", args, runErr)
+	}
+	return data
+}
+
+func TestReplay_GetMatch(t *testing.T) {
+	data := runReplay(t, "get", "match", "--riot-id", "Faker#KR1")
+
+	var output struct {
+		MatchID string `json:"matchId"`
+		Match   struct {
+			Info struct {
+				QueueID      int `json:"queueId"`
+				Participants []struct {
+					PUUID        string `json:"puuid"`
+					ChampionName string `json:"championName"`
+				} `json:"participants"`
+			} `json:"info"`
+		} `json:"match"`
+	}
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, data)
+	}
+	if output.MatchID != "KR_7000000001" || output.Match.Info.QueueID != 420 {
+		t.Errorf("unexpected match %s in queue %d", output.MatchID, output.Match.Info.QueueID)
+	}
+	if len(output.Match.Info.Participants) != 10 || output.Match.Info.Participants[2].ChampionName != "Ahri" {
+		t.Errorf("expected 10 participants with Ahri third, got %+v", output.Match.Info.Participants)
+	}
+}
+
+func TestReplay_Matchups(t *testing.T) {
+	data := runReplay(t, "matchups", "--riot-id", "Faker#KR1", "--match-count", "1")
+
+	var output struct {
+		PUUID        string `json:"puuid"`
+		TotalMatches int    `json:"totalMatches"`
+		Matchups     []struct {
+			ChampionName         string  `json:"championName"`
+			OpponentChampionName string  `json:"opponentChampionName"`
+			GamesWithTimeline    int     `json:"gamesWithTimeline"`
+			AvgGoldDiffAt10      float64 `json:"avgGoldDiffAt10"`
+		} `json:"matchups"`
+	}
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, data)
+	}
+	if output.PUUID != "puuid-faker" || output.TotalMatches != 1 || len(output.Matchups) != 1 {
+		t.Fatalf("unexpected matchups output: %s", data)
+	}
+	m := output.Matchups[0]
+	if m.ChampionName != "Ahri" || m.OpponentChampionName != "Zed" || m.GamesWithTimeline != 1 {
+		t.Errorf("expected Ahri vs Zed with a timeline, got %+v", m)
+	}
+	if m.AvgGoldDiffAt10 <= 0 {
+		t.Errorf("expected a gold lead at 10 from the recorded timeline, got %.0f", m.AvgGoldDiffAt10)
+	}
+}
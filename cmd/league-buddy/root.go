@@ -2,12 +2,15 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"os"
 	"time"
 
 	"github.com/HatiCode/league-buddy/internal/riot"
 	"github.com/HatiCode/league-buddy/internal/store"
 	"github.com/HatiCode/league-buddy/pkg/ratelimit"
+	"github.com/HatiCode/league-buddy/pkg/replay"
 	"github.com/spf13/cobra"
 )
 
@@ -17,28 +20,71 @@ var (
 	region   string // Derived from platform (americas, asia, europe, sea)
 	dbURL    string
 
+	maxRetries int
+	cacheDir   string
+	noCache    bool
+	recordDir  string
+	replayDir  string
+
 	riotClient *riot.APIClient
 	dataStore  store.Store
+
+	// matchFetcher serves matches and timelines, through the match cache unless disabled.
+	matchFetcher riot.MatchFetcher
 )
 
+// riotTimeout matches the Riot client's default request timeout.
+const riotTimeout = 10 * time.Second
+
 var rootCmd = &cobra.Command{
 	Use:   "league-buddy",
 	Short: "League Buddy CLI - Get insights on your League of Legends gameplay",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if recordDir != "" && replayDir != "" {
+			return fmt.Errorf("--record and --replay cannot be used together")
+		}
+
 		if apiKey == "" {
 			apiKey = os.Getenv("RIOT_API_KEY")
 		}
-		if apiKey == "" {
+		if apiKey == "" && replayDir == "" {
 			cmd.PrintErrln("Error: RIOT_API_KEY is required (use --api-key or set RIOT_API_KEY env var)")
 			os.Exit(1)
 		}
 
-		limiter := ratelimit.NewLimiter(
-			ratelimit.WithLimit(500, 10*time.Second),
-			ratelimit.WithLimit(30000, 10*time.Minute),
-		)
+		// Start with development key limits; the client raises them from
+		// X-App-Rate-Limit headers once Riot reports the key's real limits.
+		// Riot enforces them per routing value, so euw1 and europe get separate buckets.
+		appLimits := []ratelimit.Limit{
+			{Count: 20, Window: time.Second},
+			{Count: 100, Window: 2 * time.Minute},
+		}
 
-		riotClient = riot.NewClient(apiKey, riot.WithRateLimiter(limiter))
+		clientOpts := []riot.ClientOption{
+			riot.WithEndpointRateLimits(appLimits, riot.DefaultEndpointFamilies),
+			riot.WithMaxRetries(maxRetries),
+		}
+
+		// Recording and replaying bypass the match cache so every response goes
+		// through the fixtures.
+		switch {
+		case recordDir != "":
+			recorder, err := replay.NewRecorder(recordDir, nil)
+			if err != nil {
+				return err
+			}
+			clientOpts = append([]riot.ClientOption{riot.WithHTTPClient(&http.Client{Timeout: riotTimeout, Transport: recorder})}, clientOpts...)
+			noCache = true
+		case replayDir != "":
+			replayer, err := replay.NewReplayer(replayDir)
+			if err != nil {
+				return err
+			}
+			clientOpts = []riot.ClientOption{riot.WithHTTPClient(&http.Client{Timeout: riotTimeout, Transport: replayer})}
+			noCache = true
+		}
+
+		riotClient = riot.NewClient(apiKey, clientOpts...)
 
 		// Default region from platform if not specified
 		if region == "" {
@@ -53,20 +99,65 @@ var rootCmd = &cobra.Command{
 			dbURL = os.Getenv("DATABASE_URL")
 		}
 		if dbURL != "" {
-			var err error
-			dataStore, err = store.NewPostgresStore(context.Background(), dbURL)
+			// Assign through a typed variable so a failed connection leaves dataStore nil.
+			st, err := store.NewPostgresStore(context.Background(), dbURL)
 			if err != nil {
 				cmd.PrintErrf("Warning: failed to connect to database: %v\n", err)
+			} else {
+				dataStore = st
 			}
 		}
 
+		matchFetcher = newMatchFetcher(cmd)
+
 		return nil
 	},
 }
 
+// validateMatchCount checks a --match-count flag against the largest page of
+// match IDs Riot returns.
+func validateMatchCount(count int) error {
+	if count < 1 || count > riot.MaxMatchIDsPerPage {
+		return fmt.Errorf("--match-count must be between 1 and %d", riot.MaxMatchIDsPerPage)
+	}
+	return nil
+}
+
+// newMatchFetcher wraps the Riot client with a read-through match cache, kept in
+// the database when connected and on disk otherwise.
+func newMatchFetcher(cmd *cobra.Command) riot.MatchFetcher {
+	if noCache {
+		return riotClient
+	}
+	if dataStore != nil {
+		return riot.NewCachingFetcher(riotClient, dataStore)
+	}
+
+	dir := cacheDir
+	if dir == "" {
+		d, err := riot.DefaultCacheDir()
+		if err != nil {
+			cmd.PrintErrf("Warning: match cache disabled: %v\n", err)
+			return riotClient
+		}
+		dir = d
+	}
+	cache, err := riot.NewFileCache(dir)
+	if err != nil {
+		cmd.PrintErrf("Warning: match cache disabled: %v\n", err)
+		return riotClient
+	}
+	return riot.NewCachingFetcher(riotClient, cache)
+}
+
 func init() {
 	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "Riot API key (or set RIOT_API_KEY env var)")
 	rootCmd.PersistentFlags().StringVar(&platform, "platform", "euw1", "Platform for summoner data (euw1, na1, kr, etc.)")
 	rootCmd.PersistentFlags().StringVar(&region, "region", "", "Region for account lookup (americas, asia, europe). Defaults based on platform.")
 	rootCmd.PersistentFlags().StringVar(&dbURL, "db-url", "", "PostgreSQL connection URL (or set DATABASE_URL env var)")
+	rootCmd.PersistentFlags().IntVar(&maxRetries, "max-retries", 3, "Max retries for rate limited Riot API requests")
+	rootCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", "", "Directory for cached match data when no database is configured (default: user cache dir)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Always fetch matches and timelines from the Riot API")
+	rootCmd.PersistentFlags().StringVar(&recordDir, "record", "", "Record every Riot API response to this directory")
+	rootCmd.PersistentFlags().StringVar(&replayDir, "replay", "", "Serve Riot API responses from a directory recorded with --record, without network access")
 }
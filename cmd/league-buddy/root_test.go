@@ -0,0 +1,20 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/riot"
+)
+
+func TestValidateMatchCount(t *testing.T) {
+	for _, count := range []int{1, riot.MaxMatchIDsPerPage} {
+		if err := validateMatchCount(count); err != nil {
+			t.Errorf("count %d: unexpected error: %v", count, err)
+		}
+	}
+	for _, count := range []int{0, -1, riot.MaxMatchIDsPerPage + 1} {
+		if err := validateMatchCount(count); err == nil {
+			t.Errorf("count %d: expected an error", count)
+		}
+	}
+}
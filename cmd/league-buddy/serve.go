@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/HatiCode/league-buddy/internal/analysis"
+	"github.com/HatiCode/league-buddy/internal/coaching"
+	"github.com/HatiCode/league-buddy/internal/riot"
+	"github.com/HatiCode/league-buddy/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr        string
+	serveConcurrency int
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve analysis and coaching over an HTTP API",
+	Long: `Run a REST API exposing player analysis, coaching, progress and match data:
+
+  GET  /players/{riotId}/analysis   ?queue=solo&count=10
+  POST /players/{riotId}/coach      ?queue=solo&count=10
+  GET  /players/{riotId}/progress   ?queue=solo
+  GET  /matches/{id}
+  GET  /matches/{id}/timeline
+
+Riot IDs are gameName#tagLine with the # escaped as %23. Each client IP is rate limited.
+Coaching needs an LLM key (same --provider and --llm-key as coach); progress and
+coaching history need a database (--db-url).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var rules *analysis.RuleSet
+		if coachRules != "" {
+			r, err := analysis.LoadRules(coachRules)
+			if err != nil {
+				return fmt.Errorf("failed to load insight rules: %w", err)
+			}
+			rules = r
+		}
+
+		var llm coaching.LLMClient
+		if client, err := createLLMClient(); err != nil {
+			cmd.PrintErrf("Warning: coaching disabled: %v\n", err)
+		} else {
+			llm = client
+		}
+		if dataStore == nil {
+			cmd.PrintErrln("Warning: no database configured, progress and coaching history are disabled")
+		}
+
+		srv := &http.Server{
+			Addr: serveAddr,
+			Handler: server.New(server.Config{
+				Players:     riotClient,
+				Matches:     matchFetcher,
+				Store:       dataStore,
+				LLM:         llm,
+				Rules:       rules,
+				Platform:    platform,
+				Region:      region,
+				Concurrency: serveConcurrency,
+			}),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- srv.ListenAndServe()
+		}()
+		cmd.Printf("Listening on %s\n", serveAddr)
+
+		select {
+		case err := <-errCh:
+			return fmt.Errorf("failed to serve: %w", err)
+		case <-ctx.Done():
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("failed to shut down: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "Address to listen on")
+	serveCmd.Flags().IntVar(&serveConcurrency, "concurrency", riot.DefaultFetchConcurrency, "Number of matches to fetch in parallel per request")
+	serveCmd.Flags().StringVar(&coachRules, "rules", "", "JSON file of insight rules replacing the built-in ones")
+	// Coaching shares the coach command's LLM settings.
+	serveCmd.Flags().StringVar(&coachProvider, "provider", "claude", "LLM provider (claude, openai)")
+	serveCmd.Flags().StringVar(&coachLLMKey, "llm-key", "", "LLM API key (or set ANTHROPIC_API_KEY/OPENAI_API_KEY env var)")
+	serveCmd.Flags().StringVar(&coachModel, "model", "", "LLM model (defaults based on provider)")
+	rootCmd.AddCommand(serveCmd)
+}
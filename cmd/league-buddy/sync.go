@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+	"github.com/HatiCode/league-buddy/internal/riot"
+	"github.com/HatiCode/league-buddy/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncRiotID      string
+	syncSince       string
+	syncQueue       string
+	syncConcurrency int
+)
+
+// syncBatchSize is how many matches are fetched in parallel before they are
+// stored, so an interrupted sync loses at most one batch.
+const syncBatchSize = 20
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Backfill a player's match history into the database",
+	Long: `Page through every match since --since and store it, with its timeline, in the database.
+Matches already stored for the player are skipped, so an interrupted sync resumes where it left off.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if syncRiotID == "" {
+			return fmt.Errorf("--riot-id is required (format: gameName#tagLine)")
+		}
+		if syncSince == "" {
+			return fmt.Errorf("--since is required (format: YYYY-MM-DD)")
+		}
+		since, err := time.Parse(time.DateOnly, syncSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since date, expected YYYY-MM-DD: %w", err)
+		}
+
+		parts := strings.SplitN(syncRiotID, "#", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid Riot ID format, expected gameName#tagLine")
+		}
+		gameName, tagLine := parts[0], parts[1]
+
+		// Without --queue every queue is synced, ARAM and rotating modes included.
+		var queueIDs []int
+		if syncQueue != "" {
+			queue, err := models.QueueGroupByName(syncQueue)
+			if err != nil {
+				return err
+			}
+			queueIDs = queue.QueueIDs
+		}
+
+		if dataStore == nil {
+			return fmt.Errorf("database is required for sync (use --db-url or set DATABASE_URL)")
+		}
+
+		ctx := context.Background()
+
+		account, err := riotClient.GetAccountByRiotID(ctx, region, gameName, tagLine)
+		if err != nil {
+			return fmt.Errorf("failed to get account: %w", err)
+		}
+
+		summoner, err := ensureSummoner(ctx, account)
+		if err != nil {
+			return err
+		}
+
+		synced, err := dataStore.GetMatchesForSummoner(ctx, summoner.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get synced matches: %w", err)
+		}
+		done := make(map[string]bool, len(synced))
+		for _, m := range synced {
+			done[m.MatchID] = true
+		}
+
+		matchIDs, err := riot.GetAllMatchIDsInQueues(ctx, riotClient, platform, account.PUUID, queueIDs, riot.MatchIDsOptions{
+			StartTime: since,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list match IDs: %w", err)
+		}
+
+		var pending []string
+		for _, id := range matchIDs {
+			if !done[id] {
+				pending = append(pending, id)
+			}
+		}
+		cmd.Printf("Found %d matches since %s, %d already synced\n", len(matchIDs), syncSince, len(matchIDs)-len(pending))
+
+		// Oldest first, so progress is a contiguous prefix of the season.
+		slices.Reverse(pending)
+		for start := 0; start < len(pending); start += syncBatchSize {
+			batch := pending[start:min(start+syncBatchSize, len(pending))]
+			for i, result := range riot.FetchMatchesWithTimelines(ctx, matchFetcher, platform, batch, syncConcurrency) {
+				if err := syncMatch(ctx, summoner.ID, result); err != nil {
+					return fmt.Errorf("stopped after %d of %d matches: %w", start+i, len(pending), err)
+				}
+			}
+		}
+
+		cmd.Printf("Synced %d matches\n", len(pending))
+		return nil
+	},
+}
+
+// ensureSummoner returns the stored summoner for account, creating it if needed.
+func ensureSummoner(ctx context.Context, account *models.Account) (*store.Summoner, error) {
+	existing, err := dataStore.GetSummonerByPUUID(ctx, account.PUUID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get summoner: %w", err)
+	}
+
+	summoner, err := riotClient.GetSummonerByPUUID(ctx, platform, account.PUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get summoner: %w", err)
+	}
+	if err := dataStore.UpsertSummoner(ctx, store.SummonerFromAPI(account, summoner, platform)); err != nil {
+		return nil, fmt.Errorf("failed to save summoner: %w", err)
+	}
+
+	created, err := dataStore.GetSummonerByPUUID(ctx, account.PUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get summoner: %w", err)
+	}
+	return created, nil
+}
+
+// syncMatch stores a fetched match and its timeline in full and links the match
+// to the summoner. Linking comes last, so a match is only skipped by later syncs
+// once everything is stored. A timeline Riot no longer has doesn't stop the sync.
+func syncMatch(ctx context.Context, summonerID int64, result riot.MatchResult) error {
+	matchID, match := result.MatchID, result.Match
+	if result.Err != nil {
+		return fmt.Errorf("failed to get match %s: %w", matchID, result.Err)
+	}
+	if result.TimelineErr != nil && !errors.Is(result.TimelineErr, riot.ErrNotFound) {
+		return fmt.Errorf("failed to get timeline %s: %w", matchID, result.TimelineErr)
+	}
+
+	entity := store.MatchFromAPI(match)
+	if err := dataStore.SaveMatch(ctx, entity, store.ParticipantsFromAPI(match)); err != nil {
+		return fmt.Errorf("failed to save match %s: %w", matchID, err)
+	}
+	if err := dataStore.SaveFullMatch(ctx, match); err != nil {
+		return fmt.Errorf("failed to save full match %s: %w", matchID, err)
+	}
+	if result.Timeline != nil {
+		if err := dataStore.SaveTimeline(ctx, matchID, result.Timeline); err != nil {
+			return fmt.Errorf("failed to save timeline %s: %w", matchID, err)
+		}
+	}
+	if err := dataStore.LinkSummonerMatch(ctx, summonerID, entity.ID); err != nil {
+		return fmt.Errorf("failed to link match %s: %w", matchID, err)
+	}
+	return nil
+}
+
+func init() {
+	syncCmd.Flags().StringVar(&syncRiotID, "riot-id", "", "Riot ID (format: gameName#tagLine, e.g., Faker#KR1)")
+	syncCmd.Flags().StringVar(&syncSince, "since", "", "Sync matches played on or after this date (YYYY-MM-DD)")
+	syncCmd.Flags().StringVar(&syncQueue, "queue", "", "Queue to sync (solo, flex, normal, aram, all); every queue when unset")
+	syncCmd.Flags().IntVar(&syncConcurrency, "concurrency", riot.DefaultFetchConcurrency, "Number of matches to fetch in parallel")
+	rootCmd.AddCommand(syncCmd)
+}
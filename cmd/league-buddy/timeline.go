@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/HatiCode/league-buddy/internal/riot"
 	"github.com/spf13/cobra"
 )
 
@@ -40,7 +41,7 @@ var timelineCmd = &cobra.Command{
 				return fmt.Errorf("failed to get account: %w", err)
 			}
 
-			matchIDs, err := riotClient.GetMatchIDs(ctx, platform, account.PUUID, 1, 0)
+			matchIDs, err := riotClient.GetMatchIDs(ctx, platform, account.PUUID, riot.MatchIDsOptions{Count: 1})
 			if err != nil {
 				return fmt.Errorf("failed to get match IDs: %w", err)
 			}
@@ -54,7 +55,7 @@ var timelineCmd = &cobra.Command{
 
 		// Get timeline
 		timelineStart := time.Now()
-		timeline, err := riotClient.GetMatchTimeline(ctx, platform, matchID)
+		timeline, err := matchFetcher.GetMatchTimeline(ctx, platform, matchID)
 		if err != nil {
 			return fmt.Errorf("failed to get timeline: %w", err)
 		}
@@ -4,12 +4,18 @@ import (
 	"fmt"
 	"math"
 	"sort"
+
+	"github.com/HatiCode/league-buddy/internal/models"
 )
 
 func AnalyzePlayer(params PlayerAnalysisParams) (*PlayerAnalysis, error) {
 	if len(params.Matches) == 0 {
 		return nil, fmt.Errorf("at least one match is required")
 	}
+	mapType, err := commonMap(params.Matches)
+	if err != nil {
+		return nil, err
+	}
 
 	var analyses []MatchAnalysis
 	for i := range params.Matches {
@@ -21,13 +27,53 @@ func AnalyzePlayer(params PlayerAnalysisParams) (*PlayerAnalysis, error) {
 
 		if params.Timelines != nil {
 			if tl, ok := params.Timelines[match.Metadata.MatchID]; ok && tl != nil {
-				lanePhase, err := AnalyzeLanePhase(tl, match, params.PUUID)
+				// Lanes, objectives, death zones and vision are Summoner's Rift concepts.
+				if result.ARAM == nil {
+					lanePhase, err := AnalyzeLanePhase(tl, match, params.PUUID)
+					if err == nil {
+						result.LanePhase = lanePhase
+					}
+					objectives, err := AnalyzeObjectives(tl, match, params.PUUID)
+					if err == nil {
+						result.Objectives = objectives
+					}
+					deaths, err := AnalyzeDeaths(tl, match, params.PUUID)
+					if err == nil {
+						result.Deaths = deaths
+					}
+					vision, err := AnalyzeVision(tl, match, params.PUUID)
+					if err == nil {
+						result.Vision = vision
+					}
+				}
+				build, err := AnalyzeBuild(tl, params.PUUID)
+				if err == nil {
+					result.Build = build
+				}
+				skills, err := AnalyzeSkills(tl, params.PUUID)
 				if err == nil {
-					result.LanePhase = lanePhase
+					result.Skills = skills
+				}
+				curve, err := AnalyzeGameCurve(tl, match, params.PUUID)
+				if err == nil {
+					result.Curve = curve
 				}
 			}
 		}
 
+		switch result.Metrics.Role {
+		case RoleJungle:
+			jungle, err := AnalyzeJungle(params.Timelines[match.Metadata.MatchID], match, params.PUUID)
+			if err == nil {
+				result.Jungle = jungle
+			}
+		case RoleSupport:
+			support, err := AnalyzeSupport(params.Timelines[match.Metadata.MatchID], match, params.PUUID)
+			if err == nil {
+				result.Support = support
+			}
+		}
+
 		analyses = append(analyses, *result)
 	}
 
@@ -39,6 +85,8 @@ func AnalyzePlayer(params PlayerAnalysisParams) (*PlayerAnalysis, error) {
 		PUUID:        params.PUUID,
 		GameName:     params.GameName,
 		TagLine:      params.TagLine,
+		Queue:        params.Queue,
+		Map:          mapType,
 		TotalMatches: len(analyses),
 		Matches:      analyses,
 	}
@@ -52,13 +100,45 @@ func AnalyzePlayer(params PlayerAnalysisParams) (*PlayerAnalysis, error) {
 	analysis.WinRate = computeWinRate(analyses)
 	analysis.Averages = computeAverages(analyses)
 	analysis.Consistency = computeConsistency(analyses)
-	analysis.RoleBreakdown = computeRoleBreakdown(analyses)
+	if mapType != models.MapHowlingAbyss {
+		analysis.RoleBreakdown = computeRoleBreakdown(analyses)
+	}
 	analysis.ChampionPool = computeChampionPool(analyses)
-	analysis.Strengths, analysis.Weaknesses = identifyInsights(analysis.Averages, analysis.ChampionPool, analysis.Consistency)
+	analysis.Matchups = AnalyzeMatchups(analyses)
+	analysis.Objectives = computeObjectiveSummary(analyses)
+	analysis.Deaths = computeDeathSummary(analyses)
+	analysis.Vision = computeVisionSummary(analyses)
+	analysis.Builds = AnalyzeBuilds(analyses)
+	analysis.Skills = computeSkillSummary(analyses)
+	analysis.Curves = computeCurveSummary(analyses)
+	analysis.Jungle = computeJungleSummary(analyses)
+	analysis.Support = computeSupportSummary(analyses)
+	analysis.ARAM = computeARAMSummary(analyses)
+	analysis.Sessions = computeSessionSummary(analyses)
+	analysis.Percentiles = computePercentiles(analysis, params.Benchmarks)
+	analysis.Strengths, analysis.Weaknesses = identifyInsights(analysis, params.Rules)
 
 	return analysis, nil
 }
 
+// commonMap returns the map all matches were played on. Summoner's Rift and
+// ARAM games have incomparable metrics, so mixing them is an error. Matches on
+// unknown maps are ignored.
+func commonMap(matches []models.Match) (string, error) {
+	mapType := ""
+	for i := range matches {
+		m := matches[i].Info.MapType()
+		if m == "" {
+			continue
+		}
+		if mapType != "" && m != mapType {
+			return "", fmt.Errorf("%w: analyze one queue at a time", ErrMixedMaps)
+		}
+		mapType = m
+	}
+	return mapType, nil
+}
+
 func computeWinRate(analyses []MatchAnalysis) float64 {
 	wins := 0
 	for _, a := range analyses {
@@ -200,125 +280,78 @@ func computeChampionPool(analyses []MatchAnalysis) []ChampionStats {
 	return pool
 }
 
-type insightThreshold struct {
-	category       string
-	strengthMin    float64
-	weaknessMax    float64
-	getValue       func(AverageMetrics) float64
-	strengthDesc   string
-	weaknessDesc   string
-	invertWeakness bool // true = high value is weakness (e.g. deaths)
-}
+// Roles as reported in Participant.TeamPosition.
+const (
+	RoleTop     = "TOP"
+	RoleJungle  = "JUNGLE"
+	RoleMiddle  = "MIDDLE"
+	RoleBottom  = "BOTTOM"
+	RoleSupport = "UTILITY"
+)
 
-var thresholds = []insightThreshold{
-	{
-		category: "combat", strengthMin: 3.0, weaknessMax: 1.5,
-		getValue:     func(a AverageMetrics) float64 { return a.KDA },
-		strengthDesc: "Strong KDA averaging %.1f -- effective at getting kills and staying alive",
-		weaknessDesc: "Low KDA averaging %.1f -- dying too frequently relative to kill contribution",
-	},
-	{
-		category: "combat", strengthMin: 0.65, weaknessMax: 0.40,
-		getValue:     func(a AverageMetrics) float64 { return a.KillParticipation },
-		strengthDesc: "High kill participation at %.0f%% -- consistently involved in team fights",
-		weaknessDesc: "Low kill participation at %.0f%% -- missing team fights or playing too passively",
-	},
-	{
-		category: "farming", strengthMin: 7.5, weaknessMax: 5.5,
-		getValue:     func(a AverageMetrics) float64 { return a.CSPerMinute },
-		strengthDesc: "Strong farming at %.1f CS/min -- efficient gold generation",
-		weaknessDesc: "Low CS at %.1f per minute -- missing too much farm",
-	},
-	{
-		category: "vision", strengthMin: 1.2, weaknessMax: 0.6,
-		getValue:     func(a AverageMetrics) float64 { return a.VisionScorePerMinute },
-		strengthDesc: "Excellent vision control at %.2f score/min",
-		weaknessDesc: "Low vision score at %.2f per minute -- not warding enough",
-	},
-	{
-		category: "combat", strengthMin: 0.28, weaknessMax: 0.15,
-		getValue:     func(a AverageMetrics) float64 { return a.DamageShare },
-		strengthDesc: "High team damage share at %.0f%% -- carrying damage output",
-		weaknessDesc: "Low damage share at %.0f%% -- not contributing enough damage",
-	},
-	{
-		category: "objectives", strengthMin: 0.60, weaknessMax: 0.30,
-		getValue:     func(a AverageMetrics) float64 { return a.ObjectiveParticipation },
-		strengthDesc: "Strong objective participation at %.0f%%",
-		weaknessDesc: "Low objective participation at %.0f%% -- missing dragon and baron fights",
-	},
-	{
-		category: "deaths", weaknessMax: 0.25, invertWeakness: true,
-		getValue:     func(a AverageMetrics) float64 { return a.DeathsPerMinute },
-		weaknessDesc: "High death rate at %.2f per minute -- positioning or decision-making needs work",
-	},
+// primaryRole returns the most played role, or "" without role data.
+func primaryRole(roles []RoleStats) string {
+	if len(roles) == 0 {
+		return ""
+	}
+	return roles[0].Role
 }
 
-func identifyInsights(avg AverageMetrics, championPool []ChampionStats, consistency ConsistencyMetrics) (strengths []Insight, weaknesses []Insight) {
-	for _, t := range thresholds {
-		value := t.getValue(avg)
-		displayValue := value
-		if t.category == "combat" && t.strengthMin == 0.65 || t.category == "combat" && t.strengthMin == 0.28 || t.category == "objectives" {
-			displayValue = value * 100
-		}
+// identifyInsights derives strengths and weaknesses from the aggregated sections of an analysis.
+// Metric insights come from rules, or the embedded defaults when rules is nil.
+// ARAM analyses skip the rules, which are written for Summoner's Rift roles.
+// The section insights (vision, builds, jungle, ...) keep fixed thresholds in code.
+func identifyInsights(a *PlayerAnalysis, rules *RuleSet) (strengths []Insight, weaknesses []Insight) {
+	if a.ARAM != nil {
+		strengths, weaknesses = aramInsights(a.ARAM)
+		weaknesses = append(weaknesses, skillInsights(a.Skills)...)
+		sessionStrengths, sessionWeaknesses := sessionInsights(a.Sessions)
+		return append(strengths, sessionStrengths...), append(weaknesses, sessionWeaknesses...)
+	}
+	if rules == nil {
+		rules = DefaultRules()
+	}
 
-		if t.invertWeakness {
-			if value >= t.weaknessMax {
-				weaknesses = append(weaknesses, Insight{
-					Category:    t.category,
-					Description: fmt.Sprintf(t.weaknessDesc, displayValue),
-					Value:       value,
-				})
-			}
+	percentiles := make(map[string]MetricPercentile, len(a.Percentiles))
+	for _, p := range a.Percentiles {
+		percentiles[p.Metric] = p
+	}
+
+	for _, r := range rules.forPlayer(primaryRole(a.RoleBreakdown), a.Tier) {
+		var insight Insight
+		var ok bool
+		if p, found := percentiles[r.Metric]; found {
+			insight, ok = r.evaluatePercentile(p)
+		} else {
+			insight, ok = r.evaluate(ruleMetrics[r.Metric](a))
+		}
+		if !ok {
 			continue
 		}
-
-		if t.strengthMin > 0 && value >= t.strengthMin {
-			strengths = append(strengths, Insight{
-				Category:    t.category,
-				Description: fmt.Sprintf(t.strengthDesc, displayValue),
-				Value:       value,
-				IsStrength:  true,
-			})
-		} else if value <= t.weaknessMax {
-			weaknesses = append(weaknesses, Insight{
-				Category:    t.category,
-				Description: fmt.Sprintf(t.weaknessDesc, displayValue),
-				Value:       value,
-			})
+		if insight.IsStrength {
+			strengths = append(strengths, insight)
+		} else {
+			weaknesses = append(weaknesses, insight)
 		}
 	}
 
-	uniqueChamps := len(championPool)
-	if uniqueChamps >= 8 {
-		strengths = append(strengths, Insight{
-			Category:    "champion_pool",
-			Description: fmt.Sprintf("Deep champion pool with %d unique champions", uniqueChamps),
-			Value:       float64(uniqueChamps),
-			IsStrength:  true,
-		})
-	} else if uniqueChamps <= 2 {
-		weaknesses = append(weaknesses, Insight{
-			Category:    "champion_pool",
-			Description: fmt.Sprintf("Narrow champion pool with only %d champion(s)", uniqueChamps),
-			Value:       float64(uniqueChamps),
-		})
-	}
-
-	if consistency.KDAStdDev < 1.0 {
-		strengths = append(strengths, Insight{
-			Category:    "consistency",
-			Description: fmt.Sprintf("Very consistent KDA performance (stddev %.2f)", consistency.KDAStdDev),
-			Value:       consistency.KDAStdDev,
-			IsStrength:  true,
-		})
-	} else if consistency.KDAStdDev > 3.0 {
-		weaknesses = append(weaknesses, Insight{
-			Category:    "consistency",
-			Description: fmt.Sprintf("Inconsistent performance with large KDA swings (stddev %.2f)", consistency.KDAStdDev),
-			Value:       consistency.KDAStdDev,
-		})
-	}
+	visionStrengths, visionWeaknesses := visionInsights(a.Vision)
+	strengths = append(strengths, visionStrengths...)
+	weaknesses = append(weaknesses, visionWeaknesses...)
+	weaknesses = append(weaknesses, buildInsights(a.Builds)...)
+	weaknesses = append(weaknesses, skillInsights(a.Skills)...)
+	curveStrengths, curveWeaknesses := curveInsights(a.Curves)
+	strengths = append(strengths, curveStrengths...)
+	weaknesses = append(weaknesses, curveWeaknesses...)
+	jungleStrengths, jungleWeaknesses := jungleInsights(a.Jungle)
+	strengths = append(strengths, jungleStrengths...)
+	weaknesses = append(weaknesses, jungleWeaknesses...)
+	supportStrengths, supportWeaknesses := supportInsights(a.Support)
+	strengths = append(strengths, supportStrengths...)
+	weaknesses = append(weaknesses, supportWeaknesses...)
+	sessionStrengths, sessionWeaknesses := sessionInsights(a.Sessions)
+	strengths = append(strengths, sessionStrengths...)
+	weaknesses = append(weaknesses, sessionWeaknesses...)
 
 	return strengths, weaknesses
 }
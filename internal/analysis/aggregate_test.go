@@ -1,6 +1,7 @@
 package analysis
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/HatiCode/league-buddy/internal/models"
@@ -311,6 +312,42 @@ func TestAnalyzePlayerSkipsRemakes(t *testing.T) {
 	}
 }
 
+func TestAnalyzePlayerRefusesMixedMaps(t *testing.T) {
+	puuid := "test-puuid"
+	ranked := makeAnalysisMatch("M1", puuid, "Ahri", "MIDDLE", true, 5, 3, 5)
+	ranked.Info.QueueID = models.QueueIDRankedSolo
+	aram := makeAnalysisMatch("M2", puuid, "Ahri", "", true, 10, 8, 20)
+	aram.Info.QueueID = models.QueueIDARAM
+
+	_, err := AnalyzePlayer(PlayerAnalysisParams{
+		PUUID:   puuid,
+		Matches: []models.Match{ranked, aram},
+	})
+	if !errors.Is(err, ErrMixedMaps) {
+		t.Fatalf("expected ErrMixedMaps, got %v", err)
+	}
+}
+
+func TestAnalyzePlayerRecordsQueueAndMap(t *testing.T) {
+	puuid := "test-puuid"
+	flex := makeAnalysisMatch("M1", puuid, "Ahri", "MIDDLE", true, 5, 3, 5)
+	flex.Info.QueueID = models.QueueIDRankedFlex
+	// An unknown queue on an unknown map doesn't count as mixing.
+	custom := makeAnalysisMatch("M2", puuid, "Ahri", "MIDDLE", false, 2, 4, 3)
+
+	result, err := AnalyzePlayer(PlayerAnalysisParams{
+		PUUID:   puuid,
+		Matches: []models.Match{flex, custom},
+		Queue:   models.QueueGroupFlex,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Queue != models.QueueGroupFlex || result.Map != models.MapSummonersRift {
+		t.Errorf("queue/map = %q/%q, want flex/%s", result.Queue, result.Map, models.MapSummonersRift)
+	}
+}
+
 func TestIdentifyInsightsStrengths(t *testing.T) {
 	avg := AverageMetrics{
 		KDA:                    4.5,
@@ -322,7 +359,11 @@ func TestIdentifyInsightsStrengths(t *testing.T) {
 		DeathsPerMinute:        0.10,
 	}
 
-	strengths, weaknesses := identifyInsights(avg, make([]ChampionStats, 5), ConsistencyMetrics{KDAStdDev: 0.5})
+	strengths, weaknesses := identifyInsights(&PlayerAnalysis{
+		Averages:     avg,
+		ChampionPool: make([]ChampionStats, 5),
+		Consistency:  ConsistencyMetrics{KDAStdDev: 0.5},
+	}, nil)
 
 	if len(strengths) == 0 {
 		t.Error("expected at least one strength")
@@ -362,7 +403,11 @@ func TestIdentifyInsightsWeaknesses(t *testing.T) {
 		DeathsPerMinute:        0.30,
 	}
 
-	strengths, weaknesses := identifyInsights(avg, make([]ChampionStats, 1), ConsistencyMetrics{KDAStdDev: 4.0})
+	strengths, weaknesses := identifyInsights(&PlayerAnalysis{
+		Averages:     avg,
+		ChampionPool: make([]ChampionStats, 1),
+		Consistency:  ConsistencyMetrics{KDAStdDev: 4.0},
+	}, nil)
 
 	if len(weaknesses) == 0 {
 		t.Error("expected at least one weakness")
@@ -0,0 +1,196 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+const (
+	// summonerMark is the summoner spell ID of Mark, the ARAM snowball.
+	summonerMark = 32
+
+	// minARAMGames is the minimum number of ARAM games before ARAM insights are reported.
+	minARAMGames = 3
+)
+
+// IsARAM reports whether the match was played on Howling Abyss.
+func IsARAM(match *models.Match) bool {
+	return match.Info.MapType() == models.MapHowlingAbyss
+}
+
+// AnalyzeARAM builds ARAM metrics from end-of-game stats.
+func AnalyzeARAM(match *models.Match, puuid string) (*ARAMMetrics, error) {
+	participant, _, err := findParticipant(match, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &ARAMMetrics{}
+	if teamDamage := sumTeamDamage(match, participant.TeamID); teamDamage > 0 {
+		metrics.DamageShare = float64(participant.TotalDamageDealtToChampions) / float64(teamDamage)
+	}
+	if teamTaken := sumTeamDamageTaken(match, participant.TeamID); teamTaken > 0 {
+		metrics.DamageTakenShare = float64(participant.TotalDamageTaken) / float64(teamTaken)
+	}
+	if match.Info.GameDuration > 0 {
+		gameDurationMin := float64(match.Info.GameDuration) / 60.0
+		metrics.HealShieldPerMinute = float64(participant.TotalHealsOnTeammates+participant.TotalDamageShieldedOnTeammates) / gameDurationMin
+		metrics.DeathsPerMinute = float64(participant.Deaths) / gameDurationMin
+		metrics.TimeDeadShare = float64(participant.TotalTimeSpentDead) / float64(match.Info.GameDuration)
+	}
+
+	switch summonerMark {
+	case participant.Summoner1Id:
+		metrics.HasSnowball, metrics.SnowballCasts = true, participant.Summoner1Casts
+	case participant.Summoner2Id:
+		metrics.HasSnowball, metrics.SnowballCasts = true, participant.Summoner2Casts
+	}
+	if c := participant.Challenges; c != nil {
+		metrics.SnowballsHit = c.SnowballsHit
+	}
+
+	return metrics, nil
+}
+
+// clearRiftMetrics zeroes the metrics that only make sense on Summoner's Rift.
+func clearRiftMetrics(m *MatchMetrics) {
+	m.Role = ""
+	m.OpponentChampionName = ""
+	m.VisionScorePerMinute = 0
+	m.WardsPerMinute = 0
+	m.ControlWardsPlaced = 0
+	m.ObjectiveParticipation = 0
+	m.TurretDamageShare = 0
+	m.LaneMinionsFirst10Min = 0
+	m.EarlyLaningGoldExpAdvantage = 0
+	m.LaningGoldExpAdvantage = 0
+	m.MaxCsAdvantageOnLaneOpponent = 0
+}
+
+func computeARAMSummary(analyses []MatchAnalysis) *ARAMSummary {
+	summary := &ARAMSummary{}
+	var hits, casts int
+
+	for _, a := range analyses {
+		m := a.ARAM
+		if m == nil {
+			continue
+		}
+		summary.Games++
+		summary.AvgDamageShare += m.DamageShare
+		summary.AvgDamageTakenShare += m.DamageTakenShare
+		summary.AvgHealShieldPerMinute += m.HealShieldPerMinute
+		summary.AvgDeathsPerMinute += m.DeathsPerMinute
+		summary.AvgTimeDeadShare += m.TimeDeadShare
+
+		if m.HasSnowball {
+			summary.SnowballGames++
+			casts += m.SnowballCasts
+			hits += m.SnowballsHit
+		}
+	}
+
+	if summary.Games == 0 {
+		return nil
+	}
+
+	n := float64(summary.Games)
+	summary.AvgDamageShare /= n
+	summary.AvgDamageTakenShare /= n
+	summary.AvgHealShieldPerMinute /= n
+	summary.AvgDeathsPerMinute /= n
+	summary.AvgTimeDeadShare /= n
+
+	if summary.SnowballGames > 0 {
+		summary.AvgSnowballCasts = float64(casts) / float64(summary.SnowballGames)
+	}
+	if casts > 0 {
+		summary.SnowballHitRate = min(float64(hits)/float64(casts), 1)
+	}
+
+	return summary
+}
+
+// aramInsights judges ARAM games on their own terms: teamfight damage, staying
+// alive, frontlining or sustaining the team, and landing snowballs.
+func aramInsights(s *ARAMSummary) (strengths []Insight, weaknesses []Insight) {
+	if s == nil || s.Games < minARAMGames {
+		return nil, nil
+	}
+
+	if s.AvgDamageShare >= 0.25 {
+		strengths = append(strengths, Insight{
+			Category:    "aram",
+			Description: fmt.Sprintf("Carries teamfights with %.0f%% of team damage", s.AvgDamageShare*100),
+			Value:       s.AvgDamageShare,
+			IsStrength:  true,
+		})
+	} else if s.AvgDamageShare < 0.15 && s.AvgHealShieldPerMinute < 300 {
+		weaknesses = append(weaknesses, Insight{
+			Category:    "aram",
+			Description: fmt.Sprintf("Only %.0f%% of team damage -- poke before fights and stay in range", s.AvgDamageShare*100),
+			Value:       s.AvgDamageShare,
+		})
+	}
+
+	if s.AvgDeathsPerMinute >= 0.45 {
+		weaknesses = append(weaknesses, Insight{
+			Category: "aram",
+			Description: fmt.Sprintf("Dies %.2f times per minute and spends %.0f%% of the game dead -- wait for the team before engaging",
+				s.AvgDeathsPerMinute, s.AvgTimeDeadShare*100),
+			Value: s.AvgDeathsPerMinute,
+		})
+	} else if s.AvgDeathsPerMinute <= 0.25 {
+		strengths = append(strengths, Insight{
+			Category:    "aram",
+			Description: fmt.Sprintf("Stays alive: %.2f deaths per minute", s.AvgDeathsPerMinute),
+			Value:       s.AvgDeathsPerMinute,
+			IsStrength:  true,
+		})
+	}
+
+	if s.AvgDamageTakenShare >= 0.25 && s.AvgDeathsPerMinute < 0.45 {
+		strengths = append(strengths, Insight{
+			Category:    "aram",
+			Description: fmt.Sprintf("Frontlines well: absorbs %.0f%% of team damage taken without feeding", s.AvgDamageTakenShare*100),
+			Value:       s.AvgDamageTakenShare,
+			IsStrength:  true,
+		})
+	}
+
+	if s.AvgHealShieldPerMinute >= 300 {
+		strengths = append(strengths, Insight{
+			Category:    "aram",
+			Description: fmt.Sprintf("Sustains the team with %.0f healing and shielding per minute", s.AvgHealShieldPerMinute),
+			Value:       s.AvgHealShieldPerMinute,
+			IsStrength:  true,
+		})
+	}
+
+	if s.SnowballGames >= minARAMGames {
+		switch {
+		case s.AvgSnowballCasts < 2:
+			weaknesses = append(weaknesses, Insight{
+				Category:    "aram",
+				Description: fmt.Sprintf("Rarely throws the snowball (%.1f casts per game) -- it's a free engage or follow-up", s.AvgSnowballCasts),
+				Value:       s.AvgSnowballCasts,
+			})
+		case s.SnowballHitRate >= 0.5:
+			strengths = append(strengths, Insight{
+				Category:    "aram",
+				Description: fmt.Sprintf("Accurate snowballs: %.0f%% of casts hit", s.SnowballHitRate*100),
+				Value:       s.SnowballHitRate,
+				IsStrength:  true,
+			})
+		case s.SnowballHitRate < 0.25:
+			weaknesses = append(weaknesses, Insight{
+				Category:    "aram",
+				Description: fmt.Sprintf("Only %.0f%% of snowballs hit -- throw at targets slowed, rooted or walking straight", s.SnowballHitRate*100),
+				Value:       s.SnowballHitRate,
+			})
+		}
+	}
+
+	return strengths, weaknesses
+}
@@ -0,0 +1,148 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+func makeARAMMatch(matchID, puuid string, win bool) models.Match {
+	m := makeAnalysisMatch(matchID, puuid, "Lux", "", win, 12, 6, 25)
+	m.Info.QueueID = models.QueueIDARAM
+	m.Info.MapID = models.MapIDHowlingAbyss
+	// ARAM reports garbage positions; the "opponent" must not become a lane opponent.
+	m.Info.Participants[0].TeamPosition = "MIDDLE"
+	m.Info.Participants[2].TeamPosition = "MIDDLE"
+	m.Info.Participants[2].ChampionName = "Zed"
+
+	p := &m.Info.Participants[0]
+	p.TotalHealsOnTeammates = 3000
+	p.TotalDamageShieldedOnTeammates = 6000
+	p.TotalTimeSpentDead = 180
+	p.Summoner1Id = 4
+	p.Summoner2Id = summonerMark
+	p.Summoner2Casts = 8
+	p.Challenges = &models.Challenges{KDA: 6.2, SnowballsHit: 5}
+	return m
+}
+
+func TestAnalyzeMatch_ARAM(t *testing.T) {
+	match := makeARAMMatch("ARAM1", "p1", true)
+
+	result, err := AnalyzeMatch(&match, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m := result.Metrics
+	if m.Role != "" || m.OpponentChampionName != "" {
+		t.Errorf("expected no role or lane opponent in ARAM, got %q vs %q", m.Role, m.OpponentChampionName)
+	}
+	if m.VisionScorePerMinute != 0 || m.ObjectiveParticipation != 0 || m.ControlWardsPlaced != 0 {
+		t.Errorf("expected Summoner's Rift metrics cleared, got %+v", m)
+	}
+
+	a := result.ARAM
+	if a == nil {
+		t.Fatal("expected ARAM metrics")
+	}
+	// 20000 of 30000 team damage, 15000 of 27000 damage taken, 9000 heal+shield over 30 min.
+	if !approxEqual(a.DamageShare, 20000.0/30000) || !approxEqual(a.DamageTakenShare, 15000.0/27000) {
+		t.Errorf("unexpected shares: %+v", a)
+	}
+	if !approxEqual(a.HealShieldPerMinute, 300) || !approxEqual(a.DeathsPerMinute, 0.2) || !approxEqual(a.TimeDeadShare, 0.1) {
+		t.Errorf("unexpected per-minute stats: %+v", a)
+	}
+	if !a.HasSnowball || a.SnowballCasts != 8 || a.SnowballsHit != 5 {
+		t.Errorf("unexpected snowball stats: %+v", a)
+	}
+}
+
+func TestAnalyzeMatch_RiftHasNoARAMMetrics(t *testing.T) {
+	match := makeAnalysisMatch("SR1", "p1", "Ahri", "MIDDLE", true, 5, 2, 5)
+	match.Info.QueueID = models.QueueIDRankedSolo
+
+	result, err := AnalyzeMatch(&match, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ARAM != nil || result.Metrics.Role != "MIDDLE" {
+		t.Errorf("expected a Summoner's Rift analysis, got role %q and ARAM %+v", result.Metrics.Role, result.ARAM)
+	}
+}
+
+func TestComputeARAMSummary(t *testing.T) {
+	analyses := []MatchAnalysis{
+		{ARAM: &ARAMMetrics{DamageShare: 0.3, DeathsPerMinute: 0.2, HasSnowball: true, SnowballCasts: 6, SnowballsHit: 3}},
+		{ARAM: &ARAMMetrics{DamageShare: 0.2, DeathsPerMinute: 0.4, HasSnowball: true, SnowballCasts: 4, SnowballsHit: 1}},
+		{ARAM: &ARAMMetrics{DamageShare: 0.25, DeathsPerMinute: 0.3}},
+		{},
+	}
+
+	s := computeARAMSummary(analyses)
+	if s == nil {
+		t.Fatal("expected a summary")
+	}
+	if s.Games != 3 || !approxEqual(s.AvgDamageShare, 0.25) || !approxEqual(s.AvgDeathsPerMinute, 0.3) {
+		t.Errorf("unexpected averages: %+v", s)
+	}
+	if s.SnowballGames != 2 || !approxEqual(s.AvgSnowballCasts, 5) || !approxEqual(s.SnowballHitRate, 0.4) {
+		t.Errorf("unexpected snowball stats: %+v", s)
+	}
+
+	if computeARAMSummary([]MatchAnalysis{{}}) != nil {
+		t.Error("expected nil without ARAM games")
+	}
+}
+
+func TestAramInsights(t *testing.T) {
+	strengths, weaknesses := aramInsights(&ARAMSummary{
+		Games:              5,
+		AvgDamageShare:     0.30,
+		AvgDeathsPerMinute: 0.5,
+		AvgTimeDeadShare:   0.3,
+		SnowballGames:      5,
+		AvgSnowballCasts:   6,
+		SnowballHitRate:    0.2,
+	})
+
+	if len(strengths) != 1 || !strings.Contains(strengths[0].Description, "30% of team damage") {
+		t.Errorf("expected a damage strength, got %+v", strengths)
+	}
+	if len(weaknesses) != 2 {
+		t.Fatalf("expected death and snowball weaknesses, got %+v", weaknesses)
+	}
+	if !strings.Contains(weaknesses[0].Description, "30% of the game dead") || !strings.Contains(weaknesses[1].Description, "20% of snowballs hit") {
+		t.Errorf("unexpected weaknesses: %+v", weaknesses)
+	}
+
+	if s, w := aramInsights(&ARAMSummary{Games: 2, AvgDeathsPerMinute: 1}); s != nil || w != nil {
+		t.Error("expected no insights below the minimum games")
+	}
+}
+
+func TestAnalyzePlayer_ARAM(t *testing.T) {
+	puuid := "p1"
+	matches := []models.Match{
+		makeARAMMatch("A1", puuid, true),
+		makeARAMMatch("A2", puuid, false),
+		makeARAMMatch("A3", puuid, true),
+	}
+
+	result, err := AnalyzePlayer(PlayerAnalysisParams{PUUID: puuid, Matches: matches, Queue: models.QueueGroupARAM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Map != models.MapHowlingAbyss || result.ARAM == nil || result.ARAM.Games != 3 {
+		t.Fatalf("expected an ARAM analysis, got map %q and %+v", result.Map, result.ARAM)
+	}
+	if len(result.RoleBreakdown) != 0 || len(result.Matchups) != 0 {
+		t.Errorf("expected no roles or matchups, got %+v and %+v", result.RoleBreakdown, result.Matchups)
+	}
+	for _, i := range append(result.Strengths, result.Weaknesses...) {
+		if i.Category != "aram" && i.Category != "tilt" && i.Category != "skills" {
+			t.Errorf("unexpected Summoner's Rift insight in ARAM: %+v", i)
+		}
+	}
+}
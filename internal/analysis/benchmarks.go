@@ -0,0 +1,231 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// minBenchmarkSamples is how many games a (tier, role) distribution needs
+// before it is trusted; smaller tiers fall back to every tier for the role.
+const minBenchmarkSamples = 20
+
+// Percentile bands that turn a benchmark into a strength or weakness.
+const (
+	strengthPercentile = 75.0
+	weaknessPercentile = 25.0
+)
+
+// BenchmarkMatch is a stored match with the tier of the players in it.
+type BenchmarkMatch struct {
+	Tier  string
+	Match models.Match
+}
+
+type benchmarkMetric struct {
+	name     string
+	label    string
+	format   string
+	percent  bool
+	getValue func(MatchMetrics) float64
+}
+
+// benchmarkMetrics lists the MatchMetrics fields that get a distribution.
+// Names match the insight threshold metrics so both can be looked up together.
+var benchmarkMetrics = []benchmarkMetric{
+	{name: "kda", label: "KDA", format: "%.1f", getValue: func(m MatchMetrics) float64 { return m.KDA }},
+	{name: "kill_participation", label: "Kill participation", format: "%.0f%%", percent: true, getValue: func(m MatchMetrics) float64 { return m.KillParticipation }},
+	{name: "damage_per_min", label: "Damage/min", format: "%.0f", getValue: func(m MatchMetrics) float64 { return m.DamagePerMinute }},
+	{name: "damage_share", label: "Damage share", format: "%.0f%%", percent: true, getValue: func(m MatchMetrics) float64 { return m.DamageShare }},
+	{name: "cs_per_min", label: "CS/min", format: "%.1f", getValue: func(m MatchMetrics) float64 { return m.CSPerMinute }},
+	{name: "vision_score_per_min", label: "Vision score/min", format: "%.2f", getValue: func(m MatchMetrics) float64 { return m.VisionScorePerMinute }},
+	{name: "wards_per_min", label: "Wards/min", format: "%.2f", getValue: func(m MatchMetrics) float64 { return m.WardsPerMinute }},
+	{name: "cc_per_min", label: "CC/min", format: "%.1f", getValue: func(m MatchMetrics) float64 { return m.CCPerMinute }},
+	{name: "deaths_per_min", label: "Deaths/min", format: "%.2f", getValue: func(m MatchMetrics) float64 { return m.DeathsPerMinute }},
+	{name: "gold_per_min", label: "Gold/min", format: "%.0f", getValue: func(m MatchMetrics) float64 { return m.GoldPerMinute }},
+	{name: "objective_participation", label: "Objective participation", format: "%.0f%%", percent: true, getValue: func(m MatchMetrics) float64 { return m.ObjectiveParticipation }},
+	{name: "turret_damage_share", label: "Turret damage share", format: "%.0f%%", percent: true, getValue: func(m MatchMetrics) float64 { return m.TurretDamageShare }},
+	{name: "damage_taken_share", label: "Damage taken share", format: "%.0f%%", percent: true, getValue: func(m MatchMetrics) float64 { return m.DamageTakenShare }},
+	{name: "heal_shield", label: "Effective heal and shield", format: "%.0f", getValue: func(m MatchMetrics) float64 { return m.HealShieldEffective }},
+	{name: "max_cs_advantage", label: "Max CS lead", format: "%.0f", getValue: func(m MatchMetrics) float64 { return m.MaxCsAdvantageOnLaneOpponent }},
+	{name: "solo_kills", label: "Solo kills", format: "%.1f", getValue: func(m MatchMetrics) float64 { return float64(m.SoloKills) }},
+	{name: "control_wards", label: "Control wards", format: "%.1f", getValue: func(m MatchMetrics) float64 { return float64(m.ControlWardsPlaced) }},
+	{name: "lane_minions_10", label: "Lane CS at 10", format: "%.0f", getValue: func(m MatchMetrics) float64 { return float64(m.LaneMinionsFirst10Min) }},
+	{name: "time_spent_dead", label: "Time spent dead", format: "%.0fs", getValue: func(m MatchMetrics) float64 { return float64(m.TimeSpentDead) }},
+}
+
+type benchmarkKey struct {
+	tier string
+	role string
+}
+
+// Benchmarks holds per-(tier, role) distributions of match metrics.
+type Benchmarks struct {
+	samples map[benchmarkKey]map[string][]float64
+}
+
+// BuildBenchmarks analyzes every participant of every match and groups their
+// metrics by tier and role. Each match holds ten players of similar rank, so a
+// few hundred stored matches give usable distributions.
+func BuildBenchmarks(matches []BenchmarkMatch) *Benchmarks {
+	b := &Benchmarks{samples: make(map[benchmarkKey]map[string][]float64)}
+
+	for i := range matches {
+		match := &matches[i].Match
+		tier := strings.ToUpper(matches[i].Tier)
+		for _, p := range match.Info.Participants {
+			if p.TeamPosition == "" {
+				continue
+			}
+			result, err := AnalyzeMatch(match, p.PUUID)
+			if err != nil {
+				continue
+			}
+			if tier != "" {
+				b.add(benchmarkKey{tier: tier, role: p.TeamPosition}, result.Metrics)
+			}
+			b.add(benchmarkKey{role: p.TeamPosition}, result.Metrics)
+		}
+	}
+
+	for _, byMetric := range b.samples {
+		for _, values := range byMetric {
+			sort.Float64s(values)
+		}
+	}
+	return b
+}
+
+func (b *Benchmarks) add(key benchmarkKey, m MatchMetrics) {
+	byMetric, ok := b.samples[key]
+	if !ok {
+		byMetric = make(map[string][]float64, len(benchmarkMetrics))
+		b.samples[key] = byMetric
+	}
+	for _, bm := range benchmarkMetrics {
+		byMetric[bm.name] = append(byMetric[bm.name], bm.getValue(m))
+	}
+}
+
+// Percentile returns where value falls in the metric's distribution for the
+// tier and role, from 0 to 100. When the tier has too few games the
+// distribution across all tiers is used and tier comes back empty.
+func (b *Benchmarks) Percentile(tier, role, metric string, value float64) (pct float64, usedTier string, samples int, ok bool) {
+	if b == nil {
+		return 0, "", 0, false
+	}
+
+	tier = strings.ToUpper(tier)
+	values := b.samples[benchmarkKey{tier: tier, role: role}][metric]
+	if tier == "" || len(values) < minBenchmarkSamples {
+		tier = ""
+		values = b.samples[benchmarkKey{role: role}][metric]
+	}
+	if len(values) < minBenchmarkSamples {
+		return 0, "", 0, false
+	}
+
+	// Ties count as half below so a value shared by many players lands mid-run.
+	below := sort.SearchFloat64s(values, value)
+	upTo := sort.Search(len(values), func(i int) bool { return values[i] > value })
+	pct = (float64(below) + float64(upTo-below)/2) / float64(len(values)) * 100
+	return pct, tier, len(values), true
+}
+
+// computePercentiles places the player's averages over their main role
+// against the benchmarks for their tier.
+func computePercentiles(a *PlayerAnalysis, benchmarks *Benchmarks) []MetricPercentile {
+	role := primaryRole(a.RoleBreakdown)
+	if benchmarks == nil || role == "" {
+		return nil
+	}
+
+	var games []MatchMetrics
+	for _, m := range a.Matches {
+		if m.Metrics.Role == role {
+			games = append(games, m.Metrics)
+		}
+	}
+	if len(games) == 0 {
+		return nil
+	}
+
+	var result []MetricPercentile
+	for _, bm := range benchmarkMetrics {
+		var sum float64
+		for _, g := range games {
+			sum += bm.getValue(g)
+		}
+		value := sum / float64(len(games))
+
+		pct, tier, samples, ok := benchmarks.Percentile(a.Tier, role, bm.name, value)
+		if !ok {
+			continue
+		}
+		result = append(result, MetricPercentile{
+			Metric:     bm.name,
+			Label:      bm.label,
+			Value:      value,
+			Percentile: pct,
+			Tier:       tier,
+			Role:       role,
+			Samples:    samples,
+		})
+	}
+	return result
+}
+
+// percentileInsight describes a benchmark, e.g.
+// "CS/min at the 22nd percentile for Gold junglers (5.1)".
+func percentileInsight(p MetricPercentile) string {
+	display := p.Value
+	format := "%.2f"
+	for _, bm := range benchmarkMetrics {
+		if bm.name == p.Metric {
+			format = bm.format
+			if bm.percent {
+				display *= 100
+			}
+			break
+		}
+	}
+	return fmt.Sprintf("%s at the %s percentile for %s (%s)",
+		p.Label, ordinal(int(p.Percentile+0.5)), benchmarkGroup(p.Tier, p.Role), fmt.Sprintf(format, display))
+}
+
+var rolePlurals = map[string]string{
+	RoleTop:     "top laners",
+	RoleJungle:  "junglers",
+	RoleMiddle:  "mid laners",
+	RoleBottom:  "bot laners",
+	RoleSupport: "supports",
+}
+
+// benchmarkGroup names the players a percentile was measured against.
+func benchmarkGroup(tier, role string) string {
+	players, ok := rolePlurals[role]
+	if !ok {
+		players = strings.ToLower(role) + " players"
+	}
+	if tier == "" {
+		return players + " across all tiers"
+	}
+	return strings.ToUpper(tier[:1]) + strings.ToLower(tier[1:]) + " " + players
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
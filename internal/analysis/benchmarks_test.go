@@ -0,0 +1,148 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// makeBenchmarkMatches returns n single-player matches whose CS/min runs
+// from 4.0 upward in steps of 0.1.
+func makeBenchmarkMatches(tier, role string, n int) []BenchmarkMatch {
+	matches := make([]BenchmarkMatch, n)
+	for i := range matches {
+		matches[i] = BenchmarkMatch{
+			Tier: tier,
+			Match: models.Match{
+				Metadata: models.MatchMetadata{MatchID: tier + "_" + intToStr(i)},
+				Info: models.MatchInfo{
+					GameDuration: 1800,
+					Participants: []models.Participant{{
+						PUUID:              "p" + intToStr(i),
+						TeamPosition:       role,
+						TeamID:             100,
+						TotalMinionsKilled: 120 + 3*i,
+					}},
+				},
+			},
+		}
+	}
+	return matches
+}
+
+func TestBuildBenchmarks_Percentile(t *testing.T) {
+	b := BuildBenchmarks(makeBenchmarkMatches("GOLD", RoleJungle, 40))
+
+	pct, tier, samples, ok := b.Percentile("gold", RoleJungle, "cs_per_min", 4.0)
+	if !ok {
+		t.Fatal("expected a Gold jungle benchmark")
+	}
+	if tier != "GOLD" || samples != 40 {
+		t.Errorf("expected GOLD with 40 samples, got %q with %d", tier, samples)
+	}
+	// Lowest value: nothing below, one tie counted as half.
+	if !approxEqual(pct, 1.25) {
+		t.Errorf("expected percentile 1.25, got %.2f", pct)
+	}
+
+	pct, _, _, _ = b.Percentile("GOLD", RoleJungle, "cs_per_min", 5.95)
+	if !approxEqual(pct, 50) {
+		t.Errorf("expected percentile 50 between the 20th and 21st games, got %.2f", pct)
+	}
+
+	pct, _, _, _ = b.Percentile("GOLD", RoleJungle, "cs_per_min", 20)
+	if !approxEqual(pct, 100) {
+		t.Errorf("expected percentile 100 above every game, got %.2f", pct)
+	}
+}
+
+func TestBuildBenchmarks_FallsBackToAllTiers(t *testing.T) {
+	matches := append(makeBenchmarkMatches("GOLD", RoleTop, 25), makeBenchmarkMatches("DIAMOND", RoleTop, 5)...)
+	b := BuildBenchmarks(matches)
+
+	_, tier, samples, ok := b.Percentile("DIAMOND", RoleTop, "cs_per_min", 5)
+	if !ok {
+		t.Fatal("expected a fallback benchmark")
+	}
+	if tier != "" || samples != 30 {
+		t.Errorf("expected all tiers with 30 samples, got %q with %d", tier, samples)
+	}
+
+	if _, _, _, ok := b.Percentile("GOLD", RoleSupport, "cs_per_min", 1); ok {
+		t.Error("expected no benchmark for a role without games")
+	}
+	if _, _, _, ok := (*Benchmarks)(nil).Percentile("GOLD", RoleTop, "cs_per_min", 1); ok {
+		t.Error("expected nil benchmarks to report no data")
+	}
+}
+
+func TestAnalyzePlayer_PercentileInsights(t *testing.T) {
+	matches := []models.Match{
+		*makeMatch("M1", "player", func(p *models.Participant) { p.TotalMinionsKilled, p.NeutralMinionsKilled = 100, 0 }),
+		*makeMatch("M2", "player", func(p *models.Participant) { p.TotalMinionsKilled, p.NeutralMinionsKilled = 110, 0 }),
+	}
+
+	result, err := AnalyzePlayer(PlayerAnalysisParams{
+		PUUID:      "player",
+		Matches:    matches,
+		League:     &models.LeagueEntry{Tier: "GOLD"},
+		Benchmarks: BuildBenchmarks(makeBenchmarkMatches("GOLD", RoleMiddle, 30)),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var cs *MetricPercentile
+	for i := range result.Percentiles {
+		if result.Percentiles[i].Metric == "cs_per_min" {
+			cs = &result.Percentiles[i]
+		}
+	}
+	if cs == nil {
+		t.Fatal("expected a CS/min percentile")
+	}
+	if cs.Role != RoleMiddle || cs.Tier != "GOLD" || cs.Percentile != 0 {
+		t.Errorf("unexpected CS/min percentile: %+v", *cs)
+	}
+
+	found := false
+	for _, w := range result.Weaknesses {
+		if w.Category == "farming" {
+			found = true
+			if w.Description != "CS/min at the 0th percentile for Gold mid laners (3.5)" {
+				t.Errorf("unexpected description: %q", w.Description)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected a farming weakness from the benchmark")
+	}
+}
+
+func TestAnalyzePlayer_WithoutBenchmarksUsesThresholds(t *testing.T) {
+	result, err := AnalyzePlayer(PlayerAnalysisParams{
+		PUUID:   "player",
+		Matches: []models.Match{*makeMatch("M1", "player")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Percentiles != nil {
+		t.Errorf("expected no percentiles, got %d", len(result.Percentiles))
+	}
+	for _, s := range result.Strengths {
+		if strings.Contains(s.Description, "percentile") {
+			t.Errorf("unexpected percentile insight without benchmarks: %q", s.Description)
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	tests := map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 22: "22nd", 101: "101st"}
+	for n, want := range tests {
+		if got := ordinal(n); got != want {
+			t.Errorf("ordinal(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
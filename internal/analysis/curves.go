@@ -0,0 +1,160 @@
+package analysis
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// swingGoldThreshold is the team gold lead that makes a lost game a throw,
+// and the deficit that makes a won game a comeback.
+const swingGoldThreshold = 3000
+
+// minCurveGames is the minimum number of leading or trailing games before
+// throws and comebacks are reported as insights.
+const minCurveGames = 2
+
+// AnalyzeGameCurve computes the team gold diff, the player's gold share and the
+// XP diff against the lane opponent at every timeline frame.
+func AnalyzeGameCurve(timeline *models.Timeline, match *models.Match, puuid string) (*GameCurve, error) {
+	participantID, err := findTimelineParticipantID(timeline, puuid)
+	if err != nil {
+		return nil, err
+	}
+	participant, _, err := findParticipant(match, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	enemies := enemyParticipantIDs(timeline, match, participant.TeamID)
+	playerKey := strconv.Itoa(participantID)
+	opponentKey := strconv.Itoa(findLaneOpponent(match, puuid))
+
+	curve := &GameCurve{Points: make([]CurvePoint, 0, len(timeline.Info.Frames))}
+	for _, frame := range timeline.Info.Frames {
+		pf, ok := frame.ParticipantFrames[playerKey]
+		if !ok {
+			continue
+		}
+
+		var allyGold, enemyGold int
+		for key, f := range frame.ParticipantFrames {
+			id, err := strconv.Atoi(key)
+			if err != nil {
+				continue
+			}
+			if enemies[id] {
+				enemyGold += f.TotalGold
+			} else {
+				allyGold += f.TotalGold
+			}
+		}
+
+		point := CurvePoint{
+			Minute:       int(frame.Timestamp / 60_000),
+			TeamGoldDiff: allyGold - enemyGold,
+		}
+		if allyGold > 0 {
+			point.GoldShare = float64(pf.TotalGold) / float64(allyGold)
+		}
+		if of, ok := frame.ParticipantFrames[opponentKey]; ok {
+			point.XPDiff = pf.XP - of.XP
+		}
+		curve.Points = append(curve.Points, point)
+
+		if point.TeamGoldDiff > curve.MaxLead {
+			curve.MaxLead, curve.MaxLeadAt = point.TeamGoldDiff, point.Minute
+		}
+		if point.TeamGoldDiff < curve.MaxDeficit {
+			curve.MaxDeficit, curve.MaxDeficitAt = point.TeamGoldDiff, point.Minute
+		}
+	}
+
+	if n := len(curve.Points); n > 0 {
+		curve.FinalGoldDiff = curve.Points[n-1].TeamGoldDiff
+	}
+	curve.Throw = !participant.Win && curve.MaxLead >= swingGoldThreshold
+	curve.Comeback = participant.Win && curve.MaxDeficit <= -swingGoldThreshold
+
+	return curve, nil
+}
+
+func computeCurveSummary(analyses []MatchAnalysis) *CurveSummary {
+	summary := &CurveSummary{}
+
+	for _, a := range analyses {
+		c := a.Curve
+		if c == nil || len(c.Points) == 0 {
+			continue
+		}
+		summary.GamesWithTimeline++
+		if c.MaxLead >= swingGoldThreshold {
+			summary.GamesAhead++
+		}
+		if c.MaxDeficit <= -swingGoldThreshold {
+			summary.GamesBehind++
+		}
+		if c.Throw {
+			summary.Throws++
+		}
+		if c.Comeback {
+			summary.Comebacks++
+		}
+		summary.AvgGoldShare += c.Points[len(c.Points)-1].GoldShare
+		summary.AvgMaxLead += float64(c.MaxLead)
+		summary.AvgMaxDeficit += float64(c.MaxDeficit)
+	}
+
+	if summary.GamesWithTimeline == 0 {
+		return nil
+	}
+
+	n := float64(summary.GamesWithTimeline)
+	summary.AvgGoldShare /= n
+	summary.AvgMaxLead /= n
+	summary.AvgMaxDeficit /= n
+
+	return summary
+}
+
+// curveInsights reports how often leads are thrown and deficits recovered.
+func curveInsights(c *CurveSummary) (strengths []Insight, weaknesses []Insight) {
+	if c == nil {
+		return nil, nil
+	}
+
+	if c.GamesAhead >= minCurveGames {
+		throwRate := float64(c.Throws) / float64(c.GamesAhead)
+		if throwRate >= 0.3 {
+			weaknesses = append(weaknesses, Insight{
+				Category: "game_flow",
+				Description: fmt.Sprintf("Lost %d/%d games after a %dk+ gold lead -- group for objectives instead of taking fights",
+					c.Throws, c.GamesAhead, swingGoldThreshold/1000),
+				Value: throwRate,
+			})
+		} else if c.Throws == 0 {
+			strengths = append(strengths, Insight{
+				Category:    "game_flow",
+				Description: fmt.Sprintf("Closes out leads: won all %d games with a %dk+ gold lead", c.GamesAhead, swingGoldThreshold/1000),
+				Value:       1,
+				IsStrength:  true,
+			})
+		}
+	}
+
+	if c.GamesBehind >= minCurveGames {
+		comebackRate := float64(c.Comebacks) / float64(c.GamesBehind)
+		if comebackRate >= 0.3 {
+			strengths = append(strengths, Insight{
+				Category: "game_flow",
+				Description: fmt.Sprintf("Comes back from behind: won %d/%d games after a %dk+ gold deficit",
+					c.Comebacks, c.GamesBehind, swingGoldThreshold/1000),
+				Value:      comebackRate,
+				IsStrength: true,
+			})
+		}
+	}
+
+	return strengths, weaknesses
+}
@@ -0,0 +1,79 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeGameCurve(t *testing.T) {
+	puuids := []string{"p1", "p2", "p3", "p4"}
+	timeline := makeTimeline(puuids, 20)
+
+	lead := timeline.Info.Frames[10].ParticipantFrames["1"]
+	lead.TotalGold = 10000
+	timeline.Info.Frames[10].ParticipantFrames["1"] = lead
+
+	xp := timeline.Info.Frames[5].ParticipantFrames["1"]
+	xp.XP += 500
+	timeline.Info.Frames[5].ParticipantFrames["1"] = xp
+
+	match := makeTimelineMatch(puuids)
+
+	c, err := AnalyzeGameCurve(timeline, match, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(c.Points) != 20 {
+		t.Fatalf("expected 20 points, got %d", len(c.Points))
+	}
+	if got := c.Points[0]; got.TeamGoldDiff != -80 || !approxEqual(got.GoldShare, 420.0/860) {
+		t.Errorf("unexpected first point: %+v", got)
+	}
+	if c.Points[5].XPDiff != 500 {
+		t.Errorf("expected XP diff 500 at minute 5, got %d", c.Points[5].XPDiff)
+	}
+	if c.MaxLead != 4500 || c.MaxLeadAt != 10 {
+		t.Errorf("expected max lead 4500 at minute 10, got %d at %d", c.MaxLead, c.MaxLeadAt)
+	}
+	if c.MaxDeficit != -1600 || c.FinalGoldDiff != -1600 {
+		t.Errorf("expected deficit and final diff -1600, got %d and %d", c.MaxDeficit, c.FinalGoldDiff)
+	}
+	if !c.Throw || c.Comeback {
+		t.Errorf("expected a throw, got throw=%v comeback=%v", c.Throw, c.Comeback)
+	}
+}
+
+func TestComputeCurveSummary(t *testing.T) {
+	points := []CurvePoint{{GoldShare: 0.2}, {GoldShare: 0.3}}
+	analyses := []MatchAnalysis{
+		{Curve: &GameCurve{Points: points, MaxLead: 4000, MaxDeficit: -200, Throw: true}},
+		{Curve: &GameCurve{Points: points, MaxLead: 3500}},
+		{Curve: &GameCurve{Points: points, MaxLead: 3200, MaxDeficit: -100, Throw: true}},
+		{Curve: &GameCurve{Points: points, MaxDeficit: -3300, Comeback: true}},
+		{},
+	}
+
+	c := computeCurveSummary(analyses)
+	if c == nil {
+		t.Fatal("expected a summary")
+	}
+	if c.GamesWithTimeline != 4 || c.GamesAhead != 3 || c.GamesBehind != 1 || c.Throws != 2 || c.Comebacks != 1 {
+		t.Errorf("unexpected counts: %+v", c)
+	}
+	if !approxEqual(c.AvgGoldShare, 0.3) || !approxEqual(c.AvgMaxLead, 2675) || !approxEqual(c.AvgMaxDeficit, -900) {
+		t.Errorf("unexpected averages: %+v", c)
+	}
+
+	strengths, weaknesses := curveInsights(c)
+	if len(strengths) != 0 {
+		t.Errorf("expected no strengths from a single comeback, got %+v", strengths)
+	}
+	if len(weaknesses) != 1 || !strings.Contains(weaknesses[0].Description, "Lost 2/3 games after a 3k+ gold lead") {
+		t.Errorf("expected a throw weakness, got %+v", weaknesses)
+	}
+
+	if computeCurveSummary([]MatchAnalysis{{}}) != nil {
+		t.Error("expected nil summary without timelines")
+	}
+}
@@ -0,0 +1,209 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// Map zones a death can be classified into, relative to the player's team.
+const (
+	ZoneOwnBase     = "own_base"
+	ZoneEnemyBase   = "enemy_base"
+	ZoneTopLane     = "top_lane"
+	ZoneMidLane     = "mid_lane"
+	ZoneBotLane     = "bot_lane"
+	ZoneRiver       = "river"
+	ZoneOwnJungle   = "own_jungle"
+	ZoneEnemyJungle = "enemy_jungle"
+)
+
+// How a death happened.
+const (
+	DeathSolo        = "solo"        // killed by a single enemy champion
+	DeathOutnumbered = "outnumbered" // two or more enemy champions credited
+	DeathExecuted    = "executed"    // killed by a turret, minion or monster
+)
+
+// Summoner's Rift geometry in map units. Blue side (team 100) is bottom-left.
+const (
+	MapSize        = 15000
+	baseRadius     = 5000 // distance from a corner that counts as base
+	laneEdgeWidth  = 2200 // width of the top and bottom lanes along the map edges
+	midLaneWidth   = 1500 // half-width of mid lane around the blue-red diagonal
+	riverHalfWidth = 1300 // half-width of the river around the other diagonal
+	blueTeamID     = 100
+	maxParticipant = 10
+)
+
+// AnalyzeDeaths classifies each of the player's deaths by zone, by how many enemies
+// were involved, and by the shutdown gold it gave up.
+func AnalyzeDeaths(timeline *models.Timeline, match *models.Match, puuid string) (*DeathMetrics, error) {
+	participantID, err := findTimelineParticipantID(timeline, puuid)
+	if err != nil {
+		return nil, err
+	}
+	participant, _, err := findParticipant(match, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &DeathMetrics{Deaths: []DeathEvent{}}
+	for _, frame := range timeline.Info.Frames {
+		for _, event := range frame.Events {
+			if event.Type != "CHAMPION_KILL" || event.VictimID != participantID {
+				continue
+			}
+
+			death := DeathEvent{
+				Time:         event.Timestamp / 1000,
+				Attackers:    countAttackers(event),
+				ShutdownGold: event.ShutdownBounty,
+			}
+			if event.Position != nil {
+				death.Position = *event.Position
+				death.Zone = ClassifyZone(*event.Position, participant.TeamID)
+			}
+			switch {
+			case death.Attackers == 0:
+				death.Context = DeathExecuted
+			case death.Attackers == 1:
+				death.Context = DeathSolo
+			default:
+				death.Context = DeathOutnumbered
+			}
+			metrics.Deaths = append(metrics.Deaths, death)
+		}
+	}
+
+	return metrics, nil
+}
+
+// countAttackers counts enemy champions credited with a kill. Killer IDs outside
+// 1-10 (turrets, minions, monsters) are not champions.
+func countAttackers(event models.TimelineEvent) int {
+	n := 0
+	if event.KillerID >= 1 && event.KillerID <= maxParticipant {
+		n++
+	}
+	for _, id := range event.AssistingParticipantIDs {
+		if id >= 1 && id <= maxParticipant {
+			n++
+		}
+	}
+	return n
+}
+
+// ClassifyZone returns the map zone of pos from the point of view of teamID.
+func ClassifyZone(pos models.Position, teamID int) string {
+	x, y := float64(pos.X), float64(pos.Y)
+	blue := teamID == blueTeamID
+
+	switch {
+	case math.Hypot(x, y) < baseRadius:
+		return sideZone(blue, ZoneOwnBase, ZoneEnemyBase)
+	case math.Hypot(MapSize-x, MapSize-y) < baseRadius:
+		return sideZone(!blue, ZoneOwnBase, ZoneEnemyBase)
+	case x < laneEdgeWidth || y > MapSize-laneEdgeWidth:
+		return ZoneTopLane
+	case y < laneEdgeWidth || x > MapSize-laneEdgeWidth:
+		return ZoneBotLane
+	case math.Abs(x-y) < midLaneWidth:
+		return ZoneMidLane
+	case math.Abs(x+y-MapSize) < riverHalfWidth:
+		return ZoneRiver
+	case x+y < MapSize:
+		return sideZone(blue, ZoneOwnJungle, ZoneEnemyJungle)
+	default:
+		return sideZone(!blue, ZoneOwnJungle, ZoneEnemyJungle)
+	}
+}
+
+// sideZone picks the own or enemy variant of a zone on the blue half of the map.
+func sideZone(onBlueHalf bool, own, enemy string) string {
+	if onBlueHalf {
+		return own
+	}
+	return enemy
+}
+
+func computeDeathSummary(analyses []MatchAnalysis) *DeathSummary {
+	summary := &DeathSummary{}
+	zones := make(map[string]int)
+
+	for _, a := range analyses {
+		if a.Deaths == nil {
+			continue
+		}
+		summary.GamesWithTimeline++
+		for _, d := range a.Deaths.Deaths {
+			summary.TotalDeaths++
+			if d.Zone != "" {
+				zones[d.Zone]++
+			}
+			switch d.Context {
+			case DeathSolo:
+				summary.SoloDeaths++
+			case DeathOutnumbered:
+				summary.OutnumberedDeaths++
+			case DeathExecuted:
+				summary.ExecutedDeaths++
+			}
+			if d.ShutdownGold > 0 {
+				summary.ShutdownsGiven++
+				summary.ShutdownGold += d.ShutdownGold
+			}
+		}
+	}
+
+	if summary.GamesWithTimeline == 0 {
+		return nil
+	}
+
+	summary.ByZone = make([]DeathZoneStats, 0, len(zones))
+	for zone, n := range zones {
+		summary.ByZone = append(summary.ByZone, DeathZoneStats{
+			Zone:   zone,
+			Deaths: n,
+			Share:  float64(n) / float64(summary.TotalDeaths),
+		})
+	}
+	sort.Slice(summary.ByZone, func(i, j int) bool {
+		if summary.ByZone[i].Deaths != summary.ByZone[j].Deaths {
+			return summary.ByZone[i].Deaths > summary.ByZone[j].Deaths
+		}
+		return summary.ByZone[i].Zone < summary.ByZone[j].Zone
+	})
+
+	return summary
+}
+
+// DeathHeatmap bins death positions into a rows x cols grid covering the map.
+// Row 0 is the top of the map (red side), matching how the map is usually drawn.
+func DeathHeatmap(positions []models.Position, cols, rows int) [][]int {
+	grid := make([][]int, rows)
+	for i := range grid {
+		grid[i] = make([]int, cols)
+	}
+	if cols == 0 || rows == 0 {
+		return grid
+	}
+
+	for _, p := range positions {
+		col := clampCell(p.X*cols/MapSize, cols)
+		row := rows - 1 - clampCell(p.Y*rows/MapSize, rows)
+		grid[row][col]++
+	}
+	return grid
+}
+
+func clampCell(i, n int) int {
+	if i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
@@ -0,0 +1,129 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+func TestClassifyZone(t *testing.T) {
+	tests := []struct {
+		name   string
+		pos    models.Position
+		teamID int
+		want   string
+	}{
+		{"blue fountain for blue", models.Position{X: 500, Y: 500}, 100, ZoneOwnBase},
+		{"blue fountain for red", models.Position{X: 500, Y: 500}, 200, ZoneEnemyBase},
+		{"red fountain for blue", models.Position{X: 14300, Y: 14300}, 100, ZoneEnemyBase},
+		{"top lane", models.Position{X: 1200, Y: 9000}, 100, ZoneTopLane},
+		{"top lane corner", models.Position{X: 2500, Y: 13500}, 100, ZoneTopLane},
+		{"bot lane", models.Position{X: 10000, Y: 1000}, 100, ZoneBotLane},
+		{"mid lane", models.Position{X: 7400, Y: 7600}, 100, ZoneMidLane},
+		{"dragon pit", models.Position{X: 9866, Y: 4414}, 100, ZoneRiver},
+		{"baron pit", models.Position{X: 5007, Y: 10471}, 200, ZoneRiver},
+		{"blue buff for blue", models.Position{X: 3800, Y: 7900}, 100, ZoneOwnJungle},
+		{"blue buff for red", models.Position{X: 3800, Y: 7900}, 200, ZoneEnemyJungle},
+		{"red side jungle for red", models.Position{X: 7100, Y: 10900}, 200, ZoneOwnJungle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyZone(tt.pos, tt.teamID); got != tt.want {
+				t.Errorf("ClassifyZone(%v, %d) = %s, want %s", tt.pos, tt.teamID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeDeaths(t *testing.T) {
+	puuids := []string{"p1", "p2"}
+	timeline := makeTimeline(puuids, 20)
+	jungle := models.Position{X: 7100, Y: 10900}
+	lane := models.Position{X: 1200, Y: 9000}
+	timeline.Info.Frames[4].Events = []models.TimelineEvent{
+		{Type: "CHAMPION_KILL", Timestamp: 240_000, KillerID: 2, VictimID: 1, Position: &lane},
+		{Type: "CHAMPION_KILL", Timestamp: 250_000, KillerID: 1, VictimID: 2, Position: &lane},
+	}
+	timeline.Info.Frames[12].Events = []models.TimelineEvent{
+		{Type: "CHAMPION_KILL", Timestamp: 720_000, KillerID: 2, VictimID: 1, AssistingParticipantIDs: []int{7, 8}, ShutdownBounty: 450, Position: &jungle},
+	}
+	timeline.Info.Frames[15].Events = []models.TimelineEvent{
+		{Type: "CHAMPION_KILL", Timestamp: 900_000, KillerID: 0, VictimID: 1, Position: &lane},
+	}
+	match := makeTimelineMatch(puuids)
+
+	d, err := AnalyzeDeaths(timeline, match, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(d.Deaths) != 3 {
+		t.Fatalf("expected 3 deaths, got %d", len(d.Deaths))
+	}
+
+	if got := d.Deaths[0]; got.Context != DeathSolo || got.Zone != ZoneTopLane || got.Time != 240 {
+		t.Errorf("first death: got %+v", got)
+	}
+	if got := d.Deaths[1]; got.Context != DeathOutnumbered || got.Attackers != 3 || got.Zone != ZoneEnemyJungle || got.ShutdownGold != 450 {
+		t.Errorf("second death: got %+v", got)
+	}
+	if got := d.Deaths[2]; got.Context != DeathExecuted || got.Attackers != 0 {
+		t.Errorf("third death: got %+v", got)
+	}
+}
+
+func TestComputeDeathSummary(t *testing.T) {
+	analyses := []MatchAnalysis{
+		{Deaths: &DeathMetrics{Deaths: []DeathEvent{
+			{Zone: ZoneRiver, Context: DeathOutnumbered, ShutdownGold: 300},
+			{Zone: ZoneRiver, Context: DeathSolo},
+		}}},
+		{Deaths: &DeathMetrics{Deaths: []DeathEvent{
+			{Zone: ZoneEnemyJungle, Context: DeathOutnumbered},
+			{Zone: ZoneRiver, Context: DeathExecuted},
+		}}},
+		{},
+	}
+
+	s := computeDeathSummary(analyses)
+	if s == nil {
+		t.Fatal("expected summary")
+	}
+	if s.GamesWithTimeline != 2 || s.TotalDeaths != 4 {
+		t.Errorf("expected 4 deaths over 2 games, got %d over %d", s.TotalDeaths, s.GamesWithTimeline)
+	}
+	if len(s.ByZone) != 2 || s.ByZone[0].Zone != ZoneRiver || s.ByZone[0].Deaths != 3 || !approxEqual(s.ByZone[0].Share, 0.75) {
+		t.Errorf("unexpected zone breakdown %+v", s.ByZone)
+	}
+	if s.SoloDeaths != 1 || s.OutnumberedDeaths != 2 || s.ExecutedDeaths != 1 {
+		t.Errorf("unexpected contexts: %d solo, %d outnumbered, %d executed", s.SoloDeaths, s.OutnumberedDeaths, s.ExecutedDeaths)
+	}
+	if s.ShutdownsGiven != 1 || s.ShutdownGold != 300 {
+		t.Errorf("expected 1 shutdown worth 300, got %d worth %d", s.ShutdownsGiven, s.ShutdownGold)
+	}
+
+	if computeDeathSummary([]MatchAnalysis{{}}) != nil {
+		t.Error("expected nil summary without timelines")
+	}
+}
+
+func TestDeathHeatmap(t *testing.T) {
+	positions := []models.Position{
+		{X: 100, Y: 100},     // bottom-left
+		{X: 200, Y: 300},     // bottom-left
+		{X: 14900, Y: 14900}, // top-right
+		{X: 15500, Y: -50},   // off-map, clamped to bottom-right
+	}
+
+	grid := DeathHeatmap(positions, 3, 3)
+
+	if grid[2][0] != 2 {
+		t.Errorf("expected 2 deaths bottom-left, got %d", grid[2][0])
+	}
+	if grid[0][2] != 1 {
+		t.Errorf("expected 1 death top-right, got %d", grid[0][2])
+	}
+	if grid[2][2] != 1 {
+		t.Errorf("expected clamped death bottom-right, got %d", grid[2][2])
+	}
+}
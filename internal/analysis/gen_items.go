@@ -0,0 +1,65 @@
+//go:build ignore
+
+// gen_items refreshes completed_items.json from the latest Data Dragon item.json.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/HatiCode/league-buddy/internal/analysis"
+)
+
+const ddragonURL = "https://ddragon.leagueoflegends.com"
+
+func main() {
+	var versions []string
+	if err := getJSON(ddragonURL+"/api/versions.json", &versions); err != nil {
+		log.Fatalf("get versions: %v", err)
+	}
+	if len(versions) == 0 {
+		log.Fatal("no Data Dragon versions")
+	}
+
+	data, err := get(fmt.Sprintf("%s/cdn/%s/data/en_US/item.json", ddragonURL, versions[0]))
+	if err != nil {
+		log.Fatalf("get item.json: %v", err)
+	}
+	items, err := analysis.CompletedItemsFromDataDragon(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out, err := analysis.EncodeCompletedItems(items)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("completed_items.json", out, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("wrote %d completed items from Data Dragon %s", len(items), versions[0])
+}
+
+func get(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func getJSON(url string, v any) error {
+	data, err := get(url)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,308 @@
+package analysis
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+//go:generate go run gen_items.go
+
+//go:embed completed_items.json
+var completedItemsJSON []byte
+
+// completedItems is the set of completed (legendary) Summoner's Rift items, as
+// derived from Data Dragon by CompletedItemsFromDataDragon. Run go generate
+// after a patch changes the item shop.
+var completedItems = mustParseCompletedItems(completedItemsJSON)
+
+// IsCompletedItem reports whether an item ID is a completed (legendary) item.
+func IsCompletedItem(itemID int) bool {
+	return completedItems[itemID]
+}
+
+// completedItemList is the format of completed_items.json.
+type completedItemList struct {
+	Items []int `json:"items"`
+}
+
+func mustParseCompletedItems(data []byte) map[int]bool {
+	var list completedItemList
+	if err := json.Unmarshal(data, &list); err != nil {
+		panic(fmt.Sprintf("invalid embedded completed items: %v", err))
+	}
+	items := make(map[int]bool, len(list.Items))
+	for _, id := range list.Items {
+		items[id] = true
+	}
+	return items
+}
+
+// EncodeCompletedItems renders item IDs in the completed_items.json format.
+func EncodeCompletedItems(ids []int) ([]byte, error) {
+	out, err := json.MarshalIndent(completedItemList{Items: ids}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// ddragonItem holds the item.json fields that decide whether an item is completed.
+type ddragonItem struct {
+	Into []string `json:"into"`
+	From []string `json:"from"`
+	Tags []string `json:"tags"`
+	Gold struct {
+		Purchasable bool `json:"purchasable"`
+	} `json:"gold"`
+	Maps             map[string]bool `json:"maps"`
+	RequiredChampion string          `json:"requiredChampion"`
+	RequiredAlly     string          `json:"requiredAlly"`
+}
+
+// CompletedItemsFromDataDragon derives the completed items from Data Dragon's
+// item.json: purchasable Summoner's Rift items that build from components and
+// into nothing that can be bought, leaving out consumables, boots, trinkets and
+// champion-specific items. Items that only transform further (Archangel's Staff
+// into Seraph's Embrace) are still completed. IDs are returned in ascending order.
+func CompletedItemsFromDataDragon(data []byte) ([]int, error) {
+	var catalog struct {
+		Data map[string]ddragonItem `json:"data"`
+	}
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("decode item.json: %w", err)
+	}
+
+	var ids []int
+	for key, item := range catalog.Data {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid item ID %q", key)
+		}
+		if !item.Gold.Purchasable || !item.Maps[strconv.Itoa(models.MapIDSummonersRift)] {
+			continue
+		}
+		if len(item.From) == 0 {
+			continue // a starter or basic item built from nothing
+		}
+		if slices.ContainsFunc(item.Into, func(into string) bool {
+			return catalog.Data[into].Gold.Purchasable
+		}) {
+			continue // a component of another shop item
+		}
+		if item.RequiredChampion != "" || item.RequiredAlly != "" {
+			continue
+		}
+		if slices.ContainsFunc(item.Tags, func(tag string) bool {
+			return tag == "Consumable" || tag == "Boots" || tag == "Trinket"
+		}) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// finalItems returns the player's end-of-game inventory, trinket last.
+func finalItems(p *models.Participant) []int {
+	var items []int
+	for _, id := range []int{p.Item0, p.Item1, p.Item2, p.Item3, p.Item4, p.Item5, p.Item6} {
+		if id != 0 {
+			items = append(items, id)
+		}
+	}
+	return items
+}
+
+// AnalyzeBuild reconstructs the player's purchases from item events and times
+// their first two completed items.
+func AnalyzeBuild(timeline *models.Timeline, puuid string) (*BuildMetrics, error) {
+	participantID, err := findTimelineParticipantID(timeline, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &BuildMetrics{}
+	for _, frame := range timeline.Info.Frames {
+		for _, event := range frame.Events {
+			if event.ParticipantID != participantID {
+				continue
+			}
+			item := ItemEvent{ItemID: event.ItemID, Time: int(event.Timestamp / 1000)}
+
+			switch event.Type {
+			case "ITEM_PURCHASED":
+				metrics.Purchases = append(metrics.Purchases, item)
+			case "ITEM_SOLD":
+				metrics.Sold = append(metrics.Sold, item)
+			case "ITEM_UNDO":
+				// Undoing a purchase reports the item in beforeId, undoing a sale in afterId.
+				if event.BeforeID != 0 {
+					metrics.Purchases = removeLastItem(metrics.Purchases, event.BeforeID)
+				} else if event.AfterID != 0 {
+					metrics.Sold = removeLastItem(metrics.Sold, event.AfterID)
+				}
+			}
+		}
+	}
+
+	seen := make(map[int]bool)
+	for _, p := range metrics.Purchases {
+		if IsCompletedItem(p.ItemID) && !seen[p.ItemID] {
+			seen[p.ItemID] = true
+			metrics.CompletedItems = append(metrics.CompletedItems, p)
+		}
+	}
+	if len(metrics.CompletedItems) > 0 {
+		metrics.FirstItemAt = metrics.CompletedItems[0].Time
+	}
+	if len(metrics.CompletedItems) > 1 {
+		metrics.SecondItemAt = metrics.CompletedItems[1].Time
+	}
+
+	return metrics, nil
+}
+
+func removeLastItem(events []ItemEvent, itemID int) []ItemEvent {
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].ItemID == itemID {
+			return append(events[:i], events[i+1:]...)
+		}
+	}
+	return events
+}
+
+// AnalyzeBuilds compares item timings per champion across games with a timeline,
+// most played champion first.
+func AnalyzeBuilds(analyses []MatchAnalysis) []ChampionBuildStats {
+	type accumulator struct {
+		stats                   ChampionBuildStats
+		first, second, winFirst []int
+		lossFirst               []int
+		firstItems, secondItems map[int]int
+	}
+
+	byChampion := make(map[string]*accumulator)
+	var order []string
+
+	for _, a := range analyses {
+		b := a.Build
+		if b == nil {
+			continue
+		}
+		name := a.Metrics.ChampionName
+		acc, ok := byChampion[name]
+		if !ok {
+			acc = &accumulator{
+				stats:       ChampionBuildStats{ChampionName: name},
+				firstItems:  make(map[int]int),
+				secondItems: make(map[int]int),
+			}
+			byChampion[name] = acc
+			order = append(order, name)
+		}
+
+		acc.stats.GamesWithTimeline++
+		if a.Metrics.Win {
+			acc.stats.Wins++
+		}
+		if b.FirstItemAt > 0 {
+			acc.first = append(acc.first, b.FirstItemAt)
+			acc.firstItems[b.CompletedItems[0].ItemID]++
+			if a.Metrics.Win {
+				acc.winFirst = append(acc.winFirst, b.FirstItemAt)
+			} else {
+				acc.lossFirst = append(acc.lossFirst, b.FirstItemAt)
+			}
+		}
+		if b.SecondItemAt > 0 {
+			acc.second = append(acc.second, b.SecondItemAt)
+			acc.secondItems[b.CompletedItems[1].ItemID]++
+		}
+	}
+
+	builds := make([]ChampionBuildStats, 0, len(order))
+	for _, name := range order {
+		acc := byChampion[name]
+		s := acc.stats
+		s.AvgFirstItemAt = meanSeconds(acc.first)
+		s.AvgSecondItemAt = meanSeconds(acc.second)
+		s.AvgFirstItemAtWins = meanSeconds(acc.winFirst)
+		s.AvgFirstItemAtLosses = meanSeconds(acc.lossFirst)
+		s.FirstItemWins = len(acc.winFirst)
+		s.FirstItemLosses = len(acc.lossFirst)
+		s.CommonFirstItem = mostCommon(acc.firstItems)
+		s.CommonSecondItem = mostCommon(acc.secondItems)
+		builds = append(builds, s)
+	}
+
+	sort.SliceStable(builds, func(i, j int) bool {
+		return builds[i].GamesWithTimeline > builds[j].GamesWithTimeline
+	})
+
+	return builds
+}
+
+func meanSeconds(values []int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return float64(total) / float64(len(values))
+}
+
+// mostCommon returns the most frequent key, preferring the lowest ID on ties.
+func mostCommon(counts map[int]int) int {
+	best, bestCount := 0, 0
+	for id, n := range counts {
+		if n > bestCount || (n == bestCount && id < best) {
+			best, bestCount = id, n
+		}
+	}
+	return best
+}
+
+const (
+	// slowItemSpikeSeconds is how much later the first item must land in losses
+	// than in wins before it is called out.
+	slowItemSpikeSeconds = 90
+
+	// minBuildGames is the minimum number of wins and of losses with a completed
+	// first item on a champion before its item timings are compared.
+	minBuildGames = 3
+)
+
+// buildInsights flags champions whose first item comes noticeably later in losses than in wins.
+func buildInsights(builds []ChampionBuildStats) (weaknesses []Insight) {
+	for _, b := range builds {
+		if b.FirstItemWins < minBuildGames || b.FirstItemLosses < minBuildGames {
+			continue
+		}
+		delay := b.AvgFirstItemAtLosses - b.AvgFirstItemAtWins
+		if delay < slowItemSpikeSeconds {
+			continue
+		}
+		weaknesses = append(weaknesses, Insight{
+			Category: "items",
+			Description: fmt.Sprintf("%s: first item at %s in losses vs. your %s average on wins -- slow power spike",
+				b.ChampionName, formatSeconds(b.AvgFirstItemAtLosses), formatSeconds(b.AvgFirstItemAtWins)),
+			Value: delay,
+		})
+	}
+	return weaknesses
+}
+
+// formatSeconds renders seconds from game start as m:ss.
+func formatSeconds(seconds float64) string {
+	s := int(seconds)
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
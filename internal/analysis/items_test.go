@@ -0,0 +1,185 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+func TestIsCompletedItem(t *testing.T) {
+	tests := []struct {
+		itemID int
+		want   bool
+	}{
+		{1055, false}, // Doran's Blade
+		{2003, false}, // Health Potion
+		{3006, false}, // Berserker's Greaves
+		{3340, false}, // Stealth Ward
+		{3057, false}, // Sheen
+		{3024, false}, // Glacial Buckler
+		{3866, false}, // Runic Compass
+		{2502, true},  // Unending Despair
+		{3078, true},  // Trinity Force
+		{6655, true},  // Luden's Companion
+	}
+
+	for _, tt := range tests {
+		if got := IsCompletedItem(tt.itemID); got != tt.want {
+			t.Errorf("IsCompletedItem(%d) = %v, want %v", tt.itemID, got, tt.want)
+		}
+	}
+}
+
+func TestCompletedItemsFromDataDragon(t *testing.T) {
+	itemJSON := `{"data": {
+		"1055": {"tags": ["Damage"], "gold": {"purchasable": true}, "maps": {"11": true}},
+		"2003": {"tags": ["Consumable"], "from": [], "gold": {"purchasable": true}, "maps": {"11": true}},
+		"3003": {"into": ["3040"], "from": ["3070", "3802"], "gold": {"purchasable": true}, "maps": {"11": true}},
+		"3006": {"tags": ["Boots"], "from": ["1001"], "gold": {"purchasable": true}, "maps": {"11": true}},
+		"3024": {"into": ["3110"], "from": ["1029", "1027"], "gold": {"purchasable": true}, "maps": {"11": true}},
+		"3040": {"from": ["3003"], "gold": {"purchasable": false}, "maps": {"11": true}},
+		"3070": {"into": ["3003"], "from": [], "gold": {"purchasable": true}, "maps": {"11": true}},
+		"3078": {"from": ["3057", "3044"], "gold": {"purchasable": true}, "maps": {"11": true, "12": true}},
+		"3110": {"from": ["3024"], "gold": {"purchasable": true}, "maps": {"11": true}},
+		"3600": {"from": ["1036"], "gold": {"purchasable": true}, "maps": {"11": true}, "requiredChampion": "Kalista"},
+		"7000": {"from": ["3078"], "gold": {"purchasable": false}, "maps": {"11": true}, "requiredAlly": "Ornn"},
+		"223078": {"from": ["3057", "3044"], "gold": {"purchasable": true}, "maps": {"11": false, "30": true}}
+	}}`
+
+	got, err := CompletedItemsFromDataDragon([]byte(itemJSON))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{3003, 3078, 3110}; !slices.Equal(got, want) {
+		t.Errorf("expected completed items %v, got %v", want, got)
+	}
+
+	if _, err := CompletedItemsFromDataDragon([]byte(`{"data": []}`)); err == nil {
+		t.Error("expected an error for malformed item.json")
+	}
+}
+
+func TestCompletedItemsJSON_GeneratorFormat(t *testing.T) {
+	var list completedItemList
+	if err := json.Unmarshal(completedItemsJSON, &list); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.IsSorted(list.Items) || len(slices.Compact(slices.Clone(list.Items))) != len(list.Items) {
+		t.Error("expected sorted, unique item IDs")
+	}
+	want, err := EncodeCompletedItems(list.Items)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(completedItemsJSON, want) {
+		t.Error("completed_items.json differs from the go generate output format; regenerate it instead of editing by hand")
+	}
+}
+
+func TestAnalyzeBuild(t *testing.T) {
+	timeline := makeTimeline([]string{"p1", "p2"}, 25)
+	timeline.Info.Frames[1].Events = []models.TimelineEvent{
+		{Type: "ITEM_PURCHASED", Timestamp: 10_000, ParticipantID: 1, ItemID: 1056},
+		{Type: "ITEM_PURCHASED", Timestamp: 11_000, ParticipantID: 2, ItemID: 1055},
+	}
+	timeline.Info.Frames[8].Events = []models.TimelineEvent{
+		{Type: "ITEM_PURCHASED", Timestamp: 480_000, ParticipantID: 1, ItemID: 3078},
+		{Type: "ITEM_UNDO", Timestamp: 482_000, ParticipantID: 1, BeforeID: 3078},
+		{Type: "ITEM_PURCHASED", Timestamp: 485_000, ParticipantID: 1, ItemID: 3802},
+	}
+	timeline.Info.Frames[12].Events = []models.TimelineEvent{
+		{Type: "ITEM_PURCHASED", Timestamp: 710_000, ParticipantID: 1, ItemID: 6655},
+		{Type: "ITEM_SOLD", Timestamp: 715_000, ParticipantID: 1, ItemID: 1056},
+	}
+	timeline.Info.Frames[20].Events = []models.TimelineEvent{
+		{Type: "ITEM_PURCHASED", Timestamp: 1_190_000, ParticipantID: 1, ItemID: 3020},
+		{Type: "ITEM_PURCHASED", Timestamp: 1_200_000, ParticipantID: 1, ItemID: 4645},
+	}
+
+	b, err := AnalyzeBuild(timeline, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(b.Purchases) != 5 {
+		t.Errorf("expected 5 purchases after the undo, got %d: %+v", len(b.Purchases), b.Purchases)
+	}
+	if len(b.Sold) != 1 || b.Sold[0].ItemID != 1056 {
+		t.Errorf("expected Doran's Ring sold, got %+v", b.Sold)
+	}
+	if len(b.CompletedItems) != 2 || b.CompletedItems[0].ItemID != 6655 || b.CompletedItems[1].ItemID != 4645 {
+		t.Fatalf("unexpected completed items: %+v", b.CompletedItems)
+	}
+	if b.FirstItemAt != 710 || b.SecondItemAt != 1200 {
+		t.Errorf("expected items at 710s and 1200s, got %d and %d", b.FirstItemAt, b.SecondItemAt)
+	}
+}
+
+func TestAnalyzeMatch_FinalItems(t *testing.T) {
+	match := makeMatch("EUW1_1", "p1", func(p *models.Participant) {
+		p.Item0, p.Item2, p.Item6 = 6655, 3020, 3340
+	})
+
+	result, err := AnalyzeMatch(match, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Metrics.Items; len(got) != 3 || got[0] != 6655 || got[1] != 3020 || got[2] != 3340 {
+		t.Errorf("expected final items [6655 3020 3340], got %v", got)
+	}
+}
+
+func TestAnalyzeBuilds(t *testing.T) {
+	build := func(first, second int) *BuildMetrics {
+		b := &BuildMetrics{FirstItemAt: first, SecondItemAt: second}
+		b.CompletedItems = []ItemEvent{{ItemID: 6655, Time: first}, {ItemID: 4645, Time: second}}
+		return b
+	}
+	analyses := []MatchAnalysis{
+		{Metrics: MatchMetrics{ChampionName: "Ahri", Win: true}, Build: build(700, 1100)},
+		{Metrics: MatchMetrics{ChampionName: "Ahri", Win: true}, Build: build(720, 1150)},
+		{Metrics: MatchMetrics{ChampionName: "Ahri", Win: false}, Build: build(870, 1300)},
+		{Metrics: MatchMetrics{ChampionName: "Zed", Win: false}, Build: &BuildMetrics{}},
+		{Metrics: MatchMetrics{ChampionName: "Lux", Win: true}},
+	}
+
+	builds := AnalyzeBuilds(analyses)
+	if len(builds) != 2 {
+		t.Fatalf("expected 2 champions with builds, got %d", len(builds))
+	}
+
+	ahri := builds[0]
+	if ahri.ChampionName != "Ahri" || ahri.GamesWithTimeline != 3 || ahri.Wins != 2 {
+		t.Errorf("unexpected Ahri stats: %+v", ahri)
+	}
+	if !approxEqual(ahri.AvgFirstItemAt, 2290.0/3) || !approxEqual(ahri.AvgFirstItemAtWins, 710) || !approxEqual(ahri.AvgFirstItemAtLosses, 870) {
+		t.Errorf("unexpected Ahri timings: %+v", ahri)
+	}
+	if ahri.CommonFirstItem != 6655 || ahri.CommonSecondItem != 4645 {
+		t.Errorf("unexpected common items: %d, %d", ahri.CommonFirstItem, ahri.CommonSecondItem)
+	}
+
+	if ahri.FirstItemWins != 2 || ahri.FirstItemLosses != 1 {
+		t.Errorf("expected 2 wins and 1 loss behind the timings, got %d and %d", ahri.FirstItemWins, ahri.FirstItemLosses)
+	}
+	if weaknesses := buildInsights(builds); len(weaknesses) != 0 {
+		t.Errorf("expected no item insight below %d wins and losses, got %q", minBuildGames, weaknesses[0].Description)
+	}
+
+	analyses = append(analyses,
+		MatchAnalysis{Metrics: MatchMetrics{ChampionName: "Ahri", Win: true}, Build: build(710, 1120)},
+		MatchAnalysis{Metrics: MatchMetrics{ChampionName: "Ahri", Win: false}, Build: build(860, 1290)},
+		MatchAnalysis{Metrics: MatchMetrics{ChampionName: "Ahri", Win: false}, Build: build(880, 1310)},
+	)
+	weaknesses := buildInsights(AnalyzeBuilds(analyses))
+	if len(weaknesses) != 1 {
+		t.Fatalf("expected 1 item insight, got %d", len(weaknesses))
+	}
+	if want := "Ahri: first item at 14:30 in losses vs. your 11:50 average on wins"; !strings.Contains(weaknesses[0].Description, want) {
+		t.Errorf("expected %q in %q", want, weaknesses[0].Description)
+	}
+}
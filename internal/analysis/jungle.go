@@ -0,0 +1,359 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+const (
+	// firstClearCS is the jungle CS of a full six-camp clear.
+	firstClearCS = 24
+	// gankWindowMs is how far a kill may be from a lane visit and still belong to that gank.
+	gankWindowMs = 90_000
+	// gankStartMs skips the first frames while the jungler is still on their first camps.
+	gankStartMs = 90_000
+
+	// minJungleGames is the minimum number of jungle games before jungle insights are reported.
+	minJungleGames = 3
+)
+
+var laneZones = []string{ZoneTopLane, ZoneMidLane, ZoneBotLane}
+
+// AnalyzeJungle builds jungle metrics from the match challenges and, when timeline
+// is not nil, the jungler's first clear, early ganks and objective setup.
+func AnalyzeJungle(timeline *models.Timeline, match *models.Match, puuid string) (*JungleMetrics, error) {
+	participant, _, err := findParticipant(match, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &JungleMetrics{}
+	if c := participant.Challenges; c != nil {
+		metrics.AlliedCampKills = c.AlliedJungleMonsterKills
+		metrics.EnemyCampKills = c.EnemyJungleMonsterKills
+		metrics.ScuttleCrabs = c.ScuttleCrabKills
+		if total := c.AlliedJungleMonsterKills + c.EnemyJungleMonsterKills; total > 0 {
+			metrics.CounterJungleShare = c.EnemyJungleMonsterKills / total
+		}
+	}
+
+	if timeline == nil {
+		return metrics, nil
+	}
+	participantID, err := findTimelineParticipantID(timeline, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.HasTimeline = true
+	metrics.FirstClearAt = firstClearSeconds(timeline.Info.Frames, participantID)
+	metrics.Ganks = laneVisits(timeline.Info.Frames, participantID, participant.TeamID, gankStartMs, tenMinutesMs)
+
+	for _, frame := range timeline.Info.Frames {
+		for _, event := range frame.Events {
+			if event.Type != "ELITE_MONSTER_KILL" || event.Position == nil {
+				continue
+			}
+			metrics.ObjectivesContested++
+			if nearbyBefore(timeline.Info.Frames, participantID, event) {
+				metrics.ObjectivesSetUp++
+			}
+		}
+	}
+
+	return metrics, nil
+}
+
+// firstClearSeconds interpolates when the jungler's CS first reached a full clear.
+func firstClearSeconds(frames []models.TimelineFrame, participantID int) int {
+	key := strconv.Itoa(participantID)
+	prevCS, prevMs := 0, int64(0)
+	for _, frame := range frames {
+		pf, ok := frame.ParticipantFrames[key]
+		if !ok {
+			continue
+		}
+		if pf.JungleMinionsKilled >= firstClearCS {
+			if pf.JungleMinionsKilled == prevCS {
+				return int(frame.Timestamp / 1000)
+			}
+			share := float64(firstClearCS-prevCS) / float64(pf.JungleMinionsKilled-prevCS)
+			return int((float64(prevMs) + share*float64(frame.Timestamp-prevMs)) / 1000)
+		}
+		prevCS, prevMs = pf.JungleMinionsKilled, frame.Timestamp
+	}
+	return 0
+}
+
+type gank struct {
+	lane       string
+	start, end int64
+	kills      int
+	died       bool
+}
+
+// laneVisits finds the player's visits to lanes between fromMs and untilMs from frame
+// positions, then credits kills and deaths in a lane to the visit around them.
+// Jungle ganks and support roams are both lane visits.
+func laneVisits(frames []models.TimelineFrame, participantID, teamID int, fromMs, untilMs int64) []GankStats {
+	key := strconv.Itoa(participantID)
+	var ganks []*gank
+	prevZone := ""
+
+	for _, frame := range frames {
+		if frame.Timestamp > untilMs {
+			break
+		}
+		pf, ok := frame.ParticipantFrames[key]
+		if !ok {
+			continue
+		}
+		zone := ClassifyZone(pf.Position, teamID)
+		if frame.Timestamp >= fromMs && isLaneZone(zone) {
+			if zone == prevZone && len(ganks) > 0 {
+				ganks[len(ganks)-1].end = frame.Timestamp
+			} else {
+				ganks = append(ganks, &gank{lane: zone, start: frame.Timestamp, end: frame.Timestamp})
+			}
+		}
+		prevZone = zone
+	}
+
+	for _, frame := range frames {
+		for _, event := range frame.Events {
+			if event.Type != "CHAMPION_KILL" || event.Timestamp < fromMs-gankWindowMs || event.Timestamp > untilMs || event.Position == nil {
+				continue
+			}
+			onKillingSide := event.KillerID == participantID || containsInt(event.AssistingParticipantIDs, participantID)
+			died := event.VictimID == participantID
+			if !onKillingSide && !died {
+				continue
+			}
+			lane := ClassifyZone(*event.Position, teamID)
+			if !isLaneZone(lane) {
+				continue
+			}
+
+			g := findGank(ganks, lane, event.Timestamp)
+			if g == nil {
+				g = &gank{lane: lane, start: event.Timestamp, end: event.Timestamp}
+				ganks = append(ganks, g)
+			}
+			if onKillingSide {
+				g.kills++
+			}
+			if died {
+				g.died = true
+			}
+		}
+	}
+
+	byLane := make(map[string]*GankStats)
+	for _, g := range ganks {
+		stats, ok := byLane[g.lane]
+		if !ok {
+			stats = &GankStats{Lane: g.lane}
+			byLane[g.lane] = stats
+		}
+		stats.Ganks++
+		if g.kills > 0 {
+			stats.Successful++
+		}
+		if g.died {
+			stats.Deaths++
+		}
+	}
+
+	var result []GankStats
+	for _, lane := range laneZones {
+		if stats, ok := byLane[lane]; ok {
+			result = append(result, *stats)
+		}
+	}
+	return result
+}
+
+func findGank(ganks []*gank, lane string, timestamp int64) *gank {
+	for _, g := range ganks {
+		if g.lane == lane && timestamp >= g.start-gankWindowMs && timestamp <= g.end+gankWindowMs {
+			return g
+		}
+	}
+	return nil
+}
+
+func isLaneZone(zone string) bool {
+	return zone == ZoneTopLane || zone == ZoneMidLane || zone == ZoneBotLane
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// nearbyBefore reports whether the player was near the event on the last frame before it.
+func nearbyBefore(frames []models.TimelineFrame, participantID int, event models.TimelineEvent) bool {
+	key := strconv.Itoa(participantID)
+	var last *models.ParticipantFrame
+	for i := range frames {
+		if frames[i].Timestamp >= event.Timestamp {
+			break
+		}
+		if pf, ok := frames[i].ParticipantFrames[key]; ok {
+			last = &pf
+		}
+	}
+	if last == nil {
+		return false
+	}
+	dx := float64(last.Position.X - event.Position.X)
+	dy := float64(last.Position.Y - event.Position.Y)
+	return math.Hypot(dx, dy) <= objectiveNearby
+}
+
+func computeJungleSummary(analyses []MatchAnalysis) *JungleSummary {
+	summary := &JungleSummary{}
+	byLane := make(map[string]*GankStats)
+	var clearSum float64
+	var clearGames, ganks, successful, contested, setUp int
+
+	for _, a := range analyses {
+		j := a.Jungle
+		if j == nil {
+			continue
+		}
+		summary.Games++
+		summary.AvgCounterJungleShare += j.CounterJungleShare
+		summary.AvgScuttleCrabs += float64(j.ScuttleCrabs)
+
+		if !j.HasTimeline {
+			continue
+		}
+		summary.GamesWithTimeline++
+		if j.FirstClearAt > 0 {
+			clearSum += float64(j.FirstClearAt)
+			clearGames++
+		}
+		for _, g := range j.Ganks {
+			stats, ok := byLane[g.Lane]
+			if !ok {
+				stats = &GankStats{Lane: g.Lane}
+				byLane[g.Lane] = stats
+			}
+			stats.Ganks += g.Ganks
+			stats.Successful += g.Successful
+			stats.Deaths += g.Deaths
+			ganks += g.Ganks
+			successful += g.Successful
+		}
+		contested += j.ObjectivesContested
+		setUp += j.ObjectivesSetUp
+	}
+
+	if summary.Games == 0 {
+		return nil
+	}
+
+	summary.AvgCounterJungleShare /= float64(summary.Games)
+	summary.AvgScuttleCrabs /= float64(summary.Games)
+	if clearGames > 0 {
+		summary.AvgFirstClearAt = clearSum / float64(clearGames)
+	}
+	if summary.GamesWithTimeline > 0 {
+		summary.AvgGanksBefore10 = float64(ganks) / float64(summary.GamesWithTimeline)
+	}
+	if ganks > 0 {
+		summary.GankSuccessRate = float64(successful) / float64(ganks)
+	}
+	if contested > 0 {
+		summary.ObjectiveSetupRate = float64(setUp) / float64(contested)
+	}
+	for _, lane := range laneZones {
+		if stats, ok := byLane[lane]; ok {
+			summary.GanksByLane = append(summary.GanksByLane, *stats)
+		}
+	}
+
+	return summary
+}
+
+// jungleInsights judges clear speed, early pressure, invades and objective setup.
+func jungleInsights(j *JungleSummary) (strengths []Insight, weaknesses []Insight) {
+	if j == nil || j.Games < minJungleGames {
+		return nil, nil
+	}
+
+	if j.AvgCounterJungleShare >= 0.15 {
+		strengths = append(strengths, Insight{
+			Category:    "jungle",
+			Description: fmt.Sprintf("Aggressive counter-jungling: %.0f%% of camps taken on the enemy side", j.AvgCounterJungleShare*100),
+			Value:       j.AvgCounterJungleShare,
+			IsStrength:  true,
+		})
+	}
+
+	if j.GamesWithTimeline < minJungleGames {
+		return strengths, weaknesses
+	}
+
+	switch {
+	case j.AvgFirstClearAt >= 225:
+		weaknesses = append(weaknesses, Insight{
+			Category:    "jungle",
+			Description: fmt.Sprintf("Slow first clear finishing at %s -- practice a faster full clear route", formatSeconds(j.AvgFirstClearAt)),
+			Value:       j.AvgFirstClearAt,
+		})
+	case j.AvgFirstClearAt > 0 && j.AvgFirstClearAt <= 200:
+		strengths = append(strengths, Insight{
+			Category:    "jungle",
+			Description: fmt.Sprintf("Fast first clear finishing at %s", formatSeconds(j.AvgFirstClearAt)),
+			Value:       j.AvgFirstClearAt,
+			IsStrength:  true,
+		})
+	}
+
+	if j.AvgGanksBefore10 < 1.5 {
+		weaknesses = append(weaknesses, Insight{
+			Category:    "jungle",
+			Description: fmt.Sprintf("Only %.1f ganks before 10 min per game -- look for lanes with setup", j.AvgGanksBefore10),
+			Value:       j.AvgGanksBefore10,
+		})
+	} else if j.GankSuccessRate >= 0.5 {
+		strengths = append(strengths, Insight{
+			Category:    "jungle",
+			Description: fmt.Sprintf("Effective early ganks: %.0f%% of %.1f ganks per game before 10 min get a kill", j.GankSuccessRate*100, j.AvgGanksBefore10),
+			Value:       j.GankSuccessRate,
+			IsStrength:  true,
+		})
+	} else if j.GankSuccessRate < 0.25 {
+		weaknesses = append(weaknesses, Insight{
+			Category:    "jungle",
+			Description: fmt.Sprintf("Only %.0f%% of early ganks get a kill -- gank lanes with crowd control or a pushed enemy", j.GankSuccessRate*100),
+			Value:       j.GankSuccessRate,
+		})
+	}
+
+	if j.ObjectiveSetupRate >= 0.75 {
+		strengths = append(strengths, Insight{
+			Category:    "jungle",
+			Description: fmt.Sprintf("At the pit ahead of %.0f%% of epic monsters", j.ObjectiveSetupRate*100),
+			Value:       j.ObjectiveSetupRate,
+			IsStrength:  true,
+		})
+	} else if j.ObjectiveSetupRate < 0.5 {
+		weaknesses = append(weaknesses, Insight{
+			Category:    "jungle",
+			Description: fmt.Sprintf("At the pit ahead of only %.0f%% of epic monsters -- path toward objectives before they spawn", j.ObjectiveSetupRate*100),
+			Value:       j.ObjectiveSetupRate,
+		})
+	}
+
+	return strengths, weaknesses
+}
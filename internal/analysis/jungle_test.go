@@ -0,0 +1,155 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+func setFrame(timeline *models.Timeline, minute, participantID int, update func(*models.ParticipantFrame)) {
+	key := intToStr(participantID)
+	pf := timeline.Info.Frames[minute].ParticipantFrames[key]
+	update(&pf)
+	timeline.Info.Frames[minute].ParticipantFrames[key] = pf
+}
+
+func TestAnalyzeJungle(t *testing.T) {
+	puuids := []string{"p1", "p2", "p3", "p4"}
+	timeline := makeTimeline(puuids, 12)
+	for minute, cs := range []int{0, 8, 16, 20, 28, 32, 36, 40, 44, 48, 52, 56} {
+		setFrame(timeline, minute, 1, func(pf *models.ParticipantFrame) { pf.JungleMinionsKilled = cs })
+	}
+	top := models.Position{X: 1200, Y: 9000}
+	bot := models.Position{X: 10000, Y: 1000}
+	dragon := models.Position{X: 9866, Y: 4414}
+	baron := models.Position{X: 5007, Y: 10471}
+	setFrame(timeline, 5, 1, func(pf *models.ParticipantFrame) { pf.Position = top })
+	setFrame(timeline, 6, 1, func(pf *models.ParticipantFrame) { pf.Position = top })
+	setFrame(timeline, 8, 1, func(pf *models.ParticipantFrame) { pf.Position = bot })
+	setFrame(timeline, 10, 1, func(pf *models.ParticipantFrame) { pf.Position = models.Position{X: 9500, Y: 4500} })
+
+	timeline.Info.Frames[5].Events = []models.TimelineEvent{
+		{Type: "CHAMPION_KILL", Timestamp: 330_000, KillerID: 2, VictimID: 3, AssistingParticipantIDs: []int{1}, Position: &top},
+	}
+	timeline.Info.Frames[8].Events = []models.TimelineEvent{
+		{Type: "CHAMPION_KILL", Timestamp: 500_000, KillerID: 4, VictimID: 1, Position: &bot},
+	}
+	timeline.Info.Frames[11].Events = []models.TimelineEvent{
+		{Type: "ELITE_MONSTER_KILL", Timestamp: 660_000, KillerTeamID: 100, MonsterType: monsterDragon, Position: &dragon},
+		{Type: "ELITE_MONSTER_KILL", Timestamp: 690_000, KillerTeamID: 200, MonsterType: monsterBaron, Position: &baron},
+		{Type: "CHAMPION_KILL", Timestamp: 700_000, KillerID: 1, VictimID: 3, Position: &top},
+	}
+
+	match := makeTimelineMatch(puuids)
+	match.Info.Participants[0].TeamPosition = RoleJungle
+	match.Info.Participants[0].Challenges = &models.Challenges{
+		AlliedJungleMonsterKills: 60,
+		EnemyJungleMonsterKills:  20,
+		ScuttleCrabKills:         2,
+	}
+
+	j, err := AnalyzeJungle(timeline, match, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !approxEqual(j.CounterJungleShare, 0.25) || j.ScuttleCrabs != 2 {
+		t.Errorf("unexpected challenge metrics: %+v", j)
+	}
+	if j.FirstClearAt != 210 {
+		t.Errorf("expected first clear at 210s, got %d", j.FirstClearAt)
+	}
+	if len(j.Ganks) != 2 {
+		t.Fatalf("expected ganks in 2 lanes, got %+v", j.Ganks)
+	}
+	if g := j.Ganks[0]; g.Lane != ZoneTopLane || g.Ganks != 1 || g.Successful != 1 || g.Deaths != 0 {
+		t.Errorf("unexpected top ganks: %+v", g)
+	}
+	if g := j.Ganks[1]; g.Lane != ZoneBotLane || g.Ganks != 1 || g.Successful != 0 || g.Deaths != 1 {
+		t.Errorf("unexpected bot ganks: %+v", g)
+	}
+	if j.ObjectivesContested != 2 || j.ObjectivesSetUp != 1 {
+		t.Errorf("expected 1 of 2 objectives set up, got %d of %d", j.ObjectivesSetUp, j.ObjectivesContested)
+	}
+
+	withoutTimeline, err := AnalyzeJungle(nil, match, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if withoutTimeline.HasTimeline || withoutTimeline.ScuttleCrabs != 2 {
+		t.Errorf("expected challenge metrics only, got %+v", withoutTimeline)
+	}
+}
+
+func TestComputeJungleSummary(t *testing.T) {
+	game := &JungleMetrics{
+		CounterJungleShare:  0.2,
+		ScuttleCrabs:        2,
+		HasTimeline:         true,
+		FirstClearAt:        240,
+		Ganks:               []GankStats{{Lane: ZoneTopLane, Ganks: 1, Successful: 0}},
+		ObjectivesContested: 4,
+		ObjectivesSetUp:     1,
+	}
+	analyses := []MatchAnalysis{
+		{Jungle: game}, {Jungle: game}, {Jungle: game},
+		{Jungle: &JungleMetrics{CounterJungleShare: 0.2, ScuttleCrabs: 2}},
+		{},
+	}
+
+	j := computeJungleSummary(analyses)
+	if j == nil {
+		t.Fatal("expected a summary")
+	}
+	if j.Games != 4 || j.GamesWithTimeline != 3 {
+		t.Errorf("unexpected game counts: %+v", j)
+	}
+	if !approxEqual(j.AvgFirstClearAt, 240) || !approxEqual(j.AvgGanksBefore10, 1) || !approxEqual(j.ObjectiveSetupRate, 0.25) {
+		t.Errorf("unexpected averages: %+v", j)
+	}
+	if len(j.GanksByLane) != 1 || j.GanksByLane[0].Ganks != 3 {
+		t.Errorf("unexpected ganks by lane: %+v", j.GanksByLane)
+	}
+
+	strengths, weaknesses := jungleInsights(j)
+	if len(strengths) != 1 || !strings.Contains(strengths[0].Description, "counter-jungling: 20%") {
+		t.Errorf("unexpected jungle strengths: %+v", strengths)
+	}
+	var descriptions []string
+	for _, w := range weaknesses {
+		descriptions = append(descriptions, w.Description)
+	}
+	joined := strings.Join(descriptions, "\n")
+	for _, want := range []string{"Slow first clear finishing at 4:00", "Only 1.0 ganks before 10 min", "only 25% of epic monsters"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing jungle weakness %q in:\n%s", want, joined)
+		}
+	}
+
+	if computeJungleSummary([]MatchAnalysis{{}}) != nil {
+		t.Error("expected nil summary without jungle games")
+	}
+}
+
+func TestIdentifyInsights_JungleThresholds(t *testing.T) {
+	avg := AverageMetrics{KDA: 2.5, KillParticipation: 0.55, CSPerMinute: 5.0, VisionScorePerMinute: 0.9, DamageShare: 0.13, ObjectiveParticipation: 0.5}
+
+	_, laner := identifyInsights(&PlayerAnalysis{Averages: avg, RoleBreakdown: []RoleStats{{Role: "MIDDLE"}}}, nil)
+	_, jungler := identifyInsights(&PlayerAnalysis{Averages: avg, RoleBreakdown: []RoleStats{{Role: RoleJungle}}}, nil)
+
+	hasCategory := func(insights []Insight, cat string) bool {
+		for _, i := range insights {
+			if i.Category == cat {
+				return true
+			}
+		}
+		return false
+	}
+	if !hasCategory(laner, "farming") || !hasCategory(laner, "combat") {
+		t.Errorf("expected farming and damage weaknesses for a laner, got %+v", laner)
+	}
+	if hasCategory(jungler, "farming") || hasCategory(jungler, "combat") {
+		t.Errorf("expected no farming or damage weaknesses for a jungler, got %+v", jungler)
+	}
+}
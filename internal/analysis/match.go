@@ -10,6 +10,7 @@ import (
 var (
 	ErrParticipantNotFound = errors.New("participant not found in match")
 	ErrMatchTooShort       = errors.New("match duration too short (likely a remake)")
+	ErrMixedMaps           = errors.New("matches mix Summoner's Rift and ARAM games")
 )
 
 const minMatchDurationSeconds = 60
@@ -27,12 +28,21 @@ func AnalyzeMatch(match *models.Match, puuid string) (*MatchAnalysis, error) {
 	gameDurationMin := float64(match.Info.GameDuration) / 60.0
 
 	metrics := MatchMetrics{
-		MatchID:       match.Metadata.MatchID,
-		ChampionName:  participant.ChampionName,
-		Role:          participant.TeamPosition,
-		GameDuration:  match.Info.GameDuration,
-		Win:           participant.Win,
-		TimeSpentDead: participant.TotalTimeSpentDead,
+		MatchID:            match.Metadata.MatchID,
+		ChampionName:       participant.ChampionName,
+		Role:               participant.TeamPosition,
+		GameDuration:       match.Info.GameDuration,
+		GameStartTimestamp: match.Info.GameStartTimestamp,
+		GameEndTimestamp:   match.Info.GameEndTimestamp,
+		Win:                participant.Win,
+		TimeSpentDead:      participant.TotalTimeSpentDead,
+		Items:              finalItems(participant),
+	}
+	aram := IsARAM(match)
+	if !aram {
+		if opponentID := findLaneOpponent(match, puuid); opponentID > 0 {
+			metrics.OpponentChampionName = match.Info.Participants[opponentID-1].ChampionName
+		}
 	}
 
 	if participant.Challenges != nil {
@@ -43,7 +53,15 @@ func AnalyzeMatch(match *models.Match, puuid string) (*MatchAnalysis, error) {
 
 	fillComputedStats(&metrics, participant, match, gameDurationMin)
 
-	return &MatchAnalysis{Metrics: metrics}, nil
+	result := &MatchAnalysis{Metrics: metrics}
+	if aram {
+		clearRiftMetrics(&result.Metrics)
+		result.ARAM, err = AnalyzeARAM(match, puuid)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
 }
 
 func fillFromChallenges(metrics *MatchMetrics, challenges *models.Challenges, team *models.Team) {
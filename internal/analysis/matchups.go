@@ -0,0 +1,104 @@
+package analysis
+
+import "sort"
+
+// DefaultMinMatchupGames is the minimum number of games for a matchup to count as a worst matchup.
+const DefaultMinMatchupGames = 2
+
+type matchupKey struct {
+	champion string
+	opponent string
+}
+
+// AnalyzeMatchups aggregates performance per (champion, lane opponent champion) pair.
+// Matches without a known lane opponent are skipped. Matchups are sorted by games
+// played, then by win rate ascending so problem matchups come first.
+func AnalyzeMatchups(analyses []MatchAnalysis) []MatchupStats {
+	type accumulator struct {
+		stats                                  MatchupStats
+		gold10, gold15, cs10, cs15, xp10, xp15 int
+	}
+
+	byKey := make(map[matchupKey]*accumulator)
+	var order []matchupKey
+
+	for _, a := range analyses {
+		m := a.Metrics
+		if m.OpponentChampionName == "" {
+			continue
+		}
+
+		key := matchupKey{champion: m.ChampionName, opponent: m.OpponentChampionName}
+		acc, ok := byKey[key]
+		if !ok {
+			acc = &accumulator{stats: MatchupStats{ChampionName: m.ChampionName, OpponentChampionName: m.OpponentChampionName}}
+			byKey[key] = acc
+			order = append(order, key)
+		}
+
+		acc.stats.GamesPlayed++
+		if m.Win {
+			acc.stats.Wins++
+		}
+
+		if lp := a.LanePhase; lp != nil {
+			acc.stats.GamesWithTimeline++
+			acc.gold10 += lp.GoldDiffAt10
+			acc.gold15 += lp.GoldDiffAt15
+			acc.cs10 += lp.CSDiffAt10
+			acc.cs15 += lp.CSDiffAt15
+			acc.xp10 += lp.XPDiffAt10
+			acc.xp15 += lp.XPDiffAt15
+			acc.stats.LaneKills += lp.LaneKills
+			acc.stats.LaneDeaths += lp.LaneDeaths
+		}
+	}
+
+	matchups := make([]MatchupStats, 0, len(order))
+	for _, key := range order {
+		acc := byKey[key]
+		s := acc.stats
+		s.WinRate = float64(s.Wins) / float64(s.GamesPlayed)
+		if n := float64(s.GamesWithTimeline); n > 0 {
+			s.AvgGoldDiffAt10 = float64(acc.gold10) / n
+			s.AvgGoldDiffAt15 = float64(acc.gold15) / n
+			s.AvgCSDiffAt10 = float64(acc.cs10) / n
+			s.AvgCSDiffAt15 = float64(acc.cs15) / n
+			s.AvgXPDiffAt10 = float64(acc.xp10) / n
+			s.AvgXPDiffAt15 = float64(acc.xp15) / n
+		}
+		matchups = append(matchups, s)
+	}
+
+	sort.SliceStable(matchups, func(i, j int) bool {
+		if matchups[i].GamesPlayed != matchups[j].GamesPlayed {
+			return matchups[i].GamesPlayed > matchups[j].GamesPlayed
+		}
+		return matchups[i].WinRate < matchups[j].WinRate
+	})
+
+	return matchups
+}
+
+// WorstMatchups returns up to limit matchups with at least minGames games, ordered
+// by win rate and then by gold deficit at 15 minutes. Only losing matchups qualify.
+func WorstMatchups(matchups []MatchupStats, minGames, limit int) []MatchupStats {
+	var worst []MatchupStats
+	for _, m := range matchups {
+		if m.GamesPlayed >= minGames && m.WinRate < 0.5 {
+			worst = append(worst, m)
+		}
+	}
+
+	sort.SliceStable(worst, func(i, j int) bool {
+		if worst[i].WinRate != worst[j].WinRate {
+			return worst[i].WinRate < worst[j].WinRate
+		}
+		return worst[i].AvgGoldDiffAt15 < worst[j].AvgGoldDiffAt15
+	})
+
+	if limit > 0 && len(worst) > limit {
+		worst = worst[:limit]
+	}
+	return worst
+}
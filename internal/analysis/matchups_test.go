@@ -0,0 +1,122 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+func makeMatchupAnalysis(champion, opponent string, win bool, lane *LanePhaseMetrics) MatchAnalysis {
+	return MatchAnalysis{
+		Metrics: MatchMetrics{
+			ChampionName:         champion,
+			OpponentChampionName: opponent,
+			Win:                  win,
+		},
+		LanePhase: lane,
+	}
+}
+
+func TestAnalyzeMatchupsAggregates(t *testing.T) {
+	analyses := []MatchAnalysis{
+		makeMatchupAnalysis("Ahri", "Syndra", false, &LanePhaseMetrics{GoldDiffAt10: -200, GoldDiffAt15: -600, CSDiffAt10: -5, CSDiffAt15: -10, XPDiffAt10: -100, XPDiffAt15: -300, LaneDeaths: 1}),
+		makeMatchupAnalysis("Ahri", "Syndra", true, &LanePhaseMetrics{GoldDiffAt10: 100, GoldDiffAt15: 200, CSDiffAt10: 3, CSDiffAt15: 6, XPDiffAt10: 50, XPDiffAt15: 100, LaneKills: 2}),
+		makeMatchupAnalysis("Ahri", "Syndra", false, nil),
+		makeMatchupAnalysis("Ahri", "Zed", true, nil),
+		makeMatchupAnalysis("Ahri", "", true, nil),
+	}
+
+	matchups := AnalyzeMatchups(analyses)
+
+	if len(matchups) != 2 {
+		t.Fatalf("expected 2 matchups, got %d", len(matchups))
+	}
+
+	m := matchups[0]
+	if m.ChampionName != "Ahri" || m.OpponentChampionName != "Syndra" {
+		t.Fatalf("expected Ahri vs Syndra first, got %s vs %s", m.ChampionName, m.OpponentChampionName)
+	}
+	if m.GamesPlayed != 3 || m.Wins != 1 || !approxEqual(m.WinRate, 1.0/3.0) {
+		t.Errorf("unexpected record: %d games, %d wins, %.2f WR", m.GamesPlayed, m.Wins, m.WinRate)
+	}
+	if m.GamesWithTimeline != 2 {
+		t.Errorf("expected 2 games with timeline, got %d", m.GamesWithTimeline)
+	}
+	if !approxEqual(m.AvgGoldDiffAt10, -50) || !approxEqual(m.AvgGoldDiffAt15, -200) {
+		t.Errorf("unexpected gold diffs: %.1f @10, %.1f @15", m.AvgGoldDiffAt10, m.AvgGoldDiffAt15)
+	}
+	if !approxEqual(m.AvgCSDiffAt10, -1) || !approxEqual(m.AvgCSDiffAt15, -2) {
+		t.Errorf("unexpected CS diffs: %.1f @10, %.1f @15", m.AvgCSDiffAt10, m.AvgCSDiffAt15)
+	}
+	if !approxEqual(m.AvgXPDiffAt10, -25) || !approxEqual(m.AvgXPDiffAt15, -100) {
+		t.Errorf("unexpected XP diffs: %.1f @10, %.1f @15", m.AvgXPDiffAt10, m.AvgXPDiffAt15)
+	}
+	if m.LaneKills != 2 || m.LaneDeaths != 1 {
+		t.Errorf("expected 2 lane kills and 1 lane death, got %d/%d", m.LaneKills, m.LaneDeaths)
+	}
+}
+
+func TestWorstMatchups(t *testing.T) {
+	matchups := []MatchupStats{
+		{ChampionName: "Ahri", OpponentChampionName: "Syndra", GamesPlayed: 4, WinRate: 0.25, AvgGoldDiffAt15: -100},
+		{ChampionName: "Ahri", OpponentChampionName: "Zed", GamesPlayed: 2, WinRate: 0.75},
+		{ChampionName: "Ahri", OpponentChampionName: "Fizz", GamesPlayed: 1, WinRate: 0},
+		{ChampionName: "Ahri", OpponentChampionName: "Yasuo", GamesPlayed: 3, WinRate: 0.25, AvgGoldDiffAt15: -900},
+	}
+
+	worst := WorstMatchups(matchups, 2, 5)
+
+	if len(worst) != 2 {
+		t.Fatalf("expected 2 worst matchups, got %d", len(worst))
+	}
+	if worst[0].OpponentChampionName != "Yasuo" || worst[1].OpponentChampionName != "Syndra" {
+		t.Errorf("expected Yasuo then Syndra, got %s then %s", worst[0].OpponentChampionName, worst[1].OpponentChampionName)
+	}
+
+	if limited := WorstMatchups(matchups, 2, 1); len(limited) != 1 {
+		t.Errorf("expected limit to apply, got %d", len(limited))
+	}
+}
+
+func TestAnalyzeLanePhaseLaneKillsAndXPDiffs(t *testing.T) {
+	puuids := []string{"p1", "p2"}
+	timeline := makeTimeline(puuids, 16)
+	timeline.Info.Frames[5].Events = []models.TimelineEvent{
+		{Type: "CHAMPION_KILL", KillerID: 1, VictimID: 2, Timestamp: 300_000},
+		{Type: "CHAMPION_KILL", KillerID: 2, VictimID: 1, Timestamp: 320_000},
+	}
+	timeline.Info.Frames[15].Events = []models.TimelineEvent{
+		{Type: "CHAMPION_KILL", KillerID: 1, VictimID: 2, Timestamp: 910_000}, // after laning phase
+	}
+	match := makeTimelineMatch(puuids)
+
+	lp, err := AnalyzeLanePhase(timeline, match, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if lp.LaneKills != 1 || lp.LaneDeaths != 1 {
+		t.Errorf("expected 1 lane kill and 1 lane death, got %d/%d", lp.LaneKills, lp.LaneDeaths)
+	}
+	// Both players gain the same XP per frame in makeTimeline.
+	if lp.XPDiffAt10 != 0 || lp.XPAt15 != 300*16 {
+		t.Errorf("unexpected XP: diff@10=%d xp@15=%d", lp.XPDiffAt10, lp.XPAt15)
+	}
+	// p2 farms one more CS per minute than p1.
+	if lp.CSDiffAt15 != -15 {
+		t.Errorf("expected CS diff @15 of -15, got %d", lp.CSDiffAt15)
+	}
+}
+
+func TestAnalyzeMatchOpponentChampion(t *testing.T) {
+	match := makeMatch("EUW1_1", "player-1")
+	match.Info.Participants[2].ChampionName = "Syndra"
+
+	result, err := AnalyzeMatch(match, "player-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Metrics.OpponentChampionName != "Syndra" {
+		t.Errorf("expected lane opponent Syndra, got %q", result.Metrics.OpponentChampionName)
+	}
+}
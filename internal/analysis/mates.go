@@ -0,0 +1,217 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// DefaultMinGamesTogether is the minimum number of shared games for a teammate to count as recurring.
+const DefaultMinGamesTogether = 2
+
+// RolePairing tracks results for one (player role, mate role) combination.
+type RolePairing struct {
+	PlayerRole string  `json:"playerRole"`
+	MateRole   string  `json:"mateRole"`
+	Games      int     `json:"games"`
+	WinRate    float64 `json:"winRate"`
+}
+
+// MateStats tracks the player's results alongside a recurring teammate.
+// WinRateApart and WinRateDelta are zero when GamesApart is zero, as there is
+// nothing to compare against.
+type MateStats struct {
+	PUUID           string        `json:"puuid"`
+	GameName        string        `json:"gameName"`
+	TagLine         string        `json:"tagLine,omitempty"`
+	GamesTogether   int           `json:"gamesTogether"`
+	WinsTogether    int           `json:"winsTogether"`
+	GamesApart      int           `json:"gamesApart"`
+	WinRateTogether float64       `json:"winRateTogether"`
+	WinRateApart    float64       `json:"winRateApart"`
+	WinRateDelta    float64       `json:"winRateDelta"`
+	BestPairing     *RolePairing  `json:"bestPairing,omitempty"`
+	RolePairings    []RolePairing `json:"rolePairings"`
+}
+
+// MatesReport ranks the teammates a player climbs best with.
+type MatesReport struct {
+	PUUID          string      `json:"puuid"`
+	TotalMatches   int         `json:"totalMatches"`
+	OverallWinRate float64     `json:"overallWinRate"`
+	Mates          []MateStats `json:"mates"`
+}
+
+// pairingStats counts the player's games and wins in one role pairing.
+type pairingStats struct {
+	games int
+	wins  int
+}
+
+type mateAccumulator struct {
+	gameName string
+	tagLine  string
+	games    int
+	wins     int
+	pairings map[[2]string]*pairingStats
+}
+
+// AnalyzeMates finds recurring teammates across matches and ranks them by games together,
+// then by how much the player's win rate improves when queuing with them.
+func AnalyzeMates(matches []models.Match, puuid string, minGames int) (*MatesReport, error) {
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("at least one match is required")
+	}
+	if minGames < 1 {
+		minGames = DefaultMinGamesTogether
+	}
+
+	mates := make(map[string]*mateAccumulator)
+	totalGames, totalWins := 0, 0
+
+	for i := range matches {
+		match := &matches[i]
+		if match.Info.GameDuration < minMatchDurationSeconds {
+			continue
+		}
+
+		player, _, err := findParticipant(match, puuid)
+		if err != nil {
+			continue
+		}
+
+		totalGames++
+		if player.Win {
+			totalWins++
+		}
+
+		for _, p := range match.Info.Participants {
+			if p.PUUID == puuid || p.TeamID != player.TeamID {
+				continue
+			}
+
+			acc, ok := mates[p.PUUID]
+			if !ok {
+				acc = &mateAccumulator{pairings: make(map[[2]string]*pairingStats)}
+				mates[p.PUUID] = acc
+			}
+			if p.RiotIdGameName != "" {
+				acc.gameName = p.RiotIdGameName
+				acc.tagLine = p.RiotIdTagline
+			} else if acc.gameName == "" {
+				acc.gameName = p.SummonerName
+			}
+
+			acc.games++
+			if player.Win {
+				acc.wins++
+			}
+
+			key := [2]string{roleOrUnknown(player.TeamPosition), roleOrUnknown(p.TeamPosition)}
+			pairing, ok := acc.pairings[key]
+			if !ok {
+				pairing = &pairingStats{}
+				acc.pairings[key] = pairing
+			}
+			pairing.games++
+			if player.Win {
+				pairing.wins++
+			}
+		}
+	}
+
+	if totalGames == 0 {
+		return nil, fmt.Errorf("no valid matches to analyze (all may be remakes)")
+	}
+
+	report := &MatesReport{
+		PUUID:          puuid,
+		TotalMatches:   totalGames,
+		OverallWinRate: float64(totalWins) / float64(totalGames),
+		Mates:          make([]MateStats, 0),
+	}
+
+	for matePUUID, acc := range mates {
+		if acc.games < minGames {
+			continue
+		}
+
+		stats := MateStats{
+			PUUID:           matePUUID,
+			GameName:        acc.gameName,
+			TagLine:         acc.tagLine,
+			GamesTogether:   acc.games,
+			WinsTogether:    acc.wins,
+			WinRateTogether: float64(acc.wins) / float64(acc.games),
+		}
+
+		stats.GamesApart = totalGames - acc.games
+		if stats.GamesApart > 0 {
+			stats.WinRateApart = float64(totalWins-acc.wins) / float64(stats.GamesApart)
+			stats.WinRateDelta = stats.WinRateTogether - stats.WinRateApart
+		}
+
+		stats.RolePairings = make([]RolePairing, 0, len(acc.pairings))
+		for key, p := range acc.pairings {
+			stats.RolePairings = append(stats.RolePairings, RolePairing{
+				PlayerRole: key[0],
+				MateRole:   key[1],
+				Games:      p.games,
+				WinRate:    float64(p.wins) / float64(p.games),
+			})
+		}
+		sort.Slice(stats.RolePairings, func(i, j int) bool {
+			a, b := stats.RolePairings[i], stats.RolePairings[j]
+			if a.Games != b.Games {
+				return a.Games > b.Games
+			}
+			if a.WinRate != b.WinRate {
+				return a.WinRate > b.WinRate
+			}
+			return a.PlayerRole+a.MateRole < b.PlayerRole+b.MateRole
+		})
+		stats.BestPairing = bestPairing(stats.RolePairings, minGames)
+
+		report.Mates = append(report.Mates, stats)
+	}
+
+	sort.Slice(report.Mates, func(i, j int) bool {
+		a, b := report.Mates[i], report.Mates[j]
+		if a.GamesTogether != b.GamesTogether {
+			return a.GamesTogether > b.GamesTogether
+		}
+		if a.WinRateDelta != b.WinRateDelta {
+			return a.WinRateDelta > b.WinRateDelta
+		}
+		return a.PUUID < b.PUUID
+	})
+
+	return report, nil
+}
+
+// bestPairing returns the highest win rate pairing with enough games to be meaningful.
+func bestPairing(pairings []RolePairing, minGames int) *RolePairing {
+	var best *RolePairing
+	for i := range pairings {
+		p := &pairings[i]
+		if p.Games < minGames {
+			continue
+		}
+		if best == nil || p.WinRate > best.WinRate {
+			best = p
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	result := *best
+	return &result
+}
+
+func roleOrUnknown(role string) string {
+	if role == "" {
+		return "UNKNOWN"
+	}
+	return role
+}
@@ -0,0 +1,146 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+func makeMatesMatch(matchID, puuid, role string, win bool, allies map[string]string) models.Match {
+	participants := []models.Participant{
+		{PUUID: puuid, TeamPosition: role, TeamID: 100, Win: win},
+		{PUUID: "enemy", RiotIdGameName: "Enemy", TeamPosition: role, TeamID: 200, Win: !win},
+	}
+	for allyPUUID, allyRole := range allies {
+		participants = append(participants, models.Participant{
+			PUUID:          allyPUUID,
+			RiotIdGameName: allyPUUID + "-name",
+			RiotIdTagline:  "EUW",
+			TeamPosition:   allyRole,
+			TeamID:         100,
+			Win:            win,
+		})
+	}
+	return models.Match{
+		Metadata: models.MatchMetadata{MatchID: matchID},
+		Info: models.MatchInfo{
+			GameDuration: 1800,
+			Participants: participants,
+		},
+	}
+}
+
+func TestAnalyzeMatesRanking(t *testing.T) {
+	puuid := "test-puuid"
+	matches := []models.Match{
+		makeMatesMatch("M1", puuid, "BOTTOM", true, map[string]string{"duo": "UTILITY", "friend": "TOP"}),
+		makeMatesMatch("M2", puuid, "BOTTOM", true, map[string]string{"duo": "UTILITY", "friend": "TOP"}),
+		makeMatesMatch("M3", puuid, "BOTTOM", true, map[string]string{"duo": "UTILITY"}),
+		makeMatesMatch("M4", puuid, "BOTTOM", false, map[string]string{"friend": "JUNGLE"}),
+		makeMatesMatch("M5", puuid, "BOTTOM", false, map[string]string{"random": "MIDDLE"}),
+	}
+
+	report, err := AnalyzeMates(matches, puuid, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.TotalMatches != 5 {
+		t.Errorf("totalMatches = %d, want 5", report.TotalMatches)
+	}
+	if !approxEqual(report.OverallWinRate, 0.6) {
+		t.Errorf("overallWinRate = %f, want 0.6", report.OverallWinRate)
+	}
+
+	// "random" and the enemy player are excluded: one game only / opposite team.
+	if len(report.Mates) != 2 {
+		t.Fatalf("mates size = %d, want 2", len(report.Mates))
+	}
+
+	duo := report.Mates[0]
+	if duo.PUUID != "duo" {
+		t.Fatalf("top mate = %q, want duo", duo.PUUID)
+	}
+	if duo.GameName != "duo-name" || duo.TagLine != "EUW" {
+		t.Errorf("duo riot id = %s#%s, want duo-name#EUW", duo.GameName, duo.TagLine)
+	}
+	if duo.GamesTogether != 3 {
+		t.Errorf("duo gamesTogether = %d, want 3", duo.GamesTogether)
+	}
+	// Together 3/3, apart 0/2
+	if duo.GamesApart != 2 {
+		t.Errorf("duo gamesApart = %d, want 2", duo.GamesApart)
+	}
+	if !approxEqual(duo.WinRateTogether, 1.0) {
+		t.Errorf("duo winRateTogether = %f, want 1.0", duo.WinRateTogether)
+	}
+	if !approxEqual(duo.WinRateApart, 0.0) {
+		t.Errorf("duo winRateApart = %f, want 0.0", duo.WinRateApart)
+	}
+	if duo.BestPairing == nil || duo.BestPairing.PlayerRole != "BOTTOM" || duo.BestPairing.MateRole != "UTILITY" {
+		t.Errorf("duo bestPairing = %+v, want BOTTOM+UTILITY", duo.BestPairing)
+	}
+
+	friend := report.Mates[1]
+	if friend.GamesTogether != 3 {
+		t.Errorf("friend gamesTogether = %d, want 3", friend.GamesTogether)
+	}
+	if len(friend.RolePairings) != 2 {
+		t.Fatalf("friend rolePairings size = %d, want 2", len(friend.RolePairings))
+	}
+	if friend.RolePairings[0].MateRole != "TOP" || friend.RolePairings[0].Games != 2 {
+		t.Errorf("friend top pairing = %+v, want TOP with 2 games", friend.RolePairings[0])
+	}
+}
+
+func TestAnalyzeMatesNoGamesApart(t *testing.T) {
+	puuid := "test-puuid"
+	matches := []models.Match{
+		makeMatesMatch("M1", puuid, "MIDDLE", true, map[string]string{"duo": "JUNGLE"}),
+		makeMatesMatch("M2", puuid, "MIDDLE", false, map[string]string{"duo": "JUNGLE"}),
+	}
+
+	report, err := AnalyzeMates(matches, puuid, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Mates) != 1 {
+		t.Fatalf("mates size = %d, want 1", len(report.Mates))
+	}
+
+	// Every game was played together, so there is no win rate apart to compare.
+	duo := report.Mates[0]
+	if duo.GamesApart != 0 || duo.WinRateApart != 0 || duo.WinRateDelta != 0 {
+		t.Errorf("duo gamesApart = %d, winRateApart = %f, winRateDelta = %f, want all zero", duo.GamesApart, duo.WinRateApart, duo.WinRateDelta)
+	}
+}
+
+func TestAnalyzeMatesSkipsRemakes(t *testing.T) {
+	puuid := "test-puuid"
+	remake := makeMatesMatch("REMAKE", puuid, "MIDDLE", false, map[string]string{"duo": "JUNGLE"})
+	remake.Info.GameDuration = 30
+
+	matches := []models.Match{
+		remake,
+		makeMatesMatch("M1", puuid, "MIDDLE", true, map[string]string{"duo": "JUNGLE"}),
+	}
+
+	report, err := AnalyzeMates(matches, puuid, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.TotalMatches != 1 {
+		t.Errorf("totalMatches = %d, want 1 (remake should be skipped)", report.TotalMatches)
+	}
+	if len(report.Mates) != 0 {
+		t.Errorf("mates size = %d, want 0", len(report.Mates))
+	}
+}
+
+func TestAnalyzeMatesNoMatches(t *testing.T) {
+	_, err := AnalyzeMates(nil, "test", 2)
+	if err == nil {
+		t.Fatal("expected error for empty matches")
+	}
+}
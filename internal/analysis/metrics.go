@@ -8,6 +8,11 @@ type MatchMetrics struct {
 	ChampionName string `json:"championName"`
 	Role         string `json:"role"`
 
+	OpponentChampionName string `json:"opponentChampionName,omitempty"`
+
+	// Items held at the end of the game, trinket last. Empty slots are skipped.
+	Items []int `json:"items,omitempty"`
+
 	KDA                          float64 `json:"kda"`
 	KillParticipation            float64 `json:"killParticipation"`
 	DamagePerMinute              float64 `json:"damagePerMinute"`
@@ -24,6 +29,10 @@ type MatchMetrics struct {
 	HealShieldEffective          float64 `json:"healShieldEffective"`
 	MaxCsAdvantageOnLaneOpponent float64 `json:"maxCsAdvantageOnLaneOpponent"`
 
+	// Unix milliseconds; zero for matches fetched without timestamps.
+	GameStartTimestamp int64 `json:"gameStartTimestamp,omitempty"`
+	GameEndTimestamp   int64 `json:"gameEndTimestamp,omitempty"`
+
 	GameDuration                int64 `json:"gameDuration"`
 	SoloKills                   int   `json:"soloKills"`
 	ControlWardsPlaced          int   `json:"controlWardsPlaced"`
@@ -36,22 +45,215 @@ type MatchMetrics struct {
 }
 
 // LanePhaseMetrics holds timeline-derived early game data.
+// Diffs are against the lane opponent and stay zero when there is none.
 type LanePhaseMetrics struct {
 	GoldDiffAt10   int `json:"goldDiffAt10"`
 	GoldDiffAt15   int `json:"goldDiffAt15"`
 	CSDiffAt10     int `json:"csDiffAt10"`
+	CSDiffAt15     int `json:"csDiffAt15"`
+	XPDiffAt10     int `json:"xpDiffAt10"`
+	XPDiffAt15     int `json:"xpDiffAt15"`
 	GoldAt10       int `json:"goldAt10"`
 	GoldAt15       int `json:"goldAt15"`
 	CSAt10         int `json:"csAt10"`
 	CSAt15         int `json:"csAt15"`
 	XPAt10         int `json:"xpAt10"`
+	XPAt15         int `json:"xpAt15"`
 	DeathsBefore10 int `json:"deathsBefore10"`
+
+	// Kills on and deaths to the lane opponent before 15 minutes.
+	LaneKills  int `json:"laneKills"`
+	LaneDeaths int `json:"laneDeaths"`
+}
+
+// ObjectiveEvent is one epic monster taken by either team, seen from the player's side.
+// Time is in seconds from game start.
+type ObjectiveEvent struct {
+	MonsterType    string `json:"monsterType"`
+	MonsterSubType string `json:"monsterSubType,omitempty"`
+	Time           int64  `json:"time"`
+	Secured        bool   `json:"secured"`
+	PlayerNearby   bool   `json:"playerNearby"`
+	PlayerAlive    bool   `json:"playerAlive"`
+}
+
+// ObjectiveMetrics holds timeline-derived objective control for one match.
+// Times are in seconds from game start and zero when the objective never happened.
+type ObjectiveMetrics struct {
+	AllyDragons    int    `json:"allyDragons"`
+	EnemyDragons   int    `json:"enemyDragons"`
+	DragonSoulTeam string `json:"dragonSoulTeam,omitempty"` // "ally" or "enemy"
+	DragonSoulTime int64  `json:"dragonSoulTime,omitempty"`
+
+	FirstDragonTime int64 `json:"firstDragonTime,omitempty"`
+	FirstGrubsTime  int64 `json:"firstGrubsTime,omitempty"`
+	FirstHeraldTime int64 `json:"firstHeraldTime,omitempty"`
+	FirstBaronTime  int64 `json:"firstBaronTime,omitempty"`
+
+	FirstTowerTime  int64 `json:"firstTowerTime,omitempty"`
+	FirstTowerTaken bool  `json:"firstTowerTaken"`
+	PlatesTaken     int   `json:"platesTaken"`
+	PlatesLost      int   `json:"platesLost"`
+	PlayerPlates    int   `json:"playerPlates"`
+
+	Objectives []ObjectiveEvent `json:"objectives,omitempty"`
+}
+
+// VisionMetrics holds the player's ward activity in one match. Phases split the game
+// at 14 minutes (plates fall) and 25 minutes.
+type VisionMetrics struct {
+	WardsPlacedLaning int `json:"wardsPlacedLaning"`
+	WardsPlacedMid    int `json:"wardsPlacedMid"`
+	WardsPlacedLate   int `json:"wardsPlacedLate"`
+
+	ControlWards     int `json:"controlWards"`
+	StealthWards     int `json:"stealthWards"` // yellow trinkets and sightstone-style wards
+	BlueTrinketWards int `json:"blueTrinketWards"`
+
+	WardsKilled        int `json:"wardsKilled"`
+	EnemyWardsPlaced   int `json:"enemyWardsPlaced"`
+	FirstControlWardAt int `json:"firstControlWardAt,omitempty"` // seconds; zero when none placed
+}
+
+// ItemEvent is an item bought or sold at a point in the game.
+type ItemEvent struct {
+	ItemID int `json:"itemId"`
+	Time   int `json:"time"` // seconds from game start
+}
+
+// BuildMetrics holds the player's shopping in one match, with undone purchases and sales removed.
+type BuildMetrics struct {
+	Purchases []ItemEvent `json:"purchases"`
+	Sold      []ItemEvent `json:"sold,omitempty"`
+
+	// CompletedItems lists the first purchase of each completed item, in build order.
+	CompletedItems []ItemEvent `json:"completedItems"`
+	FirstItemAt    int         `json:"firstItemAt,omitempty"`  // seconds; zero when never completed
+	SecondItemAt   int         `json:"secondItemAt,omitempty"` // seconds; zero when never completed
+}
+
+// SkillLevelUp is one skill point spent. Level is the champion level the point belongs to.
+type SkillLevelUp struct {
+	Slot  int `json:"slot"` // 1=Q, 2=W, 3=E, 4=R
+	Level int `json:"level"`
+	Time  int `json:"time"` // seconds from game start
+}
+
+// SkillMetrics holds the player's skill order in one match.
+type SkillMetrics struct {
+	LevelUps []SkillLevelUp `json:"levelUps"`
+	MaxOrder string         `json:"maxOrder"` // basic abilities by max priority, e.g. "Q>E>W"
+
+	// LateUltLevels lists the ultimate breakpoints (6, 11, 16) the player reached
+	// without putting a point in their ultimate at that level.
+	LateUltLevels []int `json:"lateUltLevels,omitempty"`
 }
 
-// MatchAnalysis combines match metrics with optional lane phase data.
+// CurvePoint is one timeline frame of a game's gold and XP state.
+type CurvePoint struct {
+	Minute       int     `json:"minute"`
+	TeamGoldDiff int     `json:"teamGoldDiff"` // player's team minus enemy team
+	GoldShare    float64 `json:"goldShare"`    // player's share of their team's gold
+	XPDiff       int     `json:"xpDiff"`       // vs. lane opponent, zero when there is none
+}
+
+// GameCurve is a compact full-game gold and XP curve. Leads and deficits are team gold diffs.
+type GameCurve struct {
+	Points        []CurvePoint `json:"points"`
+	MaxLead       int          `json:"maxLead"`
+	MaxLeadAt     int          `json:"maxLeadAt"` // minute
+	MaxDeficit    int          `json:"maxDeficit"`
+	MaxDeficitAt  int          `json:"maxDeficitAt"` // minute
+	FinalGoldDiff int          `json:"finalGoldDiff"`
+	Throw         bool         `json:"throw,omitempty"`
+	Comeback      bool         `json:"comeback,omitempty"`
+}
+
+// GankStats counts early ganks into one lane. A gank is a visit to the lane
+// before 10 minutes, successful when the jungler's side scores a kill there.
+type GankStats struct {
+	Lane       string `json:"lane"` // top_lane, mid_lane or bot_lane
+	Ganks      int    `json:"ganks"`
+	Successful int    `json:"successful"`
+	Deaths     int    `json:"deaths"`
+}
+
+// JungleMetrics holds jungle-specific data for one match played in the jungle.
+// Timeline fields stay zero when the match has no timeline.
+type JungleMetrics struct {
+	AlliedCampKills    float64 `json:"alliedCampKills"`
+	EnemyCampKills     float64 `json:"enemyCampKills"`
+	CounterJungleShare float64 `json:"counterJungleShare"` // share of camps taken in the enemy jungle
+	ScuttleCrabs       int     `json:"scuttleCrabs"`
+
+	HasTimeline         bool        `json:"hasTimeline"`
+	FirstClearAt        int         `json:"firstClearAt,omitempty"` // seconds; zero when not detected
+	Ganks               []GankStats `json:"ganks,omitempty"`
+	ObjectivesContested int         `json:"objectivesContested"` // epic monsters taken by either team
+	ObjectivesSetUp     int         `json:"objectivesSetUp"`     // with the jungler at the pit beforehand
+}
+
+// SupportMetrics holds support-specific data for one match played as support.
+// Roams are visits to top or mid lane between 3 and 14 minutes; they stay empty without a timeline.
+type SupportMetrics struct {
+	HealsOnTeammates    int     `json:"healsOnTeammates"`
+	ShieldsOnTeammates  int     `json:"shieldsOnTeammates"`
+	HealShieldPerMinute float64 `json:"healShieldPerMinute"`
+	CCPerMinute         float64 `json:"ccPerMinute"`
+	SavesFromDeath      int     `json:"savesFromDeath"`
+	ControlWardsPlaced  int     `json:"controlWardsPlaced"`
+	StealthWardsPlaced  int     `json:"stealthWardsPlaced"`
+	WardsKilled         int     `json:"wardsKilled"`
+
+	HasTimeline bool        `json:"hasTimeline"`
+	FirstRoamAt int         `json:"firstRoamAt,omitempty"` // seconds; zero when the player never roamed
+	Roams       []GankStats `json:"roams,omitempty"`
+}
+
+// ARAMMetrics holds the metrics of one ARAM game, where roles, lanes, vision
+// and objectives don't apply.
+type ARAMMetrics struct {
+	DamageShare         float64 `json:"damageShare"`
+	DamageTakenShare    float64 `json:"damageTakenShare"`
+	HealShieldPerMinute float64 `json:"healShieldPerMinute"` // on teammates
+	DeathsPerMinute     float64 `json:"deathsPerMinute"`
+	// TimeDeadShare is the fraction of the game spent waiting to respawn.
+	TimeDeadShare float64 `json:"timeDeadShare"`
+
+	// Snowball (Mark) stats, zero when the player took another summoner spell.
+	HasSnowball   bool `json:"hasSnowball"`
+	SnowballCasts int  `json:"snowballCasts"`
+	SnowballsHit  int  `json:"snowballsHit"`
+}
+
+// DeathEvent describes one of the player's deaths. Time is in seconds from game start.
+type DeathEvent struct {
+	Time         int64           `json:"time"`
+	Position     models.Position `json:"position"`
+	Zone         string          `json:"zone"`
+	Context      string          `json:"context"`   // DeathSolo, DeathOutnumbered or DeathExecuted
+	Attackers    int             `json:"attackers"` // enemy champions credited with the kill
+	ShutdownGold int             `json:"shutdownGold,omitempty"`
+}
+
+// DeathMetrics holds the player's deaths in one match.
+type DeathMetrics struct {
+	Deaths []DeathEvent `json:"deaths"`
+}
+
+// MatchAnalysis combines match metrics with optional timeline-derived data.
 type MatchAnalysis struct {
-	Metrics   MatchMetrics      `json:"metrics"`
-	LanePhase *LanePhaseMetrics `json:"lanePhase,omitempty"`
+	Metrics    MatchMetrics      `json:"metrics"`
+	LanePhase  *LanePhaseMetrics `json:"lanePhase,omitempty"`
+	Objectives *ObjectiveMetrics `json:"objectives,omitempty"`
+	Deaths     *DeathMetrics     `json:"deaths,omitempty"`
+	Vision     *VisionMetrics    `json:"vision,omitempty"`
+	Build      *BuildMetrics     `json:"build,omitempty"`
+	Skills     *SkillMetrics     `json:"skills,omitempty"`
+	Curve      *GameCurve        `json:"curve,omitempty"`
+	Jungle     *JungleMetrics    `json:"jungle,omitempty"`
+	Support    *SupportMetrics   `json:"support,omitempty"`
+	ARAM       *ARAMMetrics      `json:"aram,omitempty"`
 }
 
 // AverageMetrics holds mean values across all analyzed matches.
@@ -74,6 +276,158 @@ type ConsistencyMetrics struct {
 	DPMStdDev      float64 `json:"dpmStdDev"`
 }
 
+// ObjectiveSummary aggregates objective control across matches with a timeline.
+type ObjectiveSummary struct {
+	GamesWithTimeline int     `json:"gamesWithTimeline"`
+	AvgAllyDragons    float64 `json:"avgAllyDragons"`
+	AvgEnemyDragons   float64 `json:"avgEnemyDragons"`
+	SoulRate          float64 `json:"soulRate"`
+	EnemySoulRate     float64 `json:"enemySoulRate"`
+	FirstTowerRate    float64 `json:"firstTowerRate"`
+	AvgFirstTowerTime float64 `json:"avgFirstTowerTime"`
+	AvgPlatesTaken    float64 `json:"avgPlatesTaken"`
+	AvgPlatesLost     float64 `json:"avgPlatesLost"`
+
+	// Epic monsters (dragons, grubs, herald, baron) and where the player was.
+	ObjectivesSecured  int     `json:"objectivesSecured"`
+	ObjectivesLost     int     `json:"objectivesLost"`
+	SecuredPresentRate float64 `json:"securedPresentRate"` // secured with the player nearby and alive
+	LostWhileDeadRate  float64 `json:"lostWhileDeadRate"`
+	LostWhileAwayRate  float64 `json:"lostWhileAwayRate"` // alive but not nearby
+}
+
+// DeathZoneStats counts deaths in one map zone.
+type DeathZoneStats struct {
+	Zone   string  `json:"zone"`
+	Deaths int     `json:"deaths"`
+	Share  float64 `json:"share"`
+}
+
+// DeathSummary aggregates where and how the player dies across matches with a timeline.
+type DeathSummary struct {
+	GamesWithTimeline int              `json:"gamesWithTimeline"`
+	TotalDeaths       int              `json:"totalDeaths"`
+	ByZone            []DeathZoneStats `json:"byZone"`
+	SoloDeaths        int              `json:"soloDeaths"`
+	OutnumberedDeaths int              `json:"outnumberedDeaths"`
+	ExecutedDeaths    int              `json:"executedDeaths"`
+	ShutdownsGiven    int              `json:"shutdownsGiven"`
+	ShutdownGold      int              `json:"shutdownGold"`
+}
+
+// VisionSummary aggregates ward activity across matches with a timeline.
+type VisionSummary struct {
+	GamesWithTimeline int `json:"gamesWithTimeline"`
+
+	AvgWardsLaning float64 `json:"avgWardsLaning"`
+	AvgWardsMid    float64 `json:"avgWardsMid"`
+	AvgWardsLate   float64 `json:"avgWardsLate"`
+
+	// Per-minute placement rates before and after 14 minutes, so phases of
+	// different lengths compare fairly. Only games past 14 minutes count.
+	AvgWardsPerMinLaning      float64 `json:"avgWardsPerMinLaning"`
+	AvgWardsPerMinAfterLaning float64 `json:"avgWardsPerMinAfterLaning"`
+
+	AvgControlWards     float64 `json:"avgControlWards"`
+	AvgStealthWards     float64 `json:"avgStealthWards"`
+	AvgBlueTrinketWards float64 `json:"avgBlueTrinketWards"`
+
+	AvgWardsKilled float64 `json:"avgWardsKilled"`
+	WardClearRate  float64 `json:"wardClearRate"` // share of enemy wards the player cleared
+
+	GamesWithoutEarlyControlWard int `json:"gamesWithoutEarlyControlWard"`
+}
+
+// CurveSummary aggregates how games with a timeline were won and lost.
+type CurveSummary struct {
+	GamesWithTimeline int     `json:"gamesWithTimeline"`
+	GamesAhead        int     `json:"gamesAhead"`  // reached a big gold lead
+	GamesBehind       int     `json:"gamesBehind"` // fell to a big gold deficit
+	Throws            int     `json:"throws"`
+	Comebacks         int     `json:"comebacks"`
+	AvgGoldShare      float64 `json:"avgGoldShare"` // at the last frame
+	AvgMaxLead        float64 `json:"avgMaxLead"`
+	AvgMaxDeficit     float64 `json:"avgMaxDeficit"`
+}
+
+// JungleSummary aggregates jungle games. Timeline averages only cover games with a timeline.
+type JungleSummary struct {
+	Games             int `json:"games"`
+	GamesWithTimeline int `json:"gamesWithTimeline"`
+
+	AvgCounterJungleShare float64 `json:"avgCounterJungleShare"`
+	AvgScuttleCrabs       float64 `json:"avgScuttleCrabs"`
+
+	AvgFirstClearAt    float64     `json:"avgFirstClearAt"`
+	AvgGanksBefore10   float64     `json:"avgGanksBefore10"`
+	GankSuccessRate    float64     `json:"gankSuccessRate"`
+	GanksByLane        []GankStats `json:"ganksByLane"`
+	ObjectiveSetupRate float64     `json:"objectiveSetupRate"`
+}
+
+// ARAMSummary aggregates ARAM games. Snowball stats only cover games with Mark.
+type ARAMSummary struct {
+	Games int `json:"games"`
+
+	AvgDamageShare         float64 `json:"avgDamageShare"`
+	AvgDamageTakenShare    float64 `json:"avgDamageTakenShare"`
+	AvgHealShieldPerMinute float64 `json:"avgHealShieldPerMinute"`
+	AvgDeathsPerMinute     float64 `json:"avgDeathsPerMinute"`
+	AvgTimeDeadShare       float64 `json:"avgTimeDeadShare"`
+
+	SnowballGames    int     `json:"snowballGames"`
+	AvgSnowballCasts float64 `json:"avgSnowballCasts"`
+	SnowballHitRate  float64 `json:"snowballHitRate"` // hits per cast
+}
+
+// SupportSummary aggregates support games. Roam averages only cover games with a timeline.
+type SupportSummary struct {
+	Games             int `json:"games"`
+	GamesWithTimeline int `json:"gamesWithTimeline"`
+
+	AvgHealShieldPerMinute float64 `json:"avgHealShieldPerMinute"`
+	AvgCCPerMinute         float64 `json:"avgCcPerMinute"`
+	AvgSavesFromDeath      float64 `json:"avgSavesFromDeath"`
+	AvgControlWards        float64 `json:"avgControlWards"`
+	AvgStealthWards        float64 `json:"avgStealthWards"`
+	AvgWardsKilled         float64 `json:"avgWardsKilled"`
+
+	AvgFirstRoamAt   float64 `json:"avgFirstRoamAt"`
+	AvgRoamsBefore14 float64 `json:"avgRoamsBefore14"`
+	RoamSuccessRate  float64 `json:"roamSuccessRate"`
+}
+
+// SessionStats summarizes a slice of games, e.g. every second game of a session.
+type SessionStats struct {
+	Label          string  `json:"label"`
+	Games          int     `json:"games"`
+	WinRate        float64 `json:"winRate"`
+	AvgKDA         float64 `json:"avgKda"`
+	AvgCSPerMinute float64 `json:"avgCsPerMinute"`
+}
+
+// SessionSummary groups games into play sessions separated by long breaks.
+type SessionSummary struct {
+	Sessions           int     `json:"sessions"`
+	AvgGamesPerSession float64 `json:"avgGamesPerSession"`
+	LongestSession     int     `json:"longestSession"`
+	WinRate            float64 `json:"winRate"`
+
+	// ByGameIndex holds the 1st, 2nd, ... game of each session; the last entry
+	// groups every game from maxSessionIndex on.
+	ByGameIndex []SessionStats `json:"byGameIndex"`
+	// AfterLossStreak covers games started right after lossStreakLength or more
+	// losses in a row within the same session.
+	AfterLossStreak *SessionStats  `json:"afterLossStreak,omitempty"`
+	ByTimeOfDay     []SessionStats `json:"byTimeOfDay"`
+	ByDayOfWeek     []SessionStats `json:"byDayOfWeek"`
+
+	// Early and Late split long sessions into their first games and the rest.
+	LongSessions int           `json:"longSessions"`
+	Early        *SessionStats `json:"early,omitempty"`
+	Late         *SessionStats `json:"late,omitempty"`
+}
+
 // ChampionStats tracks per-champion aggregated performance.
 type ChampionStats struct {
 	ChampionName string  `json:"championName"`
@@ -82,6 +436,42 @@ type ChampionStats struct {
 	GamesPlayed  int     `json:"gamesPlayed"`
 }
 
+// ChampionBuildStats compares completed item timings across games on one champion.
+// Timing averages only cover games where the item was completed.
+type ChampionBuildStats struct {
+	ChampionName      string `json:"championName"`
+	GamesWithTimeline int    `json:"gamesWithTimeline"`
+	Wins              int    `json:"wins"`
+
+	AvgFirstItemAt       float64 `json:"avgFirstItemAt"`
+	AvgSecondItemAt      float64 `json:"avgSecondItemAt"`
+	AvgFirstItemAtWins   float64 `json:"avgFirstItemAtWins,omitempty"`
+	AvgFirstItemAtLosses float64 `json:"avgFirstItemAtLosses,omitempty"`
+	// FirstItemWins and FirstItemLosses count the games behind the two averages above.
+	FirstItemWins   int `json:"firstItemWins"`
+	FirstItemLosses int `json:"firstItemLosses"`
+
+	CommonFirstItem  int `json:"commonFirstItem,omitempty"`
+	CommonSecondItem int `json:"commonSecondItem,omitempty"`
+}
+
+// SkillOrderStats tracks results for one skill max order on one champion.
+type SkillOrderStats struct {
+	ChampionName string  `json:"championName"`
+	MaxOrder     string  `json:"maxOrder"`
+	GamesPlayed  int     `json:"gamesPlayed"`
+	Wins         int     `json:"wins"`
+	WinRate      float64 `json:"winRate"`
+}
+
+// SkillSummary aggregates skill orders across matches with a timeline.
+type SkillSummary struct {
+	GamesWithTimeline int               `json:"gamesWithTimeline"`
+	GamesWithLateUlt  int               `json:"gamesWithLateUlt"`
+	LateUltPoints     int               `json:"lateUltPoints"`
+	Orders            []SkillOrderStats `json:"orders"`
+}
+
 // RoleStats tracks per-role aggregated performance.
 type RoleStats struct {
 	Role        string  `json:"role"`
@@ -89,6 +479,26 @@ type RoleStats struct {
 	GamesPlayed int     `json:"gamesPlayed"`
 }
 
+// MatchupStats tracks aggregated performance against one lane opponent champion.
+// Lane averages only cover games with a timeline.
+type MatchupStats struct {
+	ChampionName         string  `json:"championName"`
+	OpponentChampionName string  `json:"opponentChampionName"`
+	GamesPlayed          int     `json:"gamesPlayed"`
+	Wins                 int     `json:"wins"`
+	WinRate              float64 `json:"winRate"`
+
+	GamesWithTimeline int     `json:"gamesWithTimeline"`
+	AvgGoldDiffAt10   float64 `json:"avgGoldDiffAt10"`
+	AvgGoldDiffAt15   float64 `json:"avgGoldDiffAt15"`
+	AvgCSDiffAt10     float64 `json:"avgCsDiffAt10"`
+	AvgCSDiffAt15     float64 `json:"avgCsDiffAt15"`
+	AvgXPDiffAt10     float64 `json:"avgXpDiffAt10"`
+	AvgXPDiffAt15     float64 `json:"avgXpDiffAt15"`
+	LaneKills         int     `json:"laneKills"`
+	LaneDeaths        int     `json:"laneDeaths"`
+}
+
 // Insight represents a single identified strength or weakness.
 type Insight struct {
 	Category    string  `json:"category"`
@@ -97,6 +507,19 @@ type Insight struct {
 	IsStrength  bool    `json:"isStrength"`
 }
 
+// MetricPercentile places a player's average for one metric, over their main
+// role, within the distribution for their tier. Tier is empty when the
+// benchmark fell back to all tiers.
+type MetricPercentile struct {
+	Metric     string  `json:"metric"`
+	Label      string  `json:"label"`
+	Value      float64 `json:"value"`
+	Percentile float64 `json:"percentile"`
+	Tier       string  `json:"tier,omitempty"`
+	Role       string  `json:"role"`
+	Samples    int     `json:"samples"`
+}
+
 // PlayerAnalysis is the final output combining all analysis for the coaching LLM.
 type PlayerAnalysis struct {
 	PUUID    string `json:"puuid"`
@@ -105,17 +528,34 @@ type PlayerAnalysis struct {
 	Tier     string `json:"tier,omitempty"`
 	Rank     string `json:"rank,omitempty"`
 
+	// Queue is the queue group the matches were selected from, e.g. "solo".
+	Queue string `json:"queue,omitempty"`
+	// Map is the map every analyzed match was played on, when known.
+	Map string `json:"map,omitempty"`
+
 	WinRate      float64 `json:"winRate"`
 	LeaguePoints int     `json:"leaguePoints,omitempty"`
 	TotalMatches int     `json:"totalMatches"`
 
-	Averages      AverageMetrics     `json:"averages"`
-	Consistency   ConsistencyMetrics `json:"consistency"`
-	RoleBreakdown []RoleStats        `json:"roleBreakdown"`
-	ChampionPool  []ChampionStats    `json:"championPool"`
-	Strengths     []Insight          `json:"strengths"`
-	Weaknesses    []Insight          `json:"weaknesses"`
-	Matches       []MatchAnalysis    `json:"matches"`
+	Averages      AverageMetrics       `json:"averages"`
+	Consistency   ConsistencyMetrics   `json:"consistency"`
+	RoleBreakdown []RoleStats          `json:"roleBreakdown"`
+	ChampionPool  []ChampionStats      `json:"championPool"`
+	Matchups      []MatchupStats       `json:"matchups,omitempty"`
+	Objectives    *ObjectiveSummary    `json:"objectives,omitempty"`
+	Deaths        *DeathSummary        `json:"deaths,omitempty"`
+	Vision        *VisionSummary       `json:"vision,omitempty"`
+	Builds        []ChampionBuildStats `json:"builds,omitempty"`
+	Skills        *SkillSummary        `json:"skills,omitempty"`
+	Curves        *CurveSummary        `json:"curves,omitempty"`
+	Jungle        *JungleSummary       `json:"jungle,omitempty"`
+	Support       *SupportSummary      `json:"support,omitempty"`
+	ARAM          *ARAMSummary         `json:"aram,omitempty"`
+	Sessions      *SessionSummary      `json:"sessions,omitempty"`
+	Percentiles   []MetricPercentile   `json:"percentiles,omitempty"`
+	Strengths     []Insight            `json:"strengths"`
+	Weaknesses    []Insight            `json:"weaknesses"`
+	Matches       []MatchAnalysis      `json:"matches"`
 }
 
 // PlayerAnalysisParams bundles all inputs for player analysis.
@@ -126,4 +566,12 @@ type PlayerAnalysisParams struct {
 	Matches   []models.Match
 	Timelines map[string]*models.Timeline
 	League    *models.LeagueEntry
+	Queue     string
+
+	// Benchmarks, when set, replace fixed insight thresholds with percentiles
+	// against players of the same tier and role.
+	Benchmarks *Benchmarks
+
+	// Rules drive the metric insights; nil uses DefaultRules.
+	Rules *RuleSet
 }
@@ -0,0 +1,284 @@
+package analysis
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// Epic monster and building types reported by timeline events.
+const (
+	monsterDragon = "DRAGON"
+	monsterGrubs  = "HORDE"
+	monsterHerald = "RIFTHERALD"
+	monsterBaron  = "BARON_NASHOR"
+	monsterElder  = "ELDER_DRAGON"
+	buildingTower = "TOWER_BUILDING"
+)
+
+const (
+	dragonsForSoul   = 4
+	objectiveNearby  = 3000 // map units; roughly the objective pit plus its entrances
+	respawnScaleFrom = 15.0 // minutes before respawn timers start scaling with game time
+)
+
+// baseRespawnSeconds is the death timer by champion level (index 0 = level 1).
+var baseRespawnSeconds = []float64{10, 10, 12, 12, 14, 16, 20, 25, 28, 32.5, 35, 37.5, 40, 42.5, 45, 47.5, 50, 52.5}
+
+// AnalyzeObjectives extracts dragon, grub, herald, baron and tower control from a timeline.
+// Player presence is estimated from the per-minute positions on the frames around each
+// objective, and aliveness from death events and level-based respawn timers.
+func AnalyzeObjectives(timeline *models.Timeline, match *models.Match, puuid string) (*ObjectiveMetrics, error) {
+	participantID, err := findTimelineParticipantID(timeline, puuid)
+	if err != nil {
+		return nil, err
+	}
+	participant, _, err := findParticipant(match, puuid)
+	if err != nil {
+		return nil, err
+	}
+	allyTeam := participant.TeamID
+
+	metrics := &ObjectiveMetrics{}
+	deaths := playerDeaths(timeline.Info.Frames, participantID)
+
+	for _, frame := range timeline.Info.Frames {
+		for _, event := range frame.Events {
+			switch event.Type {
+			case "ELITE_MONSTER_KILL":
+				recordMonster(metrics, timeline.Info.Frames, event, allyTeam, participantID, deaths)
+			case "BUILDING_KILL":
+				if event.BuildingType == buildingTower && metrics.FirstTowerTime == 0 {
+					metrics.FirstTowerTime = event.Timestamp / 1000
+					metrics.FirstTowerTaken = event.TeamID != allyTeam
+				}
+			case "TURRET_PLATE_DESTROYED":
+				if event.TeamID == allyTeam {
+					metrics.PlatesLost++
+				} else {
+					metrics.PlatesTaken++
+					if event.KillerID == participantID {
+						metrics.PlayerPlates++
+					}
+				}
+			}
+		}
+	}
+
+	return metrics, nil
+}
+
+func recordMonster(metrics *ObjectiveMetrics, frames []models.TimelineFrame, event models.TimelineEvent, allyTeam, participantID int, deaths []playerDeath) {
+	secured := event.KillerTeamID == allyTeam
+	seconds := event.Timestamp / 1000
+
+	switch event.MonsterType {
+	case monsterDragon:
+		if metrics.FirstDragonTime == 0 {
+			metrics.FirstDragonTime = seconds
+		}
+		if event.MonsterSubType != monsterElder {
+			if secured {
+				metrics.AllyDragons++
+			} else {
+				metrics.EnemyDragons++
+			}
+			if metrics.DragonSoulTeam == "" && (metrics.AllyDragons == dragonsForSoul || metrics.EnemyDragons == dragonsForSoul) {
+				metrics.DragonSoulTeam = "enemy"
+				if secured {
+					metrics.DragonSoulTeam = "ally"
+				}
+				metrics.DragonSoulTime = seconds
+			}
+		}
+	case monsterGrubs:
+		if metrics.FirstGrubsTime == 0 {
+			metrics.FirstGrubsTime = seconds
+		}
+	case monsterHerald:
+		if metrics.FirstHeraldTime == 0 {
+			metrics.FirstHeraldTime = seconds
+		}
+	case monsterBaron:
+		if metrics.FirstBaronTime == 0 {
+			metrics.FirstBaronTime = seconds
+		}
+	default:
+		return
+	}
+
+	involved := event.KillerID == participantID
+	for _, id := range event.AssistingParticipantIDs {
+		if id == participantID {
+			involved = true
+		}
+	}
+
+	alive := involved || !isDeadAt(deaths, event.Timestamp)
+	metrics.Objectives = append(metrics.Objectives, ObjectiveEvent{
+		MonsterType:    event.MonsterType,
+		MonsterSubType: event.MonsterSubType,
+		Time:           seconds,
+		Secured:        secured,
+		PlayerNearby:   involved || (alive && wasNearby(frames, participantID, event)),
+		PlayerAlive:    alive,
+	})
+}
+
+type playerDeath struct {
+	timestamp int64
+	respawnMs int64
+}
+
+func playerDeaths(frames []models.TimelineFrame, participantID int) []playerDeath {
+	var deaths []playerDeath
+	key := strconv.Itoa(participantID)
+	level := 1
+	for _, frame := range frames {
+		if pf, ok := frame.ParticipantFrames[key]; ok && pf.Level > 0 {
+			level = pf.Level
+		}
+		for _, event := range frame.Events {
+			if event.Type == "CHAMPION_KILL" && event.VictimID == participantID {
+				deaths = append(deaths, playerDeath{
+					timestamp: event.Timestamp,
+					respawnMs: int64(respawnSeconds(level, event.Timestamp) * 1000),
+				})
+			}
+		}
+	}
+	return deaths
+}
+
+// respawnSeconds approximates the death timer: a level-based base time that grows
+// with game time after 15 minutes, capped at +50%.
+func respawnSeconds(level int, timestampMs int64) float64 {
+	if level < 1 {
+		level = 1
+	}
+	if level > len(baseRespawnSeconds) {
+		level = len(baseRespawnSeconds)
+	}
+	base := baseRespawnSeconds[level-1]
+
+	minutes := float64(timestampMs) / 60_000
+	var scale float64
+	switch {
+	case minutes <= respawnScaleFrom:
+	case minutes <= 30:
+		scale = math.Ceil(2*(minutes-15)) * 0.00425
+	case minutes <= 45:
+		scale = 0.1275 + math.Ceil(2*(minutes-30))*0.003
+	default:
+		scale = 0.2175 + math.Ceil(2*(minutes-45))*0.0145
+	}
+	return base * (1 + math.Min(scale, 0.5))
+}
+
+func isDeadAt(deaths []playerDeath, timestamp int64) bool {
+	for _, d := range deaths {
+		if timestamp >= d.timestamp && timestamp < d.timestamp+d.respawnMs {
+			return true
+		}
+	}
+	return false
+}
+
+// wasNearby reports whether the player was within range of the event on either
+// frame around it. Frames are a minute apart, so this errs towards "nearby".
+func wasNearby(frames []models.TimelineFrame, participantID int, event models.TimelineEvent) bool {
+	if event.Position == nil {
+		return false
+	}
+	key := strconv.Itoa(participantID)
+	for i := range frames {
+		if frames[i].Timestamp < event.Timestamp-60_000 || frames[i].Timestamp > event.Timestamp+60_000 {
+			continue
+		}
+		pf, ok := frames[i].ParticipantFrames[key]
+		if !ok {
+			continue
+		}
+		dx := float64(pf.Position.X - event.Position.X)
+		dy := float64(pf.Position.Y - event.Position.Y)
+		if math.Hypot(dx, dy) <= objectiveNearby {
+			return true
+		}
+	}
+	return false
+}
+
+func computeObjectiveSummary(analyses []MatchAnalysis) *ObjectiveSummary {
+	summary := &ObjectiveSummary{}
+	var towerTimeSum float64
+	var towerGames, soulGames, enemySoulGames, firstTowers int
+	var securedPresent, lostDead, lostAway int
+
+	for _, a := range analyses {
+		o := a.Objectives
+		if o == nil {
+			continue
+		}
+		summary.GamesWithTimeline++
+		summary.AvgAllyDragons += float64(o.AllyDragons)
+		summary.AvgEnemyDragons += float64(o.EnemyDragons)
+		summary.AvgPlatesTaken += float64(o.PlatesTaken)
+		summary.AvgPlatesLost += float64(o.PlatesLost)
+
+		switch o.DragonSoulTeam {
+		case "ally":
+			soulGames++
+		case "enemy":
+			enemySoulGames++
+		}
+		if o.FirstTowerTime > 0 {
+			towerGames++
+			towerTimeSum += float64(o.FirstTowerTime)
+			if o.FirstTowerTaken {
+				firstTowers++
+			}
+		}
+
+		for _, e := range o.Objectives {
+			if e.Secured {
+				summary.ObjectivesSecured++
+				if e.PlayerNearby && e.PlayerAlive {
+					securedPresent++
+				}
+				continue
+			}
+			summary.ObjectivesLost++
+			if !e.PlayerAlive {
+				lostDead++
+			} else if !e.PlayerNearby {
+				lostAway++
+			}
+		}
+	}
+
+	if summary.GamesWithTimeline == 0 {
+		return nil
+	}
+
+	n := float64(summary.GamesWithTimeline)
+	summary.AvgAllyDragons /= n
+	summary.AvgEnemyDragons /= n
+	summary.AvgPlatesTaken /= n
+	summary.AvgPlatesLost /= n
+	summary.SoulRate = float64(soulGames) / n
+	summary.EnemySoulRate = float64(enemySoulGames) / n
+	if towerGames > 0 {
+		summary.FirstTowerRate = float64(firstTowers) / float64(towerGames)
+		summary.AvgFirstTowerTime = towerTimeSum / float64(towerGames)
+	}
+	if summary.ObjectivesSecured > 0 {
+		summary.SecuredPresentRate = float64(securedPresent) / float64(summary.ObjectivesSecured)
+	}
+	if summary.ObjectivesLost > 0 {
+		summary.LostWhileDeadRate = float64(lostDead) / float64(summary.ObjectivesLost)
+		summary.LostWhileAwayRate = float64(lostAway) / float64(summary.ObjectivesLost)
+	}
+
+	return summary
+}
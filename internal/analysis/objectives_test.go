@@ -0,0 +1,182 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// makeObjectiveTimeline builds a 30-minute timeline for two players where p1
+// (team 100) stands at dragon pit and p2 (team 200) stands at baron pit.
+func makeObjectiveTimeline(events map[int][]models.TimelineEvent) (*models.Timeline, *models.Match) {
+	puuids := []string{"p1", "p2"}
+	timeline := makeTimeline(puuids, 31)
+	for i := range timeline.Info.Frames {
+		frame := &timeline.Info.Frames[i]
+		p1 := frame.ParticipantFrames["1"]
+		p1.Position = dragonPit
+		p1.Level = 10
+		frame.ParticipantFrames["1"] = p1
+		p2 := frame.ParticipantFrames["2"]
+		p2.Position = baronPit
+		frame.ParticipantFrames["2"] = p2
+		frame.Events = events[i]
+	}
+	return timeline, makeTimelineMatch(puuids)
+}
+
+var (
+	dragonPit = models.Position{X: 9866, Y: 4414}
+	baronPit  = models.Position{X: 5007, Y: 10471}
+)
+
+func dragonKill(ms int64, teamID int, subType string) models.TimelineEvent {
+	pos := dragonPit
+	return models.TimelineEvent{Type: "ELITE_MONSTER_KILL", Timestamp: ms, KillerTeamID: teamID, MonsterType: "DRAGON", MonsterSubType: subType, Position: &pos}
+}
+
+func TestAnalyzeObjectivesDragonSoulRace(t *testing.T) {
+	timeline, match := makeObjectiveTimeline(map[int][]models.TimelineEvent{
+		6:  {dragonKill(360_000, 100, "FIRE_DRAGON")},
+		11: {dragonKill(660_000, 200, "WATER_DRAGON")},
+		16: {dragonKill(960_000, 100, "EARTH_DRAGON")},
+		21: {dragonKill(1_260_000, 100, "EARTH_DRAGON")},
+		26: {dragonKill(1_560_000, 100, "EARTH_DRAGON")},
+		29: {dragonKill(1_740_000, 100, "ELDER_DRAGON")},
+	})
+
+	o, err := AnalyzeObjectives(timeline, match, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if o.AllyDragons != 4 || o.EnemyDragons != 1 {
+		t.Errorf("expected 4-1 dragons (elder excluded), got %d-%d", o.AllyDragons, o.EnemyDragons)
+	}
+	if o.DragonSoulTeam != "ally" || o.DragonSoulTime != 1560 {
+		t.Errorf("expected ally soul at 1560s, got %q at %d", o.DragonSoulTeam, o.DragonSoulTime)
+	}
+	if o.FirstDragonTime != 360 {
+		t.Errorf("expected first dragon at 360s, got %d", o.FirstDragonTime)
+	}
+	if len(o.Objectives) != 6 {
+		t.Errorf("expected 6 objective events, got %d", len(o.Objectives))
+	}
+}
+
+func TestAnalyzeObjectivesPresenceAndDeaths(t *testing.T) {
+	baron := baronPit
+	timeline, match := makeObjectiveTimeline(map[int][]models.TimelineEvent{
+		// Secured at dragon pit where p1 is standing.
+		6: {dragonKill(360_000, 100, "FIRE_DRAGON")},
+		// Lost shortly after p1 dies.
+		10: {
+			{Type: "CHAMPION_KILL", Timestamp: 600_000, KillerID: 2, VictimID: 1},
+			dragonKill(605_000, 200, "WATER_DRAGON"),
+		},
+		// Lost on the other side of the map while p1 is alive.
+		25: {{Type: "ELITE_MONSTER_KILL", Timestamp: 1_500_000, KillerTeamID: 200, MonsterType: "BARON_NASHOR", Position: &baron}},
+	})
+
+	o, err := AnalyzeObjectives(timeline, match, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(o.Objectives) != 3 {
+		t.Fatalf("expected 3 objective events, got %d", len(o.Objectives))
+	}
+
+	if e := o.Objectives[0]; !e.Secured || !e.PlayerNearby || !e.PlayerAlive {
+		t.Errorf("first dragon: expected secured with player present, got %+v", e)
+	}
+	if e := o.Objectives[1]; e.Secured || e.PlayerAlive {
+		t.Errorf("second dragon: expected lost while player dead, got %+v", e)
+	}
+	if e := o.Objectives[2]; e.Secured || !e.PlayerAlive || e.PlayerNearby {
+		t.Errorf("baron: expected lost while player away, got %+v", e)
+	}
+	if o.FirstBaronTime != 1500 {
+		t.Errorf("expected first baron at 1500s, got %d", o.FirstBaronTime)
+	}
+}
+
+func TestAnalyzeObjectivesTowersAndPlates(t *testing.T) {
+	timeline, match := makeObjectiveTimeline(map[int][]models.TimelineEvent{
+		5: {
+			{Type: "TURRET_PLATE_DESTROYED", Timestamp: 300_000, KillerID: 1, TeamID: 200},
+			{Type: "TURRET_PLATE_DESTROYED", Timestamp: 310_000, KillerID: 0, TeamID: 200},
+			{Type: "TURRET_PLATE_DESTROYED", Timestamp: 320_000, KillerID: 2, TeamID: 100},
+		},
+		12: {{Type: "BUILDING_KILL", Timestamp: 720_000, BuildingType: "TOWER_BUILDING", TeamID: 100}},
+		14: {{Type: "BUILDING_KILL", Timestamp: 840_000, BuildingType: "TOWER_BUILDING", TeamID: 200}},
+	})
+
+	o, err := AnalyzeObjectives(timeline, match, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if o.PlatesTaken != 2 || o.PlatesLost != 1 || o.PlayerPlates != 1 {
+		t.Errorf("expected plates 2 taken / 1 lost / 1 by player, got %d/%d/%d", o.PlatesTaken, o.PlatesLost, o.PlayerPlates)
+	}
+	if o.FirstTowerTime != 720 || o.FirstTowerTaken {
+		t.Errorf("expected first tower lost at 720s, got taken=%v at %d", o.FirstTowerTaken, o.FirstTowerTime)
+	}
+}
+
+func TestRespawnSecondsScalesWithGameTime(t *testing.T) {
+	if got := respawnSeconds(1, 60_000); got != 10 {
+		t.Errorf("level 1 early: expected 10s, got %.2f", got)
+	}
+	early := respawnSeconds(18, 10*60_000)
+	late := respawnSeconds(18, 60*60_000)
+	if !approxEqual(early, 52.5) || !approxEqual(late, 52.5*1.5) {
+		t.Errorf("level 18: expected 52.5s early and capped +50%% late, got %.2f and %.2f", early, late)
+	}
+}
+
+func TestComputeObjectiveSummary(t *testing.T) {
+	analyses := []MatchAnalysis{
+		{Objectives: &ObjectiveMetrics{
+			AllyDragons: 4, EnemyDragons: 1, DragonSoulTeam: "ally",
+			FirstTowerTime: 600, FirstTowerTaken: true, PlatesTaken: 3, PlatesLost: 1,
+			Objectives: []ObjectiveEvent{
+				{Secured: true, PlayerNearby: true, PlayerAlive: true},
+				{Secured: true, PlayerNearby: false, PlayerAlive: true},
+				{Secured: false, PlayerAlive: false},
+			},
+		}},
+		{Objectives: &ObjectiveMetrics{
+			AllyDragons: 0, EnemyDragons: 4, DragonSoulTeam: "enemy",
+			FirstTowerTime: 800, PlatesTaken: 1, PlatesLost: 3,
+			Objectives: []ObjectiveEvent{
+				{Secured: false, PlayerNearby: false, PlayerAlive: true},
+			},
+		}},
+		{}, // no timeline
+	}
+
+	s := computeObjectiveSummary(analyses)
+	if s == nil {
+		t.Fatal("expected summary")
+	}
+	if s.GamesWithTimeline != 2 {
+		t.Errorf("expected 2 games with timeline, got %d", s.GamesWithTimeline)
+	}
+	if !approxEqual(s.AvgAllyDragons, 2) || !approxEqual(s.AvgEnemyDragons, 2.5) {
+		t.Errorf("unexpected dragon averages %.2f/%.2f", s.AvgAllyDragons, s.AvgEnemyDragons)
+	}
+	if !approxEqual(s.SoulRate, 0.5) || !approxEqual(s.EnemySoulRate, 0.5) {
+		t.Errorf("unexpected soul rates %.2f/%.2f", s.SoulRate, s.EnemySoulRate)
+	}
+	if !approxEqual(s.FirstTowerRate, 0.5) || !approxEqual(s.AvgFirstTowerTime, 700) {
+		t.Errorf("unexpected first tower %.2f at %.0f", s.FirstTowerRate, s.AvgFirstTowerTime)
+	}
+	if !approxEqual(s.SecuredPresentRate, 0.5) || !approxEqual(s.LostWhileDeadRate, 0.5) || !approxEqual(s.LostWhileAwayRate, 0.5) {
+		t.Errorf("unexpected presence rates %.2f/%.2f/%.2f", s.SecuredPresentRate, s.LostWhileDeadRate, s.LostWhileAwayRate)
+	}
+
+	if computeObjectiveSummary([]MatchAnalysis{{}}) != nil {
+		t.Error("expected nil summary without timelines")
+	}
+}
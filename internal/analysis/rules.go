@@ -0,0 +1,189 @@
+package analysis
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+//go:embed default_rules.json
+var defaultRulesJSON []byte
+
+// Rule comparators.
+const (
+	// CompareHigher marks metrics where a high value is good (KDA, CS/min).
+	CompareHigher = "higher"
+	// CompareLower marks metrics where a high value is bad (deaths/min).
+	CompareLower = "lower"
+)
+
+// InsightRule turns one player metric into a strength or weakness.
+// Strength and Weakness are optional; a rule without one only reports the other.
+type InsightRule struct {
+	Metric     string   `json:"metric"`
+	Category   string   `json:"category"`
+	Comparator string   `json:"comparator"`
+	Strength   *float64 `json:"strength,omitempty"`
+	Weakness   *float64 `json:"weakness,omitempty"`
+
+	// Roles and Tiers restrict the rule to players whose main role or tier is
+	// listed; empty means any. ExcludeRoles skips the listed roles.
+	Roles        []string `json:"roles,omitempty"`
+	ExcludeRoles []string `json:"excludeRoles,omitempty"`
+	Tiers        []string `json:"tiers,omitempty"`
+
+	// Formats are fmt templates taking the value, e.g. "Strong KDA averaging %.1f".
+	StrengthFormat string `json:"strengthFormat,omitempty"`
+	WeaknessFormat string `json:"weaknessFormat,omitempty"`
+
+	// Percent displays a ratio as a percentage in the formats.
+	Percent bool `json:"percent,omitempty"`
+}
+
+// RuleSet is an ordered list of insight rules. For each metric, the first rule
+// matching the player's role and tier applies, so specific rules go first.
+type RuleSet struct {
+	Rules []InsightRule `json:"rules"`
+}
+
+// ruleMetrics are the player values rules can refer to.
+var ruleMetrics = map[string]func(*PlayerAnalysis) float64{
+	"kda":                     func(a *PlayerAnalysis) float64 { return a.Averages.KDA },
+	"kill_participation":      func(a *PlayerAnalysis) float64 { return a.Averages.KillParticipation },
+	"damage_per_min":          func(a *PlayerAnalysis) float64 { return a.Averages.DamagePerMinute },
+	"damage_share":            func(a *PlayerAnalysis) float64 { return a.Averages.DamageShare },
+	"cs_per_min":              func(a *PlayerAnalysis) float64 { return a.Averages.CSPerMinute },
+	"vision_score_per_min":    func(a *PlayerAnalysis) float64 { return a.Averages.VisionScorePerMinute },
+	"deaths_per_min":          func(a *PlayerAnalysis) float64 { return a.Averages.DeathsPerMinute },
+	"gold_per_min":            func(a *PlayerAnalysis) float64 { return a.Averages.GoldPerMinute },
+	"objective_participation": func(a *PlayerAnalysis) float64 { return a.Averages.ObjectiveParticipation },
+	"unique_champions":        func(a *PlayerAnalysis) float64 { return float64(len(a.ChampionPool)) },
+	"kda_stddev":              func(a *PlayerAnalysis) float64 { return a.Consistency.KDAStdDev },
+}
+
+var defaultRules = mustParseRules(defaultRulesJSON)
+
+// DefaultRules returns the rules embedded in the binary.
+func DefaultRules() *RuleSet {
+	return defaultRules
+}
+
+// LoadRules reads and validates a JSON rule file.
+func LoadRules(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read rules: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// ParseRules decodes and validates JSON rules.
+func ParseRules(data []byte) (*RuleSet, error) {
+	var rs RuleSet
+	if err := json.Unmarshal(data, &rs); err != nil {
+		return nil, fmt.Errorf("decode rules: %w", err)
+	}
+	for i, r := range rs.Rules {
+		if err := r.validate(); err != nil {
+			return nil, fmt.Errorf("rule %d (%s): %w", i+1, r.Metric, err)
+		}
+	}
+	return &rs, nil
+}
+
+func mustParseRules(data []byte) *RuleSet {
+	rs, err := ParseRules(data)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded insight rules: %v", err))
+	}
+	return rs
+}
+
+func (r InsightRule) validate() error {
+	if _, ok := ruleMetrics[r.Metric]; !ok {
+		return fmt.Errorf("unknown metric %q", r.Metric)
+	}
+	if r.Comparator != CompareHigher && r.Comparator != CompareLower {
+		return fmt.Errorf("comparator must be %q or %q, got %q", CompareHigher, CompareLower, r.Comparator)
+	}
+	if r.Category == "" {
+		return fmt.Errorf("category is required")
+	}
+	if r.Strength == nil && r.Weakness == nil {
+		return fmt.Errorf("at least one of strength or weakness is required")
+	}
+	if r.Strength != nil && r.StrengthFormat == "" {
+		return fmt.Errorf("strengthFormat is required with a strength threshold")
+	}
+	if r.Weakness != nil && r.WeaknessFormat == "" {
+		return fmt.Errorf("weaknessFormat is required with a weakness threshold")
+	}
+	return nil
+}
+
+func (r InsightRule) matches(role, tier string) bool {
+	if len(r.Roles) > 0 && !slices.Contains(r.Roles, role) {
+		return false
+	}
+	if slices.Contains(r.ExcludeRoles, role) {
+		return false
+	}
+	if len(r.Tiers) > 0 && !slices.ContainsFunc(r.Tiers, func(t string) bool { return strings.EqualFold(t, tier) }) {
+		return false
+	}
+	return true
+}
+
+// forPlayer returns the rule that applies to each metric for the role and tier.
+func (rs *RuleSet) forPlayer(role, tier string) []InsightRule {
+	seen := make(map[string]bool)
+	var out []InsightRule
+	for _, r := range rs.Rules {
+		if seen[r.Metric] || !r.matches(role, tier) {
+			continue
+		}
+		seen[r.Metric] = true
+		out = append(out, r)
+	}
+	return out
+}
+
+// evaluate checks value against the rule's thresholds. A value must pass a
+// threshold to count; landing exactly on it reports nothing.
+func (r InsightRule) evaluate(value float64) (Insight, bool) {
+	display := value
+	if r.Percent {
+		display = value * 100
+	}
+
+	higher := r.Comparator == CompareHigher
+	switch {
+	case r.Strength != nil && ((higher && value > *r.Strength) || (!higher && value < *r.Strength)):
+		return Insight{Category: r.Category, Description: fmt.Sprintf(r.StrengthFormat, display), Value: value, IsStrength: true}, true
+	case r.Weakness != nil && ((higher && value < *r.Weakness) || (!higher && value > *r.Weakness)):
+		return Insight{Category: r.Category, Description: fmt.Sprintf(r.WeaknessFormat, display), Value: value}, true
+	}
+	return Insight{}, false
+}
+
+// evaluatePercentile judges the metric by its rank among players of the same
+// tier and role instead of the rule's fixed thresholds.
+func (r InsightRule) evaluatePercentile(p MetricPercentile) (Insight, bool) {
+	insight := Insight{Category: r.Category, Description: percentileInsight(p), Value: p.Value}
+
+	good, bad := p.Percentile >= strengthPercentile, p.Percentile <= weaknessPercentile
+	if r.Comparator == CompareLower {
+		good, bad = bad, good
+	}
+	switch {
+	case good && r.Strength != nil:
+		insight.IsStrength = true
+		return insight, true
+	case bad && r.Weakness != nil:
+		return insight, true
+	}
+	return Insight{}, false
+}
@@ -0,0 +1,147 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultRules_Valid(t *testing.T) {
+	rs := DefaultRules()
+	if len(rs.Rules) == 0 {
+		t.Fatal("expected embedded default rules")
+	}
+
+	// Every role gets a rule for deaths, the generic rules' last metric.
+	for _, role := range []string{"", RoleTop, RoleJungle, RoleMiddle, RoleBottom, RoleSupport} {
+		found := false
+		for _, r := range rs.forPlayer(role, "") {
+			if r.Metric == "deaths_per_min" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("role %q: expected a deaths_per_min rule", role)
+		}
+	}
+}
+
+func TestParseRules_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown metric":     `{"rules":[{"metric":"pentakills","category":"combat","comparator":"higher","strength":1,"strengthFormat":"%.0f"}]}`,
+		"bad comparator":     `{"rules":[{"metric":"kda","category":"combat","comparator":"above","strength":1,"strengthFormat":"%.0f"}]}`,
+		"no thresholds":      `{"rules":[{"metric":"kda","category":"combat","comparator":"higher"}]}`,
+		"missing format":     `{"rules":[{"metric":"kda","category":"combat","comparator":"higher","weakness":1}]}`,
+		"missing category":   `{"rules":[{"metric":"kda","comparator":"higher","weakness":1,"weaknessFormat":"%.1f"}]}`,
+		"malformed document": `{"rules":[`,
+	}
+	for name, data := range tests {
+		if _, err := ParseRules([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestRuleSet_FirstMatchingRuleWins(t *testing.T) {
+	rs, err := ParseRules([]byte(`{"rules":[
+		{"metric":"cs_per_min","category":"farming","comparator":"higher","strength":9,"strengthFormat":"gold jungle %.1f","roles":["JUNGLE"],"tiers":["GOLD"]},
+		{"metric":"cs_per_min","category":"farming","comparator":"higher","strength":6,"strengthFormat":"jungle %.1f","roles":["JUNGLE"]},
+		{"metric":"cs_per_min","category":"farming","comparator":"higher","strength":7,"strengthFormat":"any %.1f","excludeRoles":["UTILITY"]}
+	]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		role, tier string
+		want       string
+	}{
+		{RoleJungle, "gold", "gold jungle %.1f"},
+		{RoleJungle, "SILVER", "jungle %.1f"},
+		{RoleMiddle, "GOLD", "any %.1f"},
+		{RoleSupport, "GOLD", ""},
+	}
+	for _, tt := range tests {
+		got := rs.forPlayer(tt.role, tt.tier)
+		if tt.want == "" {
+			if len(got) != 0 {
+				t.Errorf("%s/%s: expected no rules, got %d", tt.role, tt.tier, len(got))
+			}
+			continue
+		}
+		if len(got) != 1 || got[0].StrengthFormat != tt.want {
+			t.Errorf("%s/%s: expected only %q, got %+v", tt.role, tt.tier, tt.want, got)
+		}
+	}
+}
+
+func TestIdentifyInsights_CustomRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	data := `{"rules":[
+		{"metric":"deaths_per_min","category":"deaths","comparator":"lower","strength":0.1,"weakness":0.3,
+		 "strengthFormat":"Rarely dies (%.2f/min)","weaknessFormat":"Dies a lot (%.2f/min)"},
+		{"metric":"kill_participation","category":"combat","comparator":"higher","weakness":0.5,
+		 "weaknessFormat":"KP only %.0f%%","percent":true}
+	]}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rs, err := LoadRules(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	strengths, weaknesses := identifyInsights(&PlayerAnalysis{
+		Averages: AverageMetrics{DeathsPerMinute: 0.05, KillParticipation: 0.42, KDA: 0.5},
+	}, rs)
+
+	if len(strengths) != 1 || strengths[0].Description != "Rarely dies (0.05/min)" {
+		t.Errorf("unexpected strengths: %+v", strengths)
+	}
+	// The low KDA has no rule in this set, so only kill participation is flagged.
+	if len(weaknesses) != 1 || weaknesses[0].Description != "KP only 42%" {
+		t.Errorf("unexpected weaknesses: %+v", weaknesses)
+	}
+}
+
+func TestInsightRule_ThresholdsAreStrict(t *testing.T) {
+	var stddev, pool InsightRule
+	for _, r := range DefaultRules().forPlayer("", "") {
+		switch r.Metric {
+		case "kda_stddev":
+			stddev = r
+		case "unique_champions":
+			pool = r
+		}
+	}
+
+	tests := []struct {
+		rule         InsightRule
+		value        float64
+		wantInsight  bool
+		wantStrength bool
+	}{
+		{stddev, 0.99, true, true},
+		{stddev, 1.0, false, false},
+		{stddev, 3.0, false, false},
+		{stddev, 3.01, true, false},
+		{pool, 8, true, true},
+		{pool, 7, false, false},
+		{pool, 3, false, false},
+		{pool, 2, true, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.rule.evaluate(tt.value)
+		if ok != tt.wantInsight || got.IsStrength != tt.wantStrength {
+			t.Errorf("%s at %v: expected insight=%v strength=%v, got %v %+v", tt.rule.Metric, tt.value, tt.wantInsight, tt.wantStrength, ok, got)
+		}
+	}
+}
+
+func TestLoadRules_MissingFile(t *testing.T) {
+	_, err := LoadRules(filepath.Join(t.TempDir(), "missing.json"))
+	if err == nil || !strings.Contains(err.Error(), "read rules") {
+		t.Errorf("expected a read error, got %v", err)
+	}
+}
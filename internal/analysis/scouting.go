@@ -0,0 +1,265 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+const (
+	// minScoutGames is the minimum number of games on a champion before tendencies are read from them.
+	minScoutGames = 2
+
+	// scoutStreakGames is the minimum win or loss streak worth calling out.
+	scoutStreakGames = 3
+)
+
+// ScoutInput is what is known about one player of a live game.
+type ScoutInput struct {
+	Participant models.CurrentGameParticipant
+	League      *models.LeagueEntry
+	// Matches are the player's stored matches, in any order.
+	Matches []models.Match
+}
+
+// PlayerScout summarizes one player of a live game for a scouting report.
+type PlayerScout struct {
+	PUUID        string `json:"puuid"`
+	RiotID       string `json:"riotId,omitempty"`
+	TeamID       int    `json:"teamId"`
+	ChampionID   int    `json:"championId"`
+	ChampionName string `json:"championName"`
+
+	Tier         string `json:"tier,omitempty"`
+	Rank         string `json:"rank,omitempty"`
+	LeaguePoints int    `json:"leaguePoints,omitempty"`
+	Wins         int    `json:"wins,omitempty"`
+	Losses       int    `json:"losses,omitempty"`
+	HotStreak    bool   `json:"hotStreak,omitempty"`
+
+	// MainRole is the most played position in stored matches.
+	MainRole    string `json:"mainRole,omitempty"`
+	StoredGames int    `json:"storedGames"`
+	// Streak counts the latest consecutive wins (positive) or losses (negative).
+	Streak int `json:"streak"`
+
+	// Champion stats only cover stored games on the champion picked in this game.
+	ChampionGames      int      `json:"championGames"`
+	ChampionWinRate    float64  `json:"championWinRate"`
+	AvgKDA             float64  `json:"avgKda"`
+	AvgCSPerMinute     float64  `json:"avgCsPerMinute"`
+	AvgDeathsPerMinute float64  `json:"avgDeathsPerMinute"`
+	AvgSoloKills       float64  `json:"avgSoloKills"`
+	AvgKP              float64  `json:"avgKillParticipation"`
+	Tendencies         []string `json:"tendencies,omitempty"`
+}
+
+// ScoutingReport describes both teams of a live game from the player's side.
+type ScoutingReport struct {
+	GameID       int64         `json:"gameId"`
+	QueueID      int           `json:"queueId"`
+	GameMode     string        `json:"gameMode"`
+	GameLength   int64         `json:"gameLength"`
+	Allies       []PlayerScout `json:"allies"`
+	Enemies      []PlayerScout `json:"enemies"`
+	LaneOpponent *PlayerScout  `json:"laneOpponent,omitempty"`
+}
+
+// BuildScoutingReport scouts every participant of game. The player identified
+// by puuid comes first among the allies, and their lane opponent is the enemy
+// whose main role matches theirs.
+func BuildScoutingReport(game *models.CurrentGameInfo, puuid string, inputs []ScoutInput) *ScoutingReport {
+	report := &ScoutingReport{
+		GameID:     game.GameID,
+		QueueID:    game.GameQueueConfigID,
+		GameMode:   game.GameMode,
+		GameLength: game.GameLength,
+	}
+
+	names := championNames(inputs)
+	teamID := 0
+	for _, in := range inputs {
+		if in.Participant.PUUID == puuid {
+			teamID = in.Participant.TeamID
+		}
+	}
+
+	for _, in := range inputs {
+		scout := ScoutPlayer(in, names)
+		if scout.TeamID == teamID {
+			if scout.PUUID == puuid {
+				report.Allies = append([]PlayerScout{scout}, report.Allies...)
+				continue
+			}
+			report.Allies = append(report.Allies, scout)
+		} else {
+			report.Enemies = append(report.Enemies, scout)
+		}
+	}
+
+	if len(report.Allies) > 0 && report.Allies[0].PUUID == puuid && report.Allies[0].MainRole != "" {
+		role := report.Allies[0].MainRole
+		for i := range report.Enemies {
+			if report.Enemies[i].MainRole == role {
+				report.LaneOpponent = &report.Enemies[i]
+				break
+			}
+		}
+	}
+
+	return report
+}
+
+// championNames maps champion IDs to names from every stored match available,
+// since the spectator API only reports IDs.
+func championNames(inputs []ScoutInput) map[int]string {
+	names := make(map[int]string)
+	for _, in := range inputs {
+		for _, m := range in.Matches {
+			for _, p := range m.Info.Participants {
+				if p.ChampionName != "" {
+					names[p.ChampionID] = p.ChampionName
+				}
+			}
+		}
+	}
+	return names
+}
+
+// ScoutPlayer summarizes a player's rank, form and history on the champion they picked.
+func ScoutPlayer(in ScoutInput, championNames map[int]string) PlayerScout {
+	p := in.Participant
+	scout := PlayerScout{
+		PUUID:        p.PUUID,
+		RiotID:       p.RiotID,
+		TeamID:       p.TeamID,
+		ChampionID:   p.ChampionID,
+		ChampionName: championNames[p.ChampionID],
+		StoredGames:  len(in.Matches),
+	}
+	if scout.ChampionName == "" {
+		scout.ChampionName = fmt.Sprintf("Champion %d", p.ChampionID)
+	}
+	if l := in.League; l != nil {
+		scout.Tier, scout.Rank, scout.LeaguePoints = l.Tier, l.Rank, l.LeaguePoints
+		scout.Wins, scout.Losses, scout.HotStreak = l.Wins, l.Losses, l.HotStreak
+	}
+
+	matches := make([]models.Match, len(in.Matches))
+	copy(matches, in.Matches)
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Info.GameEndTimestamp > matches[j].Info.GameEndTimestamp
+	})
+
+	roles := make(map[string]int)
+	var results []bool
+	var onChampion []MatchMetrics
+	for i := range matches {
+		result, err := AnalyzeMatch(&matches[i], p.PUUID)
+		if err != nil {
+			continue
+		}
+		m := result.Metrics
+		if m.Role != "" {
+			roles[m.Role]++
+		}
+		results = append(results, m.Win)
+		if participant, _, _ := findParticipant(&matches[i], p.PUUID); participant.ChampionID == p.ChampionID {
+			onChampion = append(onChampion, m)
+		}
+	}
+	scout.Streak = currentStreak(results)
+	scout.MainRole = mostPlayed(roles)
+
+	scout.ChampionGames = len(onChampion)
+	if len(onChampion) > 0 {
+		wins := 0
+		for _, m := range onChampion {
+			if m.Win {
+				wins++
+			}
+			scout.AvgKDA += m.KDA
+			scout.AvgCSPerMinute += m.CSPerMinute
+			scout.AvgDeathsPerMinute += m.DeathsPerMinute
+			scout.AvgSoloKills += float64(m.SoloKills)
+			scout.AvgKP += m.KillParticipation
+		}
+		n := float64(len(onChampion))
+		scout.ChampionWinRate = float64(wins) / n
+		scout.AvgKDA /= n
+		scout.AvgCSPerMinute /= n
+		scout.AvgDeathsPerMinute /= n
+		scout.AvgSoloKills /= n
+		scout.AvgKP /= n
+	}
+
+	scout.Tendencies = tendencies(scout)
+	return scout
+}
+
+// currentStreak counts the consecutive results matching the most recent one,
+// positive for wins and negative for losses.
+func currentStreak(results []bool) int {
+	streak := 0
+	for _, win := range results {
+		if win != results[0] {
+			break
+		}
+		streak++
+	}
+	if len(results) > 0 && !results[0] {
+		return -streak
+	}
+	return streak
+}
+
+// mostPlayed returns the role with the most games, breaking ties alphabetically.
+func mostPlayed(roles map[string]int) string {
+	best := ""
+	for role, games := range roles {
+		if games > roles[best] || (games == roles[best] && role < best) {
+			best = role
+		}
+	}
+	return best
+}
+
+// tendencies describes habits worth knowing before the game starts.
+func tendencies(s PlayerScout) []string {
+	var out []string
+	if s.Streak >= scoutStreakGames {
+		out = append(out, fmt.Sprintf("On a %d game win streak", s.Streak))
+	} else if s.Streak <= -scoutStreakGames {
+		out = append(out, fmt.Sprintf("Lost the last %d games -- may be tilted", -s.Streak))
+	} else if s.HotStreak {
+		out = append(out, "On a ranked hot streak")
+	}
+	if s.ChampionGames < minScoutGames {
+		if s.StoredGames > 0 {
+			out = append(out, fmt.Sprintf("Few or no recorded games on %s", s.ChampionName))
+		}
+		return out
+	}
+
+	if s.ChampionWinRate >= 0.60 {
+		out = append(out, fmt.Sprintf("Comfort pick: %.0f%% win rate over %d games", s.ChampionWinRate*100, s.ChampionGames))
+	} else if s.ChampionWinRate <= 0.40 {
+		out = append(out, fmt.Sprintf("Struggles on this pick: %.0f%% win rate over %d games", s.ChampionWinRate*100, s.ChampionGames))
+	}
+	if s.AvgSoloKills >= 1.0 {
+		out = append(out, fmt.Sprintf("Looks for solo kills (%.1f per game) -- respect their all-in", s.AvgSoloKills))
+	}
+	if s.AvgDeathsPerMinute >= 0.25 {
+		out = append(out, fmt.Sprintf("Dies often (%.2f per minute) -- punish overextensions", s.AvgDeathsPerMinute))
+	}
+	if s.AvgKP >= 0.65 {
+		out = append(out, fmt.Sprintf("Joins most fights (%.0f%% kill participation) -- expect roams", s.AvgKP*100))
+	} else if s.AvgKP > 0 && s.AvgKP <= 0.40 {
+		out = append(out, fmt.Sprintf("Plays on their own (%.0f%% kill participation) -- likely to split push", s.AvgKP*100))
+	}
+	if s.MainRole != RoleJungle && s.MainRole != RoleSupport && s.AvgCSPerMinute > 0 && s.AvgCSPerMinute < 5.5 {
+		out = append(out, fmt.Sprintf("Weak farmer (%.1f CS/min) -- trade CS leads into pressure", s.AvgCSPerMinute))
+	}
+	return out
+}
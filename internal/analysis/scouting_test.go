@@ -0,0 +1,102 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// makeScoutMatch returns a stored match where puuid played champion in role.
+func makeScoutMatch(id, puuid string, championID int, champion, role string, win bool, endedAt int64) models.Match {
+	m := makeMatch(id, puuid, func(p *models.Participant) {
+		p.ChampionID = championID
+		p.ChampionName = champion
+		p.TeamPosition = role
+		p.Win = win
+	})
+	m.Info.GameEndTimestamp = endedAt
+	return *m
+}
+
+func TestScoutPlayer(t *testing.T) {
+	in := ScoutInput{
+		Participant: models.CurrentGameParticipant{PUUID: "enemy", RiotID: "Enemy#EUW", ChampionID: 157, TeamID: 200},
+		League:      &models.LeagueEntry{Tier: "GOLD", Rank: "II", LeaguePoints: 40, Wins: 30, Losses: 25, HotStreak: true},
+		Matches: []models.Match{
+			makeScoutMatch("M1", "enemy", 157, "Yasuo", RoleMiddle, false, 1000),
+			makeScoutMatch("M4", "enemy", 238, "Zed", RoleMiddle, false, 4000),
+			makeScoutMatch("M3", "enemy", 157, "Yasuo", RoleMiddle, false, 3000),
+			makeScoutMatch("M2", "enemy", 157, "Yasuo", RoleTop, true, 2000),
+		},
+	}
+
+	s := ScoutPlayer(in, map[int]string{157: "Yasuo"})
+
+	if s.ChampionName != "Yasuo" || s.MainRole != RoleMiddle || s.Tier != "GOLD" || !s.HotStreak {
+		t.Errorf("unexpected scout: %+v", s)
+	}
+	if s.StoredGames != 4 || s.ChampionGames != 3 || !approxEqual(s.ChampionWinRate, 1.0/3) {
+		t.Errorf("expected 3 of 4 games on Yasuo at 33%%, got %d of %d at %.2f", s.ChampionGames, s.StoredGames, s.ChampionWinRate)
+	}
+	// Most recent first: M4 loss, M3 loss, M2 win.
+	if s.Streak != -2 {
+		t.Errorf("expected a 2 game losing streak, got %d", s.Streak)
+	}
+	if !containsString(s.Tendencies, "Struggles on this pick: 33% win rate over 3 games") {
+		t.Errorf("expected a struggling tendency, got %q", s.Tendencies)
+	}
+}
+
+func TestScoutPlayer_NoHistory(t *testing.T) {
+	s := ScoutPlayer(ScoutInput{Participant: models.CurrentGameParticipant{PUUID: "p", ChampionID: 99}}, nil)
+
+	if s.ChampionName != "Champion 99" {
+		t.Errorf("expected a placeholder champion name, got %q", s.ChampionName)
+	}
+	if s.MainRole != "" || s.Streak != 0 || len(s.Tendencies) != 0 {
+		t.Errorf("expected an empty scout, got %+v", s)
+	}
+}
+
+func TestBuildScoutingReport(t *testing.T) {
+	game := &models.CurrentGameInfo{
+		GameID:            7001,
+		GameQueueConfigID: 420,
+		Participants: []models.CurrentGameParticipant{
+			{PUUID: "ally", ChampionID: 1, TeamID: 100},
+			{PUUID: "enemy-top", ChampionID: 2, TeamID: 200},
+			{PUUID: "player", ChampionID: 3, TeamID: 100},
+			{PUUID: "enemy-mid", ChampionID: 4, TeamID: 200},
+		},
+	}
+	inputs := make([]ScoutInput, len(game.Participants))
+	for i, p := range game.Participants {
+		inputs[i] = ScoutInput{Participant: p}
+	}
+	inputs[1].Matches = []models.Match{makeScoutMatch("M1", "enemy-top", 2, "Garen", RoleTop, true, 1)}
+	inputs[2].Matches = []models.Match{makeScoutMatch("M2", "player", 3, "Ahri", RoleMiddle, true, 1)}
+	inputs[3].Matches = []models.Match{makeScoutMatch("M3", "enemy-mid", 4, "Annie", RoleMiddle, true, 1)}
+
+	r := BuildScoutingReport(game, "player", inputs)
+
+	if len(r.Allies) != 2 || r.Allies[0].PUUID != "player" || len(r.Enemies) != 2 {
+		t.Fatalf("expected the player first of 2 allies and 2 enemies, got %+v / %+v", r.Allies, r.Enemies)
+	}
+	if r.LaneOpponent == nil || r.LaneOpponent.PUUID != "enemy-mid" {
+		t.Fatalf("expected enemy-mid as lane opponent, got %+v", r.LaneOpponent)
+	}
+	// Champion names come from any stored match, including other players'.
+	if r.Enemies[0].ChampionName != "Garen" || r.LaneOpponent.ChampionName != "Annie" {
+		t.Errorf("unexpected champion names: %q, %q", r.Enemies[0].ChampionName, r.LaneOpponent.ChampionName)
+	}
+}
+
+func containsString(values []string, want string) bool {
+	for _, v := range values {
+		if strings.Contains(v, want) {
+			return true
+		}
+	}
+	return false
+}
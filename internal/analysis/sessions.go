@@ -0,0 +1,233 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// sessionGapMs is the break between two games that starts a new session.
+	sessionGapMs = 60 * 60 * 1000
+
+	// maxSessionIndex groups the 5th and later games of a session together.
+	maxSessionIndex = 5
+
+	// lossStreakLength is how many losses in a row count as a streak worth stopping at.
+	lossStreakLength = 2
+
+	// longSessionGames is the session length from which games are split into
+	// the first longSessionGames-1 and the rest.
+	longSessionGames = 4
+
+	// minSessionGames is the minimum number of games behind a session insight.
+	minSessionGames = 3
+
+	// minTimeBucketGames is the minimum number of games in a time-of-day or
+	// day-of-week bucket before it is compared with the overall win rate.
+	minTimeBucketGames = 4
+
+	// tiltWinRateDrop is how far below the baseline a win rate must fall to be reported.
+	tiltWinRateDrop = 0.15
+)
+
+// timeOfDayBuckets split the local day into four blocks of six hours.
+var timeOfDayBuckets = []string{"late-night (0-6h)", "morning (6-12h)", "afternoon (12-18h)", "evening (18-24h)"}
+
+// computeSessionSummary orders games by start time and groups them into sessions
+// separated by breaks of more than an hour. Games without timestamps are skipped.
+func computeSessionSummary(analyses []MatchAnalysis) *SessionSummary {
+	var games []MatchMetrics
+	for _, a := range analyses {
+		if a.Metrics.GameStartTimestamp > 0 {
+			games = append(games, a.Metrics)
+		}
+	}
+	if len(games) == 0 {
+		return nil
+	}
+	sort.Slice(games, func(i, j int) bool {
+		return games[i].GameStartTimestamp < games[j].GameStartTimestamp
+	})
+
+	var sessions [][]MatchMetrics
+	for i, g := range games {
+		if i == 0 || g.GameStartTimestamp-gameEnd(games[i-1]) > sessionGapMs {
+			sessions = append(sessions, nil)
+		}
+		sessions[len(sessions)-1] = append(sessions[len(sessions)-1], g)
+	}
+
+	summary := &SessionSummary{
+		Sessions:           len(sessions),
+		AvgGamesPerSession: float64(len(games)) / float64(len(sessions)),
+		WinRate:            sessionStats("", games).WinRate,
+	}
+
+	byIndex := make([][]MatchMetrics, maxSessionIndex)
+	var afterLosses, early, late []MatchMetrics
+	for _, session := range sessions {
+		summary.LongestSession = max(summary.LongestSession, len(session))
+		if len(session) >= longSessionGames {
+			summary.LongSessions++
+		}
+
+		losses := 0
+		for i, g := range session {
+			idx := min(i, maxSessionIndex-1)
+			byIndex[idx] = append(byIndex[idx], g)
+
+			if losses >= lossStreakLength {
+				afterLosses = append(afterLosses, g)
+			}
+			if g.Win {
+				losses = 0
+			} else {
+				losses++
+			}
+
+			if len(session) >= longSessionGames {
+				if i < longSessionGames-1 {
+					early = append(early, g)
+				} else {
+					late = append(late, g)
+				}
+			}
+		}
+	}
+
+	for i, group := range byIndex {
+		if len(group) == 0 {
+			continue
+		}
+		label := fmt.Sprintf("Game %d", i+1)
+		if i == maxSessionIndex-1 {
+			label += "+"
+		}
+		summary.ByGameIndex = append(summary.ByGameIndex, sessionStats(label, group))
+	}
+	if len(afterLosses) > 0 {
+		s := sessionStats(fmt.Sprintf("After %d+ losses", lossStreakLength), afterLosses)
+		summary.AfterLossStreak = &s
+	}
+	if len(early) > 0 {
+		e := sessionStats(fmt.Sprintf("Games 1-%d", longSessionGames-1), early)
+		l := sessionStats(fmt.Sprintf("Games %d+", longSessionGames), late)
+		summary.Early, summary.Late = &e, &l
+	}
+
+	byHour := make([][]MatchMetrics, len(timeOfDayBuckets))
+	byDay := make([][]MatchMetrics, 7)
+	for _, g := range games {
+		start := time.UnixMilli(g.GameStartTimestamp).Local()
+		byHour[start.Hour()/6] = append(byHour[start.Hour()/6], g)
+		// Weeks start on Monday.
+		day := (int(start.Weekday()) + 6) % 7
+		byDay[day] = append(byDay[day], g)
+	}
+	for i, group := range byHour {
+		if len(group) > 0 {
+			summary.ByTimeOfDay = append(summary.ByTimeOfDay, sessionStats(timeOfDayBuckets[i], group))
+		}
+	}
+	for i, group := range byDay {
+		if len(group) > 0 {
+			summary.ByDayOfWeek = append(summary.ByDayOfWeek, sessionStats(time.Weekday((i+1)%7).String(), group))
+		}
+	}
+
+	return summary
+}
+
+// gameEnd falls back to start plus duration for matches without an end timestamp.
+func gameEnd(m MatchMetrics) int64 {
+	if m.GameEndTimestamp > 0 {
+		return m.GameEndTimestamp
+	}
+	return m.GameStartTimestamp + m.GameDuration*1000
+}
+
+func sessionStats(label string, games []MatchMetrics) SessionStats {
+	stats := SessionStats{Label: label, Games: len(games)}
+	if len(games) == 0 {
+		return stats
+	}
+	wins := 0
+	for _, g := range games {
+		if g.Win {
+			wins++
+		}
+		stats.AvgKDA += g.KDA
+		stats.AvgCSPerMinute += g.CSPerMinute
+	}
+	n := float64(len(games))
+	stats.WinRate = float64(wins) / n
+	stats.AvgKDA /= n
+	stats.AvgCSPerMinute /= n
+	return stats
+}
+
+// sessionInsights flags tilt: losing after losing streaks, fading in long
+// sessions, and times of day or days of the week that go badly.
+func sessionInsights(s *SessionSummary) (strengths []Insight, weaknesses []Insight) {
+	if s == nil {
+		return nil, nil
+	}
+
+	if streak := s.AfterLossStreak; streak != nil && streak.Games >= minSessionGames {
+		if streak.WinRate <= s.WinRate-tiltWinRateDrop {
+			weaknesses = append(weaknesses, Insight{
+				Category: "tilt",
+				Description: fmt.Sprintf("Win rate drops to %.0f%% after %d losses in a row (%d games) -- stop after %d losses",
+					streak.WinRate*100, lossStreakLength, streak.Games, lossStreakLength),
+				Value: streak.WinRate,
+			})
+		} else if streak.WinRate >= s.WinRate {
+			strengths = append(strengths, Insight{
+				Category: "tilt",
+				Description: fmt.Sprintf("Keeps winning after losing streaks (%.0f%% in %d games) -- losses don't carry over",
+					streak.WinRate*100, streak.Games),
+				Value:      streak.WinRate,
+				IsStrength: true,
+			})
+		}
+	}
+
+	if s.Early != nil && s.Early.Games >= minSessionGames && s.Late.Games >= minSessionGames &&
+		s.Late.WinRate <= s.Early.WinRate-tiltWinRateDrop {
+		weaknesses = append(weaknesses, Insight{
+			Category: "tilt",
+			Description: fmt.Sprintf("Win rate falls from %.0f%% in the first %d games of a session to %.0f%% after (KDA %.1f to %.1f) -- cap sessions at %d games",
+				s.Early.WinRate*100, longSessionGames-1, s.Late.WinRate*100, s.Early.AvgKDA, s.Late.AvgKDA, longSessionGames-1),
+			Value: s.Late.WinRate,
+		})
+	}
+
+	for _, buckets := range [][]SessionStats{s.ByTimeOfDay, s.ByDayOfWeek} {
+		if worst, ok := worstBucket(buckets); ok && worst.WinRate <= s.WinRate-tiltWinRateDrop {
+			weaknesses = append(weaknesses, Insight{
+				Category: "tilt",
+				Description: fmt.Sprintf("Win rate is %.0f%% in %s games vs %.0f%% overall (%d games) -- avoid queueing then",
+					worst.WinRate*100, worst.Label, s.WinRate*100, worst.Games),
+				Value: worst.WinRate,
+			})
+		}
+	}
+
+	return strengths, weaknesses
+}
+
+// worstBucket returns the bucket with the lowest win rate among those with enough games.
+func worstBucket(buckets []SessionStats) (SessionStats, bool) {
+	var worst SessionStats
+	found := false
+	for _, b := range buckets {
+		if b.Games < minTimeBucketGames {
+			continue
+		}
+		if !found || b.WinRate < worst.WinRate {
+			worst, found = b, true
+		}
+	}
+	return worst, found
+}
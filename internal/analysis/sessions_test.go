@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// makeSession returns back-to-back 30 minute games, five minutes apart, starting at start.
+func makeSession(start time.Time, wins ...bool) []MatchAnalysis {
+	games := make([]MatchAnalysis, len(wins))
+	for i, win := range wins {
+		begin := start.Add(time.Duration(i) * 35 * time.Minute)
+		games[i] = MatchAnalysis{Metrics: MatchMetrics{
+			MatchID:            "M" + intToStr(int(begin.Unix())),
+			GameStartTimestamp: begin.UnixMilli(),
+			GameEndTimestamp:   begin.Add(30 * time.Minute).UnixMilli(),
+			GameDuration:       1800,
+			Win:                win,
+			KDA:                map[bool]float64{true: 4, false: 1}[win],
+		}}
+	}
+	return games
+}
+
+func TestComputeSessionSummary_GroupsByGap(t *testing.T) {
+	day := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	analyses := append(makeSession(day, true, false, true), makeSession(day.Add(10*time.Hour), false)...)
+	// Order of the input must not matter.
+	analyses[0], analyses[3] = analyses[3], analyses[0]
+
+	s := computeSessionSummary(analyses)
+	if s == nil {
+		t.Fatal("expected a session summary")
+	}
+	if s.Sessions != 2 || s.LongestSession != 3 || !approxEqual(s.AvgGamesPerSession, 2) {
+		t.Errorf("expected 2 sessions, longest 3, got %d, longest %d, avg %.2f", s.Sessions, s.LongestSession, s.AvgGamesPerSession)
+	}
+	if len(s.ByGameIndex) != 3 {
+		t.Fatalf("expected 3 game indexes, got %d", len(s.ByGameIndex))
+	}
+	if first := s.ByGameIndex[0]; first.Label != "Game 1" || first.Games != 2 || !approxEqual(first.WinRate, 0.5) {
+		t.Errorf("unexpected first games: %+v", first)
+	}
+	if s.AfterLossStreak != nil || s.Early != nil {
+		t.Error("expected no loss streaks or long sessions")
+	}
+	if len(s.ByDayOfWeek) != 1 || s.ByDayOfWeek[0].Label != day.Weekday().String() {
+		t.Errorf("expected one %s bucket, got %+v", day.Weekday(), s.ByDayOfWeek)
+	}
+}
+
+func TestComputeSessionSummary_WithoutTimestamps(t *testing.T) {
+	if s := computeSessionSummary([]MatchAnalysis{{Metrics: MatchMetrics{Win: true}}}); s != nil {
+		t.Errorf("expected nil without timestamps, got %+v", s)
+	}
+}
+
+func TestSessionInsights_Tilt(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	analyses := append(
+		makeSession(monday, false, false, false, false, true, false, false, false),
+		makeSession(monday.Add(35*time.Hour), true, true, true, true)...,
+	)
+
+	s := computeSessionSummary(analyses)
+	if s.AfterLossStreak == nil || s.AfterLossStreak.Games != 4 || !approxEqual(s.AfterLossStreak.WinRate, 0.25) {
+		t.Fatalf("unexpected loss streak stats: %+v", s.AfterLossStreak)
+	}
+	if s.LongSessions != 2 || s.Early.Games != 6 || s.Late.Games != 6 {
+		t.Fatalf("unexpected long session split: %d sessions, early %+v, late %+v", s.LongSessions, s.Early, s.Late)
+	}
+	if last := s.ByGameIndex[len(s.ByGameIndex)-1]; last.Label != "Game 5+" || last.Games != 4 {
+		t.Errorf("expected 4 games grouped as Game 5+, got %+v", last)
+	}
+
+	_, weaknesses := sessionInsights(s)
+	for _, want := range []string{
+		"Win rate drops to 25% after 2 losses in a row (4 games) -- stop after 2 losses",
+		"Win rate falls from 50% in the first 3 games of a session to 33% after (KDA 2.5 to 2.0) -- cap sessions at 3 games",
+		"in morning (6-12h) games",
+	} {
+		found := false
+		for _, w := range weaknesses {
+			if strings.Contains(w.Description, want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected a weakness containing %q, got %+v", want, weaknesses)
+		}
+	}
+}
+
+func TestSessionInsights_ResilientAfterLosses(t *testing.T) {
+	start := time.Date(2026, 3, 2, 18, 0, 0, 0, time.Local)
+	s := computeSessionSummary(makeSession(start, false, false, true, false, false, true, false, false, true))
+
+	strengths, weaknesses := sessionInsights(s)
+	if len(strengths) != 1 || !strings.Contains(strengths[0].Description, "Keeps winning after losing streaks") {
+		t.Errorf("expected a resilience strength, got %+v", strengths)
+	}
+	for _, w := range weaknesses {
+		if strings.Contains(w.Description, "losses in a row") {
+			t.Errorf("unexpected loss streak weakness: %q", w.Description)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// Skill slots reported by SKILL_LEVEL_UP events.
+const (
+	skillQ = 1
+	skillW = 2
+	skillE = 3
+	skillR = 4
+)
+
+var skillKeys = map[int]string{skillQ: "Q", skillW: "W", skillE: "E", skillR: "R"}
+
+// ultLevels are the champion levels at which each ultimate rank unlocks.
+var ultLevels = []int{6, 11, 16}
+
+const (
+	// minSkillGames is the minimum number of games with a timeline before late ultimates are reported.
+	minSkillGames = 3
+	// minSkillOrderGames is the minimum games on a max order before its win rate is compared.
+	minSkillOrderGames = 2
+	// skillOrderWinRateGap is the win rate difference between two max orders worth calling out.
+	skillOrderWinRateGap = 0.25
+)
+
+// AnalyzeSkills extracts the player's skill order from SKILL_LEVEL_UP events.
+// Evolutions (Kha'Zix, Kai'Sa, ...) are not skill points and are ignored.
+func AnalyzeSkills(timeline *models.Timeline, puuid string) (*SkillMetrics, error) {
+	participantID, err := findTimelineParticipantID(timeline, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &SkillMetrics{}
+	for _, frame := range timeline.Info.Frames {
+		for _, event := range frame.Events {
+			if event.Type != "SKILL_LEVEL_UP" || event.ParticipantID != participantID || event.LevelUpType == "EVOLVE" {
+				continue
+			}
+			metrics.LevelUps = append(metrics.LevelUps, SkillLevelUp{
+				Slot:  event.SkillSlot,
+				Level: len(metrics.LevelUps) + 1,
+				Time:  int(event.Timestamp / 1000),
+			})
+		}
+	}
+
+	metrics.MaxOrder = maxOrder(metrics.LevelUps)
+	metrics.LateUltLevels = lateUltLevels(metrics.LevelUps)
+
+	return metrics, nil
+}
+
+// maxOrder ranks Q, W and E by points spent, breaking ties by which reached
+// its final rank first, so a half-finished game still yields the intended order.
+func maxOrder(levelUps []SkillLevelUp) string {
+	type skill struct {
+		slot, points, reachedAt int
+	}
+	skills := []*skill{{slot: skillQ}, {slot: skillW}, {slot: skillE}}
+
+	for _, l := range levelUps {
+		for _, s := range skills {
+			if s.slot == l.Slot {
+				s.points++
+				s.reachedAt = l.Level
+			}
+		}
+	}
+	if skills[0].points+skills[1].points+skills[2].points == 0 {
+		return ""
+	}
+
+	sort.SliceStable(skills, func(i, j int) bool {
+		if skills[i].points != skills[j].points {
+			return skills[i].points > skills[j].points
+		}
+		return skills[i].reachedAt < skills[j].reachedAt
+	})
+
+	keys := make([]string, len(skills))
+	for i, s := range skills {
+		keys[i] = skillKeys[s.slot]
+	}
+	return strings.Join(keys, ">")
+}
+
+// lateUltLevels returns each ultimate breakpoint where the player had the level
+// but not the matching ultimate rank.
+func lateUltLevels(levelUps []SkillLevelUp) []int {
+	var late []int
+	for rank, level := range ultLevels {
+		if len(levelUps) < level {
+			break
+		}
+		ranks := 0
+		for _, l := range levelUps[:level] {
+			if l.Slot == skillR {
+				ranks++
+			}
+		}
+		if ranks <= rank {
+			late = append(late, level)
+		}
+	}
+	return late
+}
+
+// computeSkillSummary groups games by champion and max order, most played first.
+func computeSkillSummary(analyses []MatchAnalysis) *SkillSummary {
+	type orderKey struct {
+		champion, order string
+	}
+
+	summary := &SkillSummary{}
+	byKey := make(map[orderKey]*SkillOrderStats)
+	var order []orderKey
+
+	for _, a := range analyses {
+		s := a.Skills
+		if s == nil {
+			continue
+		}
+		summary.GamesWithTimeline++
+		if len(s.LateUltLevels) > 0 {
+			summary.GamesWithLateUlt++
+			summary.LateUltPoints += len(s.LateUltLevels)
+		}
+		if s.MaxOrder == "" {
+			continue
+		}
+
+		key := orderKey{champion: a.Metrics.ChampionName, order: s.MaxOrder}
+		stats, ok := byKey[key]
+		if !ok {
+			stats = &SkillOrderStats{ChampionName: key.champion, MaxOrder: key.order}
+			byKey[key] = stats
+			order = append(order, key)
+		}
+		stats.GamesPlayed++
+		if a.Metrics.Win {
+			stats.Wins++
+		}
+	}
+
+	if summary.GamesWithTimeline == 0 {
+		return nil
+	}
+
+	for _, key := range order {
+		stats := byKey[key]
+		stats.WinRate = float64(stats.Wins) / float64(stats.GamesPlayed)
+		summary.Orders = append(summary.Orders, *stats)
+	}
+	sort.SliceStable(summary.Orders, func(i, j int) bool {
+		return summary.Orders[i].GamesPlayed > summary.Orders[j].GamesPlayed
+	})
+
+	return summary
+}
+
+// skillInsights flags delayed ultimates and champions where one max order clearly wins more.
+func skillInsights(s *SkillSummary) (weaknesses []Insight) {
+	if s == nil {
+		return nil
+	}
+
+	if s.GamesWithTimeline >= minSkillGames && s.GamesWithLateUlt*3 >= s.GamesWithTimeline {
+		weaknesses = append(weaknesses, Insight{
+			Category: "skills",
+			Description: fmt.Sprintf("Ultimate not leveled at 6/11/16 in %d/%d games -- level R as soon as it unlocks",
+				s.GamesWithLateUlt, s.GamesWithTimeline),
+			Value: float64(s.GamesWithLateUlt) / float64(s.GamesWithTimeline),
+		})
+	}
+
+	best := make(map[string]SkillOrderStats)
+	worst := make(map[string]SkillOrderStats)
+	var champions []string
+	for _, o := range s.Orders {
+		if o.GamesPlayed < minSkillOrderGames {
+			continue
+		}
+		b, ok := best[o.ChampionName]
+		if !ok {
+			champions = append(champions, o.ChampionName)
+			best[o.ChampionName], worst[o.ChampionName] = o, o
+			continue
+		}
+		if o.WinRate > b.WinRate {
+			best[o.ChampionName] = o
+		}
+		if o.WinRate < worst[o.ChampionName].WinRate {
+			worst[o.ChampionName] = o
+		}
+	}
+
+	for _, champion := range champions {
+		b, w := best[champion], worst[champion]
+		if b.MaxOrder == w.MaxOrder || b.WinRate-w.WinRate < skillOrderWinRateGap {
+			continue
+		}
+		weaknesses = append(weaknesses, Insight{
+			Category: "skills",
+			Description: fmt.Sprintf("%s: maxing %s wins %.0f%% (%d games) vs. %.0f%% with %s (%d games)",
+				champion, b.MaxOrder, b.WinRate*100, b.GamesPlayed, w.WinRate*100, w.MaxOrder, w.GamesPlayed),
+			Value: b.WinRate - w.WinRate,
+		})
+	}
+
+	return weaknesses
+}
@@ -0,0 +1,122 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// skillEvents builds SKILL_LEVEL_UP events for participant 1, one per 30 seconds.
+func skillEvents(keys string) []models.TimelineEvent {
+	slots := map[rune]int{'Q': skillQ, 'W': skillW, 'E': skillE, 'R': skillR}
+	events := make([]models.TimelineEvent, 0, len(keys))
+	for i, k := range keys {
+		events = append(events, models.TimelineEvent{
+			Type:          "SKILL_LEVEL_UP",
+			Timestamp:     int64(i+1) * 30_000,
+			ParticipantID: 1,
+			SkillSlot:     slots[k],
+			LevelUpType:   "NORMAL",
+		})
+	}
+	return events
+}
+
+func TestAnalyzeSkills(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      string
+		wantOrder string
+		wantLate  []int
+	}{
+		{"standard Q max", "QWEQQRQWQWRWWEEREE", "Q>W>E", nil},
+		{"late first ult", "QEWQQQRQEWRE", "Q>E>W", []int{6}},
+		{"skipped second rank", "EQWEERQEEQQQWW", "E>Q>W", []int{11}},
+		{"short game", "QWE", "Q>W>E", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeline := makeTimeline([]string{"p1", "p2"}, 2)
+			timeline.Info.Frames[1].Events = skillEvents(tt.keys)
+
+			s, err := AnalyzeSkills(timeline, "p1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(s.LevelUps) != len(tt.keys) {
+				t.Errorf("expected %d level ups, got %d", len(tt.keys), len(s.LevelUps))
+			}
+			if s.MaxOrder != tt.wantOrder {
+				t.Errorf("expected max order %s, got %s", tt.wantOrder, s.MaxOrder)
+			}
+			if len(s.LateUltLevels) != len(tt.wantLate) {
+				t.Fatalf("expected late ult levels %v, got %v", tt.wantLate, s.LateUltLevels)
+			}
+			for i := range tt.wantLate {
+				if s.LateUltLevels[i] != tt.wantLate[i] {
+					t.Errorf("expected late ult levels %v, got %v", tt.wantLate, s.LateUltLevels)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyzeSkills_IgnoresEvolutions(t *testing.T) {
+	timeline := makeTimeline([]string{"p1", "p2"}, 2)
+	events := skillEvents("QWE")
+	events = append(events, models.TimelineEvent{Type: "SKILL_LEVEL_UP", Timestamp: 120_000, ParticipantID: 1, SkillSlot: skillR, LevelUpType: "EVOLVE"})
+	timeline.Info.Frames[1].Events = events
+
+	s, err := AnalyzeSkills(timeline, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.LevelUps) != 3 {
+		t.Errorf("expected evolutions to be skipped, got %d level ups", len(s.LevelUps))
+	}
+}
+
+func TestComputeSkillSummary(t *testing.T) {
+	skills := func(order string, late ...int) *SkillMetrics {
+		return &SkillMetrics{MaxOrder: order, LateUltLevels: late}
+	}
+	analyses := []MatchAnalysis{
+		{Metrics: MatchMetrics{ChampionName: "Ahri", Win: true}, Skills: skills("Q>W>E")},
+		{Metrics: MatchMetrics{ChampionName: "Ahri", Win: true}, Skills: skills("Q>W>E")},
+		{Metrics: MatchMetrics{ChampionName: "Ahri", Win: false}, Skills: skills("Q>W>E", 6)},
+		{Metrics: MatchMetrics{ChampionName: "Ahri", Win: false}, Skills: skills("W>Q>E", 6, 11)},
+		{Metrics: MatchMetrics{ChampionName: "Ahri", Win: false}, Skills: skills("W>Q>E")},
+		{Metrics: MatchMetrics{ChampionName: "Zed", Win: true}},
+	}
+
+	s := computeSkillSummary(analyses)
+	if s == nil {
+		t.Fatal("expected a summary")
+	}
+	if s.GamesWithTimeline != 5 || s.GamesWithLateUlt != 2 || s.LateUltPoints != 3 {
+		t.Errorf("unexpected totals: %+v", s)
+	}
+	if len(s.Orders) != 2 || s.Orders[0].MaxOrder != "Q>W>E" || s.Orders[0].GamesPlayed != 3 {
+		t.Fatalf("unexpected orders: %+v", s.Orders)
+	}
+	if !approxEqual(s.Orders[0].WinRate, 2.0/3) || !approxEqual(s.Orders[1].WinRate, 0) {
+		t.Errorf("unexpected win rates: %+v", s.Orders)
+	}
+
+	weaknesses := skillInsights(s)
+	if len(weaknesses) != 2 {
+		t.Fatalf("expected 2 skill insights, got %d: %+v", len(weaknesses), weaknesses)
+	}
+	if !strings.Contains(weaknesses[0].Description, "Ultimate not leveled at 6/11/16 in 2/5 games") {
+		t.Errorf("unexpected late ult insight: %q", weaknesses[0].Description)
+	}
+	if !strings.Contains(weaknesses[1].Description, "Ahri: maxing Q>W>E wins 67% (3 games) vs. 0% with W>Q>E (2 games)") {
+		t.Errorf("unexpected skill order insight: %q", weaknesses[1].Description)
+	}
+
+	if computeSkillSummary([]MatchAnalysis{{}}) != nil {
+		t.Error("expected nil summary without timelines")
+	}
+}
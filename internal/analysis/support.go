@@ -0,0 +1,190 @@
+package analysis
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+const (
+	// roamStartMs skips the first minutes, when supports are still securing levels 2 and 3.
+	roamStartMs = 180_000
+
+	// minSupportGames is the minimum number of support games before support insights are reported.
+	minSupportGames = 3
+)
+
+// AnalyzeSupport builds support metrics from end-of-game stats and, when timeline
+// is not nil, the player's roams to top and mid lane before 14 minutes.
+func AnalyzeSupport(timeline *models.Timeline, match *models.Match, puuid string) (*SupportMetrics, error) {
+	participant, _, err := findParticipant(match, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	gameDurationMin := float64(match.Info.GameDuration) / 60.0
+	metrics := &SupportMetrics{
+		HealsOnTeammates:   participant.TotalHealsOnTeammates,
+		ShieldsOnTeammates: participant.TotalDamageShieldedOnTeammates,
+		ControlWardsPlaced: participant.DetectorWardsPlaced,
+		StealthWardsPlaced: participant.WardsPlaced - participant.DetectorWardsPlaced,
+		WardsKilled:        participant.WardsKilled,
+	}
+	if gameDurationMin > 0 {
+		metrics.HealShieldPerMinute = float64(metrics.HealsOnTeammates+metrics.ShieldsOnTeammates) / gameDurationMin
+		metrics.CCPerMinute = float64(participant.TimeCCingOthers) / gameDurationMin
+	}
+	if c := participant.Challenges; c != nil {
+		metrics.SavesFromDeath = c.SaveAllyFromDeath
+		metrics.ControlWardsPlaced = c.ControlWardsPlaced
+		metrics.StealthWardsPlaced = c.StealthWardsPlaced
+	}
+
+	if timeline == nil {
+		return metrics, nil
+	}
+	participantID, err := findTimelineParticipantID(timeline, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.HasTimeline = true
+	metrics.FirstRoamAt = firstRoamSeconds(timeline.Info.Frames, participantID, participant.TeamID)
+	for _, v := range laneVisits(timeline.Info.Frames, participantID, participant.TeamID, roamStartMs, midGameStartMs) {
+		if v.Lane != ZoneBotLane {
+			metrics.Roams = append(metrics.Roams, v)
+		}
+	}
+
+	return metrics, nil
+}
+
+// firstRoamSeconds returns the first frame where the player stood in top or mid lane.
+func firstRoamSeconds(frames []models.TimelineFrame, participantID, teamID int) int {
+	key := strconv.Itoa(participantID)
+	for _, frame := range frames {
+		if frame.Timestamp < roamStartMs {
+			continue
+		}
+		if frame.Timestamp > midGameStartMs {
+			break
+		}
+		pf, ok := frame.ParticipantFrames[key]
+		if !ok {
+			continue
+		}
+		if zone := ClassifyZone(pf.Position, teamID); zone == ZoneTopLane || zone == ZoneMidLane {
+			return int(frame.Timestamp / 1000)
+		}
+	}
+	return 0
+}
+
+func computeSupportSummary(analyses []MatchAnalysis) *SupportSummary {
+	summary := &SupportSummary{}
+	var roamSum float64
+	var roamGames, roams, successful int
+
+	for _, a := range analyses {
+		s := a.Support
+		if s == nil {
+			continue
+		}
+		summary.Games++
+		summary.AvgHealShieldPerMinute += s.HealShieldPerMinute
+		summary.AvgCCPerMinute += s.CCPerMinute
+		summary.AvgSavesFromDeath += float64(s.SavesFromDeath)
+		summary.AvgControlWards += float64(s.ControlWardsPlaced)
+		summary.AvgStealthWards += float64(s.StealthWardsPlaced)
+		summary.AvgWardsKilled += float64(s.WardsKilled)
+
+		if !s.HasTimeline {
+			continue
+		}
+		summary.GamesWithTimeline++
+		if s.FirstRoamAt > 0 {
+			roamSum += float64(s.FirstRoamAt)
+			roamGames++
+		}
+		for _, r := range s.Roams {
+			roams += r.Ganks
+			successful += r.Successful
+		}
+	}
+
+	if summary.Games == 0 {
+		return nil
+	}
+
+	n := float64(summary.Games)
+	summary.AvgHealShieldPerMinute /= n
+	summary.AvgCCPerMinute /= n
+	summary.AvgSavesFromDeath /= n
+	summary.AvgControlWards /= n
+	summary.AvgStealthWards /= n
+	summary.AvgWardsKilled /= n
+	if roamGames > 0 {
+		summary.AvgFirstRoamAt = roamSum / float64(roamGames)
+	}
+	if summary.GamesWithTimeline > 0 {
+		summary.AvgRoamsBefore14 = float64(roams) / float64(summary.GamesWithTimeline)
+	}
+	if roams > 0 {
+		summary.RoamSuccessRate = float64(successful) / float64(roams)
+	}
+
+	return summary
+}
+
+// supportInsights judges warding, saves and map presence for supports.
+func supportInsights(s *SupportSummary) (strengths []Insight, weaknesses []Insight) {
+	if s == nil || s.Games < minSupportGames {
+		return nil, nil
+	}
+
+	if s.AvgControlWards >= 4 {
+		strengths = append(strengths, Insight{
+			Category:    "support",
+			Description: fmt.Sprintf("Keeps control wards down: %.1f per game", s.AvgControlWards),
+			Value:       s.AvgControlWards,
+			IsStrength:  true,
+		})
+	} else if s.AvgControlWards < 2 {
+		weaknesses = append(weaknesses, Insight{
+			Category:    "support",
+			Description: fmt.Sprintf("Only %.1f control wards per game -- a support should always have one on the map", s.AvgControlWards),
+			Value:       s.AvgControlWards,
+		})
+	}
+
+	if s.AvgSavesFromDeath >= 1 {
+		strengths = append(strengths, Insight{
+			Category:    "support",
+			Description: fmt.Sprintf("Saves %.1f allies from death per game", s.AvgSavesFromDeath),
+			Value:       s.AvgSavesFromDeath,
+			IsStrength:  true,
+		})
+	}
+
+	if s.GamesWithTimeline < minSupportGames {
+		return strengths, weaknesses
+	}
+
+	if s.AvgRoamsBefore14 < 0.5 {
+		weaknesses = append(weaknesses, Insight{
+			Category:    "support",
+			Description: fmt.Sprintf("Rarely roams before 14 min (%.1f per game) -- use bot lane resets to help mid and top", s.AvgRoamsBefore14),
+			Value:       s.AvgRoamsBefore14,
+		})
+	} else if s.RoamSuccessRate >= 0.5 {
+		strengths = append(strengths, Insight{
+			Category:    "support",
+			Description: fmt.Sprintf("Impactful roams: %.0f%% of roams before 14 min get a kill", s.RoamSuccessRate*100),
+			Value:       s.RoamSuccessRate,
+			IsStrength:  true,
+		})
+	}
+
+	return strengths, weaknesses
+}
@@ -0,0 +1,106 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+func TestAnalyzeSupport(t *testing.T) {
+	puuids := []string{"p1", "p2", "p3", "p4"}
+	timeline := makeTimeline(puuids, 16)
+	mid := models.Position{X: 7400, Y: 7600}
+	bot := models.Position{X: 10000, Y: 1000}
+	for minute := 2; minute < 16; minute++ {
+		setFrame(timeline, minute, 1, func(pf *models.ParticipantFrame) { pf.Position = bot })
+	}
+	setFrame(timeline, 7, 1, func(pf *models.ParticipantFrame) { pf.Position = mid })
+	timeline.Info.Frames[7].Events = []models.TimelineEvent{
+		{Type: "CHAMPION_KILL", Timestamp: 430_000, KillerID: 2, VictimID: 4, AssistingParticipantIDs: []int{1}, Position: &mid},
+	}
+
+	match := makeTimelineMatch(puuids)
+	match.Info.GameDuration = 1800
+	p := &match.Info.Participants[0]
+	p.TeamPosition = RoleSupport
+	p.TotalHealsOnTeammates = 6000
+	p.TotalDamageShieldedOnTeammates = 3000
+	p.TimeCCingOthers = 60
+	p.WardsKilled = 5
+	p.Challenges = &models.Challenges{SaveAllyFromDeath: 2, ControlWardsPlaced: 4, StealthWardsPlaced: 20}
+
+	s, err := AnalyzeSupport(timeline, match, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !approxEqual(s.HealShieldPerMinute, 300) || !approxEqual(s.CCPerMinute, 2) {
+		t.Errorf("unexpected per-minute stats: %+v", s)
+	}
+	if s.SavesFromDeath != 2 || s.ControlWardsPlaced != 4 || s.StealthWardsPlaced != 20 || s.WardsKilled != 5 {
+		t.Errorf("unexpected support stats: %+v", s)
+	}
+	if s.FirstRoamAt != 420 {
+		t.Errorf("expected first roam at 420s, got %d", s.FirstRoamAt)
+	}
+	if len(s.Roams) != 1 || s.Roams[0].Lane != ZoneMidLane || s.Roams[0].Ganks != 1 || s.Roams[0].Successful != 1 {
+		t.Errorf("expected one successful mid roam, got %+v", s.Roams)
+	}
+}
+
+func TestComputeSupportSummary(t *testing.T) {
+	game := &SupportMetrics{ControlWardsPlaced: 1, SavesFromDeath: 1, HasTimeline: true}
+	analyses := []MatchAnalysis{{Support: game}, {Support: game}, {Support: game}, {}}
+
+	s := computeSupportSummary(analyses)
+	if s == nil {
+		t.Fatal("expected a summary")
+	}
+	if s.Games != 3 || s.GamesWithTimeline != 3 || !approxEqual(s.AvgControlWards, 1) || s.AvgRoamsBefore14 != 0 {
+		t.Errorf("unexpected summary: %+v", s)
+	}
+
+	strengths, weaknesses := supportInsights(s)
+	if len(strengths) != 1 || !strings.Contains(strengths[0].Description, "Saves 1.0 allies") {
+		t.Errorf("unexpected support strengths: %+v", strengths)
+	}
+	if len(weaknesses) != 2 {
+		t.Fatalf("expected control ward and roam weaknesses, got %+v", weaknesses)
+	}
+	if !strings.Contains(weaknesses[0].Description, "Only 1.0 control wards") || !strings.Contains(weaknesses[1].Description, "Rarely roams") {
+		t.Errorf("unexpected support weaknesses: %+v", weaknesses)
+	}
+
+	if computeSupportSummary([]MatchAnalysis{{}}) != nil {
+		t.Error("expected nil summary without support games")
+	}
+}
+
+func TestIdentifyInsights_RoleThresholds(t *testing.T) {
+	avg := AverageMetrics{KDA: 2.5, KillParticipation: 0.55, CSPerMinute: 1.2, VisionScorePerMinute: 1.8, DamageShare: 0.08, ObjectiveParticipation: 0.5}
+
+	hasCategory := func(insights []Insight, cat string) bool {
+		for _, i := range insights {
+			if i.Category == cat {
+				return true
+			}
+		}
+		return false
+	}
+
+	_, support := identifyInsights(&PlayerAnalysis{Averages: avg, RoleBreakdown: []RoleStats{{Role: RoleSupport}}}, nil)
+	if hasCategory(support, "farming") || hasCategory(support, "combat") || hasCategory(support, "vision") {
+		t.Errorf("supports should not be judged on farm or damage, got %+v", support)
+	}
+
+	carry := AverageMetrics{KDA: 2.5, KillParticipation: 0.55, CSPerMinute: 5.8, VisionScorePerMinute: 0.9, DamageShare: 0.25, ObjectiveParticipation: 0.5}
+	_, mid := identifyInsights(&PlayerAnalysis{Averages: carry, RoleBreakdown: []RoleStats{{Role: RoleMiddle}}}, nil)
+	_, bottom := identifyInsights(&PlayerAnalysis{Averages: carry, RoleBreakdown: []RoleStats{{Role: RoleBottom}}}, nil)
+	if hasCategory(mid, "farming") {
+		t.Errorf("5.8 CS/min should be fine in mid, got %+v", mid)
+	}
+	if !hasCategory(bottom, "farming") {
+		t.Errorf("5.8 CS/min should be a weakness for bot laners, got %+v", bottom)
+	}
+}
@@ -35,6 +35,7 @@ func AnalyzeLanePhase(timeline *models.Timeline, match *models.Match, puuid stri
 				if of, ok := frame10.ParticipantFrames[opponentKey]; ok {
 					metrics.GoldDiffAt10 = metrics.GoldAt10 - of.TotalGold
 					metrics.CSDiffAt10 = metrics.CSAt10 - (of.MinionsKilled + of.JungleMinionsKilled)
+					metrics.XPDiffAt10 = metrics.XPAt10 - of.XP
 				}
 			}
 		}
@@ -45,16 +46,23 @@ func AnalyzeLanePhase(timeline *models.Timeline, match *models.Match, puuid stri
 		if pf, ok := frame15.ParticipantFrames[playerKey]; ok {
 			metrics.GoldAt15 = pf.TotalGold
 			metrics.CSAt15 = pf.MinionsKilled + pf.JungleMinionsKilled
+			metrics.XPAt15 = pf.XP
 
 			if opponentID > 0 {
 				if of, ok := frame15.ParticipantFrames[opponentKey]; ok {
 					metrics.GoldDiffAt15 = metrics.GoldAt15 - of.TotalGold
+					metrics.CSDiffAt15 = metrics.CSAt15 - (of.MinionsKilled + of.JungleMinionsKilled)
+					metrics.XPDiffAt15 = metrics.XPAt15 - of.XP
 				}
 			}
 		}
 	}
 
 	metrics.DeathsBefore10 = countDeathsBefore(timeline.Info.Frames, participantID, tenMinutesMs)
+	if opponentID > 0 {
+		metrics.LaneKills = countKillsBetween(timeline.Info.Frames, participantID, opponentID, fifteenMinutesMs)
+		metrics.LaneDeaths = countKillsBetween(timeline.Info.Frames, opponentID, participantID, fifteenMinutesMs)
+	}
 
 	return metrics, nil
 }
@@ -119,3 +127,15 @@ func countDeathsBefore(frames []models.TimelineFrame, participantID int, beforeM
 	}
 	return deaths
 }
+
+func countKillsBetween(frames []models.TimelineFrame, killerID, victimID int, beforeMs int64) int {
+	kills := 0
+	for _, frame := range frames {
+		for _, event := range frame.Events {
+			if event.Type == "CHAMPION_KILL" && event.KillerID == killerID && event.VictimID == victimID && event.Timestamp < beforeMs {
+				kills++
+			}
+		}
+	}
+	return kills
+}
@@ -0,0 +1,220 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// Ward types reported by WARD_PLACED and WARD_KILL events.
+const (
+	wardControl       = "CONTROL_WARD"
+	wardYellowTrinket = "YELLOW_TRINKET"
+	wardSight         = "SIGHT_WARD"
+	wardBlueTrinket   = "BLUE_TRINKET"
+)
+
+const (
+	midGameStartMs  = 14 * 60_000 // plates fall and laning ends
+	lateGameStartMs = 25 * 60_000
+
+	// minVisionGames is the minimum number of games with a timeline before
+	// ward habits are reported as insights.
+	minVisionGames = 3
+
+	// wardingDropShare is how far the per-minute ward rate must fall after
+	// laning, relative to laning, before it is reported.
+	wardingDropShare = 0.2
+)
+
+// AnalyzeVision counts the player's ward placements by game phase and ward type,
+// and how many enemy wards they cleared.
+func AnalyzeVision(timeline *models.Timeline, match *models.Match, puuid string) (*VisionMetrics, error) {
+	participantID, err := findTimelineParticipantID(timeline, puuid)
+	if err != nil {
+		return nil, err
+	}
+	participant, _, err := findParticipant(match, puuid)
+	if err != nil {
+		return nil, err
+	}
+	enemies := enemyParticipantIDs(timeline, match, participant.TeamID)
+
+	metrics := &VisionMetrics{}
+	for _, frame := range timeline.Info.Frames {
+		for _, event := range frame.Events {
+			switch event.Type {
+			case "WARD_PLACED":
+				if enemies[event.CreatorID] && isRealWard(event.WardType) {
+					metrics.EnemyWardsPlaced++
+				}
+				if event.CreatorID != participantID {
+					continue
+				}
+				recordWardPlaced(metrics, event)
+			case "WARD_KILL":
+				if event.KillerID == participantID && isRealWard(event.WardType) {
+					metrics.WardsKilled++
+				}
+			}
+		}
+	}
+
+	return metrics, nil
+}
+
+func recordWardPlaced(metrics *VisionMetrics, event models.TimelineEvent) {
+	switch event.WardType {
+	case wardControl:
+		metrics.ControlWards++
+		if metrics.FirstControlWardAt == 0 {
+			metrics.FirstControlWardAt = int(event.Timestamp / 1000)
+		}
+	case wardYellowTrinket, wardSight:
+		metrics.StealthWards++
+	case wardBlueTrinket:
+		metrics.BlueTrinketWards++
+	default:
+		return // undefined wards and champion traps don't count as vision
+	}
+
+	switch {
+	case event.Timestamp < midGameStartMs:
+		metrics.WardsPlacedLaning++
+	case event.Timestamp < lateGameStartMs:
+		metrics.WardsPlacedMid++
+	default:
+		metrics.WardsPlacedLate++
+	}
+}
+
+func isRealWard(wardType string) bool {
+	switch wardType {
+	case wardControl, wardYellowTrinket, wardSight, wardBlueTrinket:
+		return true
+	}
+	return false
+}
+
+// enemyParticipantIDs maps timeline participant IDs on the other team.
+func enemyParticipantIDs(timeline *models.Timeline, match *models.Match, teamID int) map[int]bool {
+	teams := make(map[string]int, len(match.Info.Participants))
+	for _, p := range match.Info.Participants {
+		teams[p.PUUID] = p.TeamID
+	}
+
+	enemies := make(map[int]bool)
+	for _, p := range timeline.Info.Participants {
+		if team, ok := teams[p.PUUID]; ok && team != teamID {
+			enemies[p.ParticipantID] = true
+		}
+	}
+	return enemies
+}
+
+func computeVisionSummary(analyses []MatchAnalysis) *VisionSummary {
+	summary := &VisionSummary{}
+	var wardsKilled, enemyWards int
+	var gamesPastLaning int
+
+	for _, a := range analyses {
+		v := a.Vision
+		if v == nil {
+			continue
+		}
+		summary.GamesWithTimeline++
+		summary.AvgWardsLaning += float64(v.WardsPlacedLaning)
+		summary.AvgWardsMid += float64(v.WardsPlacedMid)
+		summary.AvgWardsLate += float64(v.WardsPlacedLate)
+		summary.AvgControlWards += float64(v.ControlWards)
+		summary.AvgStealthWards += float64(v.StealthWards)
+		summary.AvgBlueTrinketWards += float64(v.BlueTrinketWards)
+		wardsKilled += v.WardsKilled
+		enemyWards += v.EnemyWardsPlaced
+
+		// Rates divide each phase's wards by that phase's length in this game.
+		if afterMs := int64(a.Metrics.GameDuration)*1000 - midGameStartMs; afterMs > 0 {
+			summary.AvgWardsPerMinLaning += float64(v.WardsPlacedLaning) / (midGameStartMs / 60_000)
+			summary.AvgWardsPerMinAfterLaning += float64(v.WardsPlacedMid+v.WardsPlacedLate) / (float64(afterMs) / 60_000)
+			gamesPastLaning++
+		}
+
+		if v.FirstControlWardAt == 0 || int64(v.FirstControlWardAt)*1000 >= midGameStartMs {
+			summary.GamesWithoutEarlyControlWard++
+		}
+	}
+
+	if summary.GamesWithTimeline == 0 {
+		return nil
+	}
+
+	n := float64(summary.GamesWithTimeline)
+	summary.AvgWardsLaning /= n
+	summary.AvgWardsMid /= n
+	summary.AvgWardsLate /= n
+	summary.AvgControlWards /= n
+	summary.AvgStealthWards /= n
+	summary.AvgBlueTrinketWards /= n
+	if gamesPastLaning > 0 {
+		summary.AvgWardsPerMinLaning /= float64(gamesPastLaning)
+		summary.AvgWardsPerMinAfterLaning /= float64(gamesPastLaning)
+	}
+	summary.AvgWardsKilled = float64(wardsKilled) / n
+	if enemyWards > 0 {
+		summary.WardClearRate = float64(wardsKilled) / float64(enemyWards)
+	}
+
+	return summary
+}
+
+// visionInsights turns ward habits into insights once there are enough games to trust them.
+func visionInsights(v *VisionSummary) (strengths []Insight, weaknesses []Insight) {
+	if v == nil || v.GamesWithTimeline < minVisionGames {
+		return nil, nil
+	}
+
+	noEarlyShare := float64(v.GamesWithoutEarlyControlWard) / float64(v.GamesWithTimeline)
+	if noEarlyShare >= 0.5 {
+		weaknesses = append(weaknesses, Insight{
+			Category: "vision",
+			Description: fmt.Sprintf("No control wards before 14 min in %d/%d games -- buy one on your first back",
+				v.GamesWithoutEarlyControlWard, v.GamesWithTimeline),
+			Value: noEarlyShare,
+		})
+	}
+
+	if v.AvgControlWards >= 3 {
+		strengths = append(strengths, Insight{
+			Category:    "vision",
+			Description: fmt.Sprintf("Consistent control ward usage at %.1f per game", v.AvgControlWards),
+			Value:       v.AvgControlWards,
+			IsStrength:  true,
+		})
+	}
+
+	if v.WardClearRate >= 0.25 {
+		strengths = append(strengths, Insight{
+			Category:    "vision",
+			Description: fmt.Sprintf("Clears %.0f%% of enemy wards -- denying vision well", v.WardClearRate*100),
+			Value:       v.WardClearRate,
+			IsStrength:  true,
+		})
+	} else if v.AvgWardsKilled < 1 {
+		weaknesses = append(weaknesses, Insight{
+			Category:    "vision",
+			Description: fmt.Sprintf("Only %.1f enemy wards cleared per game -- sweep before objectives", v.AvgWardsKilled),
+			Value:       v.AvgWardsKilled,
+		})
+	}
+
+	if v.AvgWardsPerMinAfterLaning < v.AvgWardsPerMinLaning*(1-wardingDropShare) {
+		weaknesses = append(weaknesses, Insight{
+			Category: "vision",
+			Description: fmt.Sprintf("Warding drops after laning: %.2f wards per minute before 14 min vs %.2f after",
+				v.AvgWardsPerMinLaning, v.AvgWardsPerMinAfterLaning),
+			Value: v.AvgWardsPerMinAfterLaning,
+		})
+	}
+
+	return strengths, weaknesses
+}
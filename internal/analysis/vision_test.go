@@ -0,0 +1,164 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+func TestAnalyzeVision(t *testing.T) {
+	puuids := []string{"p1", "p2", "p3", "p4"}
+	timeline := makeTimeline(puuids, 30)
+	timeline.Info.Frames[3].Events = []models.TimelineEvent{
+		{Type: "WARD_PLACED", Timestamp: 180_000, CreatorID: 1, WardType: wardYellowTrinket},
+		{Type: "WARD_PLACED", Timestamp: 185_000, CreatorID: 3, WardType: wardYellowTrinket},
+		{Type: "WARD_PLACED", Timestamp: 190_000, CreatorID: 2, WardType: wardYellowTrinket},
+	}
+	timeline.Info.Frames[10].Events = []models.TimelineEvent{
+		{Type: "WARD_KILL", Timestamp: 600_000, KillerID: 1, WardType: wardYellowTrinket},
+		{Type: "WARD_PLACED", Timestamp: 610_000, CreatorID: 1, WardType: "UNDEFINED"},
+	}
+	timeline.Info.Frames[16].Events = []models.TimelineEvent{
+		{Type: "WARD_PLACED", Timestamp: 960_000, CreatorID: 1, WardType: wardControl},
+		{Type: "WARD_PLACED", Timestamp: 970_000, CreatorID: 4, WardType: wardControl},
+	}
+	timeline.Info.Frames[27].Events = []models.TimelineEvent{
+		{Type: "WARD_PLACED", Timestamp: 1_620_000, CreatorID: 1, WardType: wardBlueTrinket},
+		{Type: "WARD_KILL", Timestamp: 1_630_000, KillerID: 1, WardType: wardControl},
+		{Type: "WARD_KILL", Timestamp: 1_640_000, KillerID: 2, WardType: wardControl},
+	}
+	match := makeTimelineMatch(puuids)
+
+	v, err := AnalyzeVision(timeline, match, "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v.WardsPlacedLaning != 1 || v.WardsPlacedMid != 1 || v.WardsPlacedLate != 1 {
+		t.Errorf("expected 1/1/1 wards by phase, got %d/%d/%d", v.WardsPlacedLaning, v.WardsPlacedMid, v.WardsPlacedLate)
+	}
+	if v.ControlWards != 1 || v.StealthWards != 1 || v.BlueTrinketWards != 1 {
+		t.Errorf("expected 1 ward of each type, got %+v", v)
+	}
+	if v.FirstControlWardAt != 960 {
+		t.Errorf("expected first control ward at 960s, got %d", v.FirstControlWardAt)
+	}
+	if v.WardsKilled != 2 {
+		t.Errorf("expected 2 wards killed, got %d", v.WardsKilled)
+	}
+	if v.EnemyWardsPlaced != 2 {
+		t.Errorf("expected 2 enemy wards placed, got %d", v.EnemyWardsPlaced)
+	}
+}
+
+func TestComputeVisionSummary(t *testing.T) {
+	analyses := []MatchAnalysis{
+		{Vision: &VisionMetrics{WardsPlacedLaning: 6, ControlWards: 2, WardsKilled: 2, EnemyWardsPlaced: 10, FirstControlWardAt: 400}},
+		{Vision: &VisionMetrics{WardsPlacedLaning: 4, WardsPlacedLate: 4, WardsKilled: 1, EnemyWardsPlaced: 10}},
+		{Vision: &VisionMetrics{WardsPlacedMid: 2, ControlWards: 1, EnemyWardsPlaced: 10, FirstControlWardAt: 900}},
+		{},
+	}
+
+	s := computeVisionSummary(analyses)
+	if s == nil {
+		t.Fatal("expected a summary")
+	}
+	if s.GamesWithTimeline != 3 {
+		t.Errorf("expected 3 games, got %d", s.GamesWithTimeline)
+	}
+	if !approxEqual(s.AvgWardsLaning, 10.0/3) || !approxEqual(s.AvgControlWards, 1) {
+		t.Errorf("unexpected averages: %+v", s)
+	}
+	if !approxEqual(s.WardClearRate, 0.1) {
+		t.Errorf("expected clear rate 0.1, got %f", s.WardClearRate)
+	}
+	if s.GamesWithoutEarlyControlWard != 2 {
+		t.Errorf("expected 2 games without an early control ward, got %d", s.GamesWithoutEarlyControlWard)
+	}
+
+	if computeVisionSummary([]MatchAnalysis{{}}) != nil {
+		t.Error("expected nil summary without timelines")
+	}
+}
+
+func TestComputeVisionSummary_RatesPerPhaseLength(t *testing.T) {
+	// 14 wards over 14 minutes of laning, 11 over the 11 minutes after: the same rate,
+	// though the raw count after laning is lower.
+	var analyses []MatchAnalysis
+	for range minVisionGames {
+		analyses = append(analyses, MatchAnalysis{
+			Metrics: MatchMetrics{GameDuration: 25 * 60},
+			Vision:  &VisionMetrics{WardsPlacedLaning: 14, WardsPlacedMid: 11},
+		})
+	}
+	// Games that end before 14 minutes have no later phase to compare.
+	analyses = append(analyses, MatchAnalysis{
+		Metrics: MatchMetrics{GameDuration: 10 * 60},
+		Vision:  &VisionMetrics{WardsPlacedLaning: 2},
+	})
+
+	s := computeVisionSummary(analyses)
+	if !approxEqual(s.AvgWardsPerMinLaning, 1) || !approxEqual(s.AvgWardsPerMinAfterLaning, 1) {
+		t.Errorf("expected 1 ward per minute in both phases, got %.2f and %.2f", s.AvgWardsPerMinLaning, s.AvgWardsPerMinAfterLaning)
+	}
+
+	_, weaknesses := visionInsights(s)
+	for _, w := range weaknesses {
+		if strings.Contains(w.Description, "Warding drops after laning") {
+			t.Errorf("expected no warding drop at a steady rate, got %q", w.Description)
+		}
+	}
+}
+
+func TestIdentifyInsights_Vision(t *testing.T) {
+	vision := &VisionSummary{
+		GamesWithTimeline:            10,
+		AvgWardsLaning:               8,
+		AvgWardsMid:                  3,
+		AvgWardsLate:                 2,
+		AvgWardsPerMinLaning:         8.0 / 14,
+		AvgWardsPerMinAfterLaning:    5.0 / 16,
+		AvgWardsKilled:               0.5,
+		WardClearRate:                0.05,
+		GamesWithoutEarlyControlWard: 7,
+	}
+
+	_, weaknesses := identifyInsights(&PlayerAnalysis{Averages: AverageMetrics{VisionScorePerMinute: 0.9}, Vision: vision}, nil)
+
+	var descriptions []string
+	for _, w := range weaknesses {
+		if w.Category == "vision" {
+			descriptions = append(descriptions, w.Description)
+		}
+	}
+	joined := strings.Join(descriptions, "\n")
+	for _, want := range []string{
+		"No control wards before 14 min in 7/10 games",
+		"Only 0.5 enemy wards cleared per game",
+		"Warding drops after laning",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing vision weakness %q in:\n%s", want, joined)
+		}
+	}
+
+	// A 15% drop is under the threshold.
+	vision.AvgWardsPerMinLaning, vision.AvgWardsPerMinAfterLaning = 1, 0.85
+	_, weaknesses = identifyInsights(&PlayerAnalysis{Averages: AverageMetrics{VisionScorePerMinute: 0.9}, Vision: vision}, nil)
+	for _, w := range weaknesses {
+		if strings.Contains(w.Description, "Warding drops after laning") {
+			t.Errorf("expected no warding drop under %.0f%%, got %q", wardingDropShare*100, w.Description)
+		}
+	}
+
+	strengths, weaknesses := identifyInsights(&PlayerAnalysis{
+		Averages: AverageMetrics{VisionScorePerMinute: 0.9},
+		Vision:   &VisionSummary{GamesWithTimeline: 2, GamesWithoutEarlyControlWard: 2},
+	}, nil)
+	for _, in := range append(strengths, weaknesses...) {
+		if in.Category == "vision" {
+			t.Errorf("expected no vision insights below %d games, got %q", minVisionGames, in.Description)
+		}
+	}
+}
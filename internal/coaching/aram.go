@@ -0,0 +1,126 @@
+package coaching
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HatiCode/league-buddy/internal/analysis"
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// isARAM reports whether an analysis covers ARAM games, which get their own
+// prompts since roles, lanes, vision and objectives don't apply.
+func isARAM(a *analysis.PlayerAnalysis) bool {
+	return a.Map == models.MapHowlingAbyss
+}
+
+// BuildARAMSystemPrompt is the system prompt for ARAM coaching. previous is nil
+// for a first session; otherwise previousAdvice is the advice given then.
+func BuildARAMSystemPrompt(current *analysis.PlayerAnalysis, previous *analysis.PlayerAnalysis, previousAdvice string) string {
+	var b strings.Builder
+
+	if previous == nil {
+		b.WriteString("You are an expert League of Legends coach specializing in ARAM on Howling Abyss. There are no roles, lanes, vision or objectives: games are won by poking and teamfighting well, staying alive, and using the snowball. Give actionable, specific advice.\n\n")
+	} else {
+		b.WriteString("You are an expert League of Legends coach specializing in ARAM on Howling Abyss, conducting a follow-up session. You previously coached this player and now have new ARAM games to assess their progress.\n\n")
+	}
+
+	writePlayerContext(&b, current)
+	writeARAMAverages(&b, current.Averages)
+	writeARAM(&b, current.ARAM)
+	writeGameFlow(&b, current.Curves)
+	writeSessions(&b, current.Sessions)
+	writeInsights(&b, "Strengths", current.Strengths)
+	writeInsights(&b, "Weaknesses", current.Weaknesses)
+	writeChampionPool(&b, current.ChampionPool)
+	writeBuilds(&b, current.Builds)
+	writeSkills(&b, current.Skills)
+	writeARAMMatchHistory(&b, current.Matches)
+
+	if previous != nil {
+		b.WriteString("## Previous Session\n\n")
+		b.WriteString("### Progress Since Last Session\n")
+		writeARAMDeltas(&b, previous, current)
+
+		b.WriteString("### Previous Coaching Advice\n")
+		b.WriteString(previousAdvice)
+		b.WriteString("\n\n")
+
+		b.WriteString("## Response Format\n")
+		b.WriteString("1. Progress assessment: what improved and what didn't since last session\n")
+		b.WriteString("2. Persistent weaknesses that need continued focus\n")
+		b.WriteString("3. Updated top 3 teamfighting habits to work on\n")
+		return b.String()
+	}
+
+	b.WriteString("## Response Format\n")
+	b.WriteString("1. Summary (2-3 sentences on how the player teamfights)\n")
+	b.WriteString("2. Top 3 teamfighting habits to work on, ranked by impact\n")
+	b.WriteString("3. Positioning, poke and snowball advice for the champions they play\n")
+	b.WriteString("4. Build adjustments worth making in ARAM\n")
+
+	return b.String()
+}
+
+func BuildARAMUserPrompt(isFollowUp bool) string {
+	if isFollowUp {
+		return "This is a follow-up ARAM coaching session. Compare my progress since the last session: what did I improve on, what still needs work, and what should I focus on next?"
+	}
+	return "Analyze my recent ARAM games and tell me how to win more teamfights. Be specific and actionable."
+}
+
+func writeARAMAverages(b *strings.Builder, avg analysis.AverageMetrics) {
+	b.WriteString("### Key Averages\n")
+	fmt.Fprintf(b, "- KDA: %.2f\n", avg.KDA)
+	fmt.Fprintf(b, "- Kill Participation: %.0f%%\n", avg.KillParticipation*100)
+	fmt.Fprintf(b, "- Damage/min: %.0f\n", avg.DamagePerMinute)
+	fmt.Fprintf(b, "- Gold/min: %.0f\n", avg.GoldPerMinute)
+	b.WriteString("\n")
+}
+
+func writeARAM(b *strings.Builder, s *analysis.ARAMSummary) {
+	if s == nil {
+		return
+	}
+	fmt.Fprintf(b, "### Teamfighting (%d games)\n", s.Games)
+	fmt.Fprintf(b, "- Damage Share: %.0f%%\n", s.AvgDamageShare*100)
+	fmt.Fprintf(b, "- Damage Taken Share: %.0f%%\n", s.AvgDamageTakenShare*100)
+	fmt.Fprintf(b, "- Healing and Shielding on Teammates/min: %.0f\n", s.AvgHealShieldPerMinute)
+	fmt.Fprintf(b, "- Deaths/min: %.2f (%.0f%% of the game spent dead)\n", s.AvgDeathsPerMinute, s.AvgTimeDeadShare*100)
+	if s.SnowballGames > 0 {
+		fmt.Fprintf(b, "- Snowball: %.1f casts per game, %.0f%% hit (%d games with Mark)\n", s.AvgSnowballCasts, s.SnowballHitRate*100, s.SnowballGames)
+	}
+	b.WriteString("\n")
+}
+
+func writeARAMMatchHistory(b *strings.Builder, matches []analysis.MatchAnalysis) {
+	if len(matches) == 0 {
+		return
+	}
+	b.WriteString("### Recent Matches\n")
+	for _, m := range matches {
+		result := "Loss"
+		if m.Metrics.Win {
+			result = "Win"
+		}
+		fmt.Fprintf(b, "- %s (%s): %.1f KDA, %.0f DPM", m.Metrics.ChampionName, result, m.Metrics.KDA, m.Metrics.DamagePerMinute)
+		if a := m.ARAM; a != nil {
+			fmt.Fprintf(b, ", %.0f%% damage share, %.0f%% damage taken share", a.DamageShare*100, a.DamageTakenShare*100)
+		}
+		fmt.Fprintf(b, " [%s]\n", m.Metrics.MatchID)
+	}
+	b.WriteString("\n")
+}
+
+func writeARAMDeltas(b *strings.Builder, previous, current *analysis.PlayerAnalysis) {
+	prev, curr := previous.Averages, current.Averages
+	writeDelta(b, "KDA", prev.KDA, curr.KDA, "%.2f", false)
+	writeDelta(b, "Kill Participation", prev.KillParticipation*100, curr.KillParticipation*100, "%.0f%%", false)
+	writeDelta(b, "Damage/min", prev.DamagePerMinute, curr.DamagePerMinute, "%.0f", false)
+	if p, c := previous.ARAM, current.ARAM; p != nil && c != nil {
+		writeDelta(b, "Damage Share", p.AvgDamageShare*100, c.AvgDamageShare*100, "%.0f%%", false)
+		writeDelta(b, "Deaths/min", p.AvgDeathsPerMinute, c.AvgDeathsPerMinute, "%.2f", true)
+		writeDelta(b, "Snowball Hit Rate", p.SnowballHitRate*100, c.SnowballHitRate*100, "%.0f%%", false)
+	}
+	b.WriteString("\n")
+}
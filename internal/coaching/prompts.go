@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/HatiCode/league-buddy/internal/analysis"
+	"github.com/HatiCode/league-buddy/internal/models"
 )
 
 func BuildInitialSystemPrompt(a *analysis.PlayerAnalysis) string {
@@ -14,10 +15,21 @@ func BuildInitialSystemPrompt(a *analysis.PlayerAnalysis) string {
 
 	writePlayerContext(&b, a)
 	writeAverages(&b, a.Averages)
+	writeBenchmarks(&b, a.Percentiles)
+	writeObjectives(&b, a.Objectives)
+	writeDeaths(&b, a.Deaths)
+	writeVision(&b, a.Vision)
+	writeGameFlow(&b, a.Curves)
+	writeJungle(&b, a.Jungle)
+	writeSupport(&b, a.Support)
+	writeSessions(&b, a.Sessions)
 	writeConsistency(&b, a.Consistency)
 	writeInsights(&b, "Strengths", a.Strengths)
 	writeInsights(&b, "Weaknesses", a.Weaknesses)
 	writeChampionPool(&b, a.ChampionPool)
+	writeBuilds(&b, a.Builds)
+	writeSkills(&b, a.Skills)
+	writeWorstMatchups(&b, a.Matchups)
 	writeRoleBreakdown(&b, a.RoleBreakdown)
 	writeMatchHistory(&b, a.Matches)
 
@@ -37,10 +49,21 @@ func BuildFollowUpSystemPrompt(current *analysis.PlayerAnalysis, previous *analy
 
 	writePlayerContext(&b, current)
 	writeAverages(&b, current.Averages)
+	writeBenchmarks(&b, current.Percentiles)
+	writeObjectives(&b, current.Objectives)
+	writeDeaths(&b, current.Deaths)
+	writeVision(&b, current.Vision)
+	writeGameFlow(&b, current.Curves)
+	writeJungle(&b, current.Jungle)
+	writeSupport(&b, current.Support)
+	writeSessions(&b, current.Sessions)
 	writeConsistency(&b, current.Consistency)
 	writeInsights(&b, "Current Strengths", current.Strengths)
 	writeInsights(&b, "Current Weaknesses", current.Weaknesses)
 	writeChampionPool(&b, current.ChampionPool)
+	writeBuilds(&b, current.Builds)
+	writeSkills(&b, current.Skills)
+	writeWorstMatchups(&b, current.Matchups)
 	writeMatchHistory(&b, current.Matches)
 
 	b.WriteString("## Previous Session\n\n")
@@ -77,6 +100,13 @@ func writePlayerContext(b *strings.Builder, a *analysis.PlayerAnalysis) {
 	if a.Tier != "" {
 		fmt.Fprintf(b, "- Rank: %s %s (%d LP)\n", a.Tier, a.Rank, a.LeaguePoints)
 	}
+	if a.Queue != "" {
+		label := a.Queue
+		if g, err := models.QueueGroupByName(a.Queue); err == nil {
+			label = g.Label
+		}
+		fmt.Fprintf(b, "- Queue: %s\n", label)
+	}
 	fmt.Fprintf(b, "- Win Rate: %.0f%% across %d matches\n", a.WinRate*100, a.TotalMatches)
 	b.WriteString("\n")
 }
@@ -95,6 +125,167 @@ func writeAverages(b *strings.Builder, avg analysis.AverageMetrics) {
 	b.WriteString("\n")
 }
 
+func writeBenchmarks(b *strings.Builder, percentiles []analysis.MetricPercentile) {
+	if len(percentiles) == 0 {
+		return
+	}
+	group := "all tiers"
+	if tier := percentiles[0].Tier; tier != "" {
+		group = tier
+	}
+	fmt.Fprintf(b, "### Rank Benchmarks (%s %s, percentile of same-role players)\n", group, percentiles[0].Role)
+	for _, p := range percentiles {
+		fmt.Fprintf(b, "- %s: %.2f -- percentile %.0f of %d games\n", p.Label, p.Value, p.Percentile, p.Samples)
+	}
+	b.WriteString("\n")
+}
+
+func writeObjectives(b *strings.Builder, o *analysis.ObjectiveSummary) {
+	if o == nil {
+		return
+	}
+	fmt.Fprintf(b, "### Objective Control (%d games with timeline)\n", o.GamesWithTimeline)
+	fmt.Fprintf(b, "- Dragons: %.1f taken vs %.1f conceded per game, soul %.0f%% vs enemy soul %.0f%%\n",
+		o.AvgAllyDragons, o.AvgEnemyDragons, o.SoulRate*100, o.EnemySoulRate*100)
+	if o.AvgFirstTowerTime > 0 {
+		fmt.Fprintf(b, "- First Tower: taken in %.0f%% of games, falls at %s on average\n",
+			o.FirstTowerRate*100, formatGameTime(o.AvgFirstTowerTime))
+	}
+	fmt.Fprintf(b, "- Plates: %.1f taken vs %.1f lost per game\n", o.AvgPlatesTaken, o.AvgPlatesLost)
+	if o.ObjectivesSecured > 0 {
+		fmt.Fprintf(b, "- Epic monsters secured: %d, player nearby and alive for %.0f%%\n",
+			o.ObjectivesSecured, o.SecuredPresentRate*100)
+	}
+	if o.ObjectivesLost > 0 {
+		fmt.Fprintf(b, "- Epic monsters lost: %d, player dead for %.0f%% and elsewhere on the map for %.0f%%\n",
+			o.ObjectivesLost, o.LostWhileDeadRate*100, o.LostWhileAwayRate*100)
+	}
+	b.WriteString("\n")
+}
+
+func writeDeaths(b *strings.Builder, d *analysis.DeathSummary) {
+	if d == nil || d.TotalDeaths == 0 {
+		return
+	}
+	fmt.Fprintf(b, "### Deaths (%d across %d games with timeline)\n", d.TotalDeaths, d.GamesWithTimeline)
+	zones := make([]string, 0, len(d.ByZone))
+	for _, z := range d.ByZone {
+		zones = append(zones, fmt.Sprintf("%s %.0f%%", strings.ReplaceAll(z.Zone, "_", " "), z.Share*100))
+	}
+	if len(zones) > 0 {
+		fmt.Fprintf(b, "- Where: %s\n", strings.Join(zones, ", "))
+	}
+	fmt.Fprintf(b, "- How: %d solo, %d outnumbered, %d to turrets/minions/monsters\n",
+		d.SoloDeaths, d.OutnumberedDeaths, d.ExecutedDeaths)
+	if d.ShutdownsGiven > 0 {
+		fmt.Fprintf(b, "- Shutdowns given up: %d (%d gold)\n", d.ShutdownsGiven, d.ShutdownGold)
+	}
+	b.WriteString("\n")
+}
+
+func writeVision(b *strings.Builder, v *analysis.VisionSummary) {
+	if v == nil {
+		return
+	}
+	fmt.Fprintf(b, "### Vision (%d games with timeline)\n", v.GamesWithTimeline)
+	fmt.Fprintf(b, "- Wards placed per game: %.1f before 14 min, %.1f from 14-25 min, %.1f after 25 min\n",
+		v.AvgWardsLaning, v.AvgWardsMid, v.AvgWardsLate)
+	fmt.Fprintf(b, "- Wards placed per minute: %.2f before 14 min, %.2f after\n",
+		v.AvgWardsPerMinLaning, v.AvgWardsPerMinAfterLaning)
+	fmt.Fprintf(b, "- By type: %.1f control, %.1f stealth, %.1f blue trinket per game\n",
+		v.AvgControlWards, v.AvgStealthWards, v.AvgBlueTrinketWards)
+	fmt.Fprintf(b, "- Wards cleared: %.1f per game (%.0f%% of enemy wards)\n", v.AvgWardsKilled, v.WardClearRate*100)
+	fmt.Fprintf(b, "- No control ward before 14 min: %d/%d games\n", v.GamesWithoutEarlyControlWard, v.GamesWithTimeline)
+	b.WriteString("\n")
+}
+
+func writeGameFlow(b *strings.Builder, c *analysis.CurveSummary) {
+	if c == nil {
+		return
+	}
+	fmt.Fprintf(b, "### Game Flow (%d games with timeline)\n", c.GamesWithTimeline)
+	fmt.Fprintf(b, "- Team reached a 3k+ gold lead in %d games and threw %d of them\n", c.GamesAhead, c.Throws)
+	fmt.Fprintf(b, "- Team fell 3k+ gold behind in %d games and came back in %d\n", c.GamesBehind, c.Comebacks)
+	fmt.Fprintf(b, "- Average peak lead %+.0f, average worst deficit %+.0f, player gold share %.0f%%\n",
+		c.AvgMaxLead, c.AvgMaxDeficit, c.AvgGoldShare*100)
+	b.WriteString("\n")
+}
+
+func writeJungle(b *strings.Builder, j *analysis.JungleSummary) {
+	if j == nil {
+		return
+	}
+	fmt.Fprintf(b, "### Jungle (%d games, %d with timeline)\n", j.Games, j.GamesWithTimeline)
+	fmt.Fprintf(b, "- Counter-jungling: %.0f%% of camps from the enemy jungle, %.1f scuttle crabs per game\n",
+		j.AvgCounterJungleShare*100, j.AvgScuttleCrabs)
+	if j.GamesWithTimeline > 0 {
+		if j.AvgFirstClearAt > 0 {
+			fmt.Fprintf(b, "- First clear: %s on average\n", formatGameTime(j.AvgFirstClearAt))
+		}
+		fmt.Fprintf(b, "- Ganks before 10 min: %.1f per game, %.0f%% get a kill\n", j.AvgGanksBefore10, j.GankSuccessRate*100)
+		lanes := make([]string, 0, len(j.GanksByLane))
+		for _, g := range j.GanksByLane {
+			lanes = append(lanes, fmt.Sprintf("%s %d/%d", strings.TrimSuffix(g.Lane, "_lane"), g.Successful, g.Ganks))
+		}
+		if len(lanes) > 0 {
+			fmt.Fprintf(b, "- Successful ganks by lane: %s\n", strings.Join(lanes, ", "))
+		}
+		fmt.Fprintf(b, "- At the pit before %.0f%% of epic monsters\n", j.ObjectiveSetupRate*100)
+	}
+	b.WriteString("\n")
+}
+
+func writeSupport(b *strings.Builder, s *analysis.SupportSummary) {
+	if s == nil {
+		return
+	}
+	fmt.Fprintf(b, "### Support (%d games, %d with timeline)\n", s.Games, s.GamesWithTimeline)
+	fmt.Fprintf(b, "- Heals and shields on allies: %.0f per minute, CC: %.1f seconds per minute, saves from death: %.1f per game\n",
+		s.AvgHealShieldPerMinute, s.AvgCCPerMinute, s.AvgSavesFromDeath)
+	fmt.Fprintf(b, "- Wards per game: %.1f control, %.1f stealth, %.1f cleared\n",
+		s.AvgControlWards, s.AvgStealthWards, s.AvgWardsKilled)
+	if s.GamesWithTimeline > 0 {
+		line := fmt.Sprintf("- Roams before 14 min: %.1f per game, %.0f%% get a kill", s.AvgRoamsBefore14, s.RoamSuccessRate*100)
+		if s.AvgFirstRoamAt > 0 {
+			line += fmt.Sprintf(", first roam at %s", formatGameTime(s.AvgFirstRoamAt))
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+}
+
+func writeSessions(b *strings.Builder, s *analysis.SessionSummary) {
+	if s == nil {
+		return
+	}
+	fmt.Fprintf(b, "### Play Sessions (%d sessions, %.1f games on average, longest %d)\n",
+		s.Sessions, s.AvgGamesPerSession, s.LongestSession)
+	writeSessionStats(b, "By game in session", s.ByGameIndex)
+	if s.AfterLossStreak != nil {
+		writeSessionStats(b, "After a losing streak", []analysis.SessionStats{*s.AfterLossStreak})
+	}
+	if s.Early != nil {
+		writeSessionStats(b, fmt.Sprintf("In %d long sessions", s.LongSessions), []analysis.SessionStats{*s.Early, *s.Late})
+	}
+	writeSessionStats(b, "By time of day", s.ByTimeOfDay)
+	writeSessionStats(b, "By day of week", s.ByDayOfWeek)
+	b.WriteString("\n")
+}
+
+func writeSessionStats(b *strings.Builder, title string, stats []analysis.SessionStats) {
+	fmt.Fprintf(b, "- %s:\n", title)
+	for _, st := range stats {
+		fmt.Fprintf(b, "  - %s: %d games, %.0f%% WR, %.1f KDA, %.1f CS/min\n",
+			st.Label, st.Games, st.WinRate*100, st.AvgKDA, st.AvgCSPerMinute)
+	}
+}
+
+// formatGameTime renders seconds from game start as m:ss.
+func formatGameTime(seconds float64) string {
+	s := int(seconds)
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
 func writeConsistency(b *strings.Builder, c analysis.ConsistencyMetrics) {
 	b.WriteString("### Consistency\n")
 	fmt.Fprintf(b, "- KDA StdDev: %.2f\n", c.KDAStdDev)
@@ -126,6 +317,66 @@ func writeChampionPool(b *strings.Builder, pool []analysis.ChampionStats) {
 	b.WriteString("\n")
 }
 
+// maxWorstMatchups caps the matchups listed in the prompt.
+const maxWorstMatchups = 3
+
+func writeBuilds(b *strings.Builder, builds []analysis.ChampionBuildStats) {
+	var lines []string
+	for _, c := range builds {
+		if c.AvgFirstItemAt == 0 {
+			continue
+		}
+		line := fmt.Sprintf("- %s (%d games): first item at %s", c.ChampionName, c.GamesWithTimeline, formatGameTime(c.AvgFirstItemAt))
+		if c.AvgFirstItemAtWins > 0 && c.AvgFirstItemAtLosses > 0 {
+			line += fmt.Sprintf(" (%s in wins, %s in losses)", formatGameTime(c.AvgFirstItemAtWins), formatGameTime(c.AvgFirstItemAtLosses))
+		}
+		if c.AvgSecondItemAt > 0 {
+			line += fmt.Sprintf(", second item at %s", formatGameTime(c.AvgSecondItemAt))
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	b.WriteString("### Item Timings (completed items, games with timeline)\n")
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+}
+
+func writeSkills(b *strings.Builder, s *analysis.SkillSummary) {
+	if s == nil {
+		return
+	}
+	fmt.Fprintf(b, "### Skill Orders (%d games with timeline)\n", s.GamesWithTimeline)
+	for _, o := range s.Orders {
+		fmt.Fprintf(b, "- %s max %s: %d games, %.0f%% WR\n", o.ChampionName, o.MaxOrder, o.GamesPlayed, o.WinRate*100)
+	}
+	if s.GamesWithLateUlt > 0 {
+		fmt.Fprintf(b, "- Late ultimate points: %d across %d games\n", s.LateUltPoints, s.GamesWithLateUlt)
+	}
+	b.WriteString("\n")
+}
+
+func writeWorstMatchups(b *strings.Builder, matchups []analysis.MatchupStats) {
+	worst := analysis.WorstMatchups(matchups, analysis.DefaultMinMatchupGames, maxWorstMatchups)
+	if len(worst) == 0 {
+		return
+	}
+	b.WriteString("### Worst Matchups\n")
+	for _, m := range worst {
+		fmt.Fprintf(b, "- %s vs %s: %d games, %.0f%% WR", m.ChampionName, m.OpponentChampionName, m.GamesPlayed, m.WinRate*100)
+		if m.GamesWithTimeline > 0 {
+			fmt.Fprintf(b, ", %+.0f gold / %+.1f CS / %+.0f XP @15, %d lane kills / %d lane deaths",
+				m.AvgGoldDiffAt15, m.AvgCSDiffAt15, m.AvgXPDiffAt15, m.LaneKills, m.LaneDeaths)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
 func writeRoleBreakdown(b *strings.Builder, roles []analysis.RoleStats) {
 	if len(roles) == 0 {
 		return
@@ -147,14 +398,28 @@ func writeMatchHistory(b *strings.Builder, matches []analysis.MatchAnalysis) {
 		if m.Metrics.Win {
 			result = "Win"
 		}
-		fmt.Fprintf(b, "- %s %s (%s): %.1f KDA, %.1f CS/min, %.0f DPM [%s]\n",
+		fmt.Fprintf(b, "- %s %s (%s): %.1f KDA, %.1f CS/min, %.0f DPM%s [%s]\n",
 			m.Metrics.ChampionName, m.Metrics.Role, result,
 			m.Metrics.KDA, m.Metrics.CSPerMinute, m.Metrics.DamagePerMinute,
-			m.Metrics.MatchID)
+			describeCurve(m.Curve), m.Metrics.MatchID)
 	}
 	b.WriteString("\n")
 }
 
+// describeCurve summarizes how a game's gold lead moved, e.g. ", threw a +4.2k lead at 18 min".
+func describeCurve(c *analysis.GameCurve) string {
+	switch {
+	case c == nil:
+		return ""
+	case c.Throw:
+		return fmt.Sprintf(", threw a %+.1fk lead at %d min", float64(c.MaxLead)/1000, c.MaxLeadAt)
+	case c.Comeback:
+		return fmt.Sprintf(", came back from %+.1fk at %d min", float64(c.MaxDeficit)/1000, c.MaxDeficitAt)
+	default:
+		return fmt.Sprintf(", peak lead %+.1fk, worst deficit %+.1fk", float64(c.MaxLead)/1000, float64(c.MaxDeficit)/1000)
+	}
+}
+
 func writeDeltas(b *strings.Builder, prev, curr analysis.AverageMetrics) {
 	writeDelta(b, "KDA", prev.KDA, curr.KDA, "%.2f", false)
 	writeDelta(b, "Kill Participation", prev.KillParticipation*100, curr.KillParticipation*100, "%.0f%%", false)
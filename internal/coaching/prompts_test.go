@@ -164,6 +164,18 @@ func TestBuildInitialSystemPromptNoRank(t *testing.T) {
 	}
 }
 
+func TestBuildInitialSystemPromptQueue(t *testing.T) {
+	a := makeTestAnalysis()
+	if strings.Contains(BuildInitialSystemPrompt(a), "Queue:") {
+		t.Error("prompt should not contain a queue line without a queue")
+	}
+
+	a.Queue = "flex"
+	if prompt := BuildInitialSystemPrompt(a); !strings.Contains(prompt, "- Queue: Ranked Flex") {
+		t.Error("prompt missing queue label")
+	}
+}
+
 func TestBuildInitialSystemPromptTokenBudget(t *testing.T) {
 	a := makeTestAnalysis()
 	prompt := BuildInitialSystemPrompt(a)
@@ -173,3 +185,357 @@ func TestBuildInitialSystemPromptTokenBudget(t *testing.T) {
 		t.Errorf("prompt is %d chars, likely exceeds 4K token budget", len(prompt))
 	}
 }
+
+func TestBuildInitialSystemPromptWorstMatchups(t *testing.T) {
+	a := makeTestAnalysis()
+	a.Matchups = []analysis.MatchupStats{
+		{ChampionName: "Ahri", OpponentChampionName: "Syndra", GamesPlayed: 3, Wins: 2, WinRate: 0.67},
+		{ChampionName: "Zed", OpponentChampionName: "Malzahar", GamesPlayed: 3, Wins: 0, WinRate: 0,
+			GamesWithTimeline: 3, AvgGoldDiffAt15: -850, AvgCSDiffAt15: -12.3, AvgXPDiffAt15: -400, LaneDeaths: 4},
+		{ChampionName: "Lux", OpponentChampionName: "Fizz", GamesPlayed: 1, Wins: 0, WinRate: 0},
+	}
+
+	prompt := BuildInitialSystemPrompt(a)
+
+	if !strings.Contains(prompt, "### Worst Matchups") {
+		t.Fatal("prompt missing worst matchups section")
+	}
+	if !strings.Contains(prompt, "Zed vs Malzahar: 3 games, 0% WR, -850 gold / -12.3 CS / -400 XP @15, 0 lane kills / 4 lane deaths") {
+		t.Error("prompt missing Zed vs Malzahar details")
+	}
+	if strings.Contains(prompt, "Ahri vs Syndra") {
+		t.Error("winning matchup should not be listed")
+	}
+	if strings.Contains(prompt, "Lux vs Fizz") {
+		t.Error("single-game matchup should not be listed")
+	}
+}
+
+func TestBuildInitialSystemPromptNoMatchups(t *testing.T) {
+	prompt := BuildInitialSystemPrompt(makeTestAnalysis())
+	if strings.Contains(prompt, "Worst Matchups") {
+		t.Error("prompt should omit worst matchups without matchup data")
+	}
+}
+
+func TestBuildInitialSystemPromptObjectives(t *testing.T) {
+	a := makeTestAnalysis()
+	a.Objectives = &analysis.ObjectiveSummary{
+		GamesWithTimeline: 4,
+		AvgAllyDragons:    1.5,
+		AvgEnemyDragons:   2.5,
+		EnemySoulRate:     0.5,
+		FirstTowerRate:    0.25,
+		AvgFirstTowerTime: 845,
+		ObjectivesLost:    6,
+		LostWhileDeadRate: 0.5,
+	}
+
+	prompt := BuildInitialSystemPrompt(a)
+
+	for _, want := range []string{
+		"### Objective Control (4 games with timeline)",
+		"Dragons: 1.5 taken vs 2.5 conceded per game, soul 0% vs enemy soul 50%",
+		"First Tower: taken in 25% of games, falls at 14:05 on average",
+		"Epic monsters lost: 6, player dead for 50%",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+func TestBuildInitialSystemPromptDeaths(t *testing.T) {
+	a := makeTestAnalysis()
+	a.Deaths = &analysis.DeathSummary{
+		GamesWithTimeline: 3,
+		TotalDeaths:       10,
+		ByZone: []analysis.DeathZoneStats{
+			{Zone: analysis.ZoneEnemyJungle, Deaths: 6, Share: 0.6},
+			{Zone: analysis.ZoneRiver, Deaths: 4, Share: 0.4},
+		},
+		SoloDeaths:        3,
+		OutnumberedDeaths: 6,
+		ExecutedDeaths:    1,
+		ShutdownsGiven:    2,
+		ShutdownGold:      700,
+	}
+
+	prompt := BuildInitialSystemPrompt(a)
+
+	for _, want := range []string{
+		"### Deaths (10 across 3 games with timeline)",
+		"Where: enemy jungle 60%, river 40%",
+		"How: 3 solo, 6 outnumbered, 1 to turrets/minions/monsters",
+		"Shutdowns given up: 2 (700 gold)",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+func TestBuildInitialSystemPromptVision(t *testing.T) {
+	a := makeTestAnalysis()
+	a.Vision = &analysis.VisionSummary{
+		GamesWithTimeline:            10,
+		AvgWardsLaning:               6.5,
+		AvgWardsMid:                  4,
+		AvgWardsLate:                 2.5,
+		AvgControlWards:              1.2,
+		AvgStealthWards:              10.8,
+		AvgBlueTrinketWards:          1,
+		AvgWardsKilled:               2.3,
+		WardClearRate:                0.15,
+		GamesWithoutEarlyControlWard: 7,
+	}
+
+	prompt := BuildInitialSystemPrompt(a)
+
+	for _, want := range []string{
+		"### Vision (10 games with timeline)",
+		"6.5 before 14 min, 4.0 from 14-25 min, 2.5 after 25 min",
+		"1.2 control, 10.8 stealth, 1.0 blue trinket",
+		"Wards cleared: 2.3 per game (15% of enemy wards)",
+		"No control ward before 14 min: 7/10 games",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+func TestBuildInitialSystemPromptBuilds(t *testing.T) {
+	a := makeTestAnalysis()
+	a.Builds = []analysis.ChampionBuildStats{
+		{ChampionName: "Ahri", GamesWithTimeline: 5, AvgFirstItemAt: 760, AvgSecondItemAt: 1180,
+			AvgFirstItemAtWins: 710, AvgFirstItemAtLosses: 870},
+		{ChampionName: "Zed", GamesWithTimeline: 1},
+	}
+
+	prompt := BuildInitialSystemPrompt(a)
+
+	want := "- Ahri (5 games): first item at 12:40 (11:50 in wins, 14:30 in losses), second item at 19:40"
+	if !strings.Contains(prompt, want) {
+		t.Errorf("prompt missing %q", want)
+	}
+	if strings.Contains(prompt, "Zed (1 games)") {
+		t.Error("champions without a completed item should be skipped")
+	}
+}
+
+func TestBuildInitialSystemPromptSkills(t *testing.T) {
+	a := makeTestAnalysis()
+	a.Skills = &analysis.SkillSummary{
+		GamesWithTimeline: 6,
+		GamesWithLateUlt:  2,
+		LateUltPoints:     3,
+		Orders: []analysis.SkillOrderStats{
+			{ChampionName: "Ahri", MaxOrder: "Q>W>E", GamesPlayed: 4, Wins: 3, WinRate: 0.75},
+		},
+	}
+
+	prompt := BuildInitialSystemPrompt(a)
+
+	for _, want := range []string{
+		"### Skill Orders (6 games with timeline)",
+		"- Ahri max Q>W>E: 4 games, 75% WR",
+		"- Late ultimate points: 3 across 2 games",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+func TestBuildInitialSystemPromptGameFlow(t *testing.T) {
+	a := makeTestAnalysis()
+	a.Curves = &analysis.CurveSummary{
+		GamesWithTimeline: 8,
+		GamesAhead:        5,
+		GamesBehind:       3,
+		Throws:            2,
+		Comebacks:         1,
+		AvgGoldShare:      0.23,
+		AvgMaxLead:        3400,
+		AvgMaxDeficit:     -2100,
+	}
+	a.Matches[0].Curve = &analysis.GameCurve{MaxLead: 4200, MaxLeadAt: 18, MaxDeficit: -500, Throw: true}
+
+	prompt := BuildInitialSystemPrompt(a)
+
+	for _, want := range []string{
+		"### Game Flow (8 games with timeline)",
+		"3k+ gold lead in 5 games and threw 2 of them",
+		"3k+ gold behind in 3 games and came back in 1",
+		"Average peak lead +3400, average worst deficit -2100, player gold share 23%",
+		", threw a +4.2k lead at 18 min [",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+func TestBuildInitialSystemPromptJungle(t *testing.T) {
+	a := makeTestAnalysis()
+	a.Jungle = &analysis.JungleSummary{
+		Games:                 5,
+		GamesWithTimeline:     4,
+		AvgCounterJungleShare: 0.12,
+		AvgScuttleCrabs:       1.5,
+		AvgFirstClearAt:       205,
+		AvgGanksBefore10:      2.5,
+		GankSuccessRate:       0.4,
+		GanksByLane: []analysis.GankStats{
+			{Lane: analysis.ZoneTopLane, Ganks: 4, Successful: 1},
+			{Lane: analysis.ZoneBotLane, Ganks: 6, Successful: 3},
+		},
+		ObjectiveSetupRate: 0.6,
+	}
+
+	prompt := BuildInitialSystemPrompt(a)
+
+	for _, want := range []string{
+		"### Jungle (5 games, 4 with timeline)",
+		"12% of camps from the enemy jungle, 1.5 scuttle crabs per game",
+		"First clear: 3:25 on average",
+		"Ganks before 10 min: 2.5 per game, 40% get a kill",
+		"Successful ganks by lane: top 1/4, bot 3/6",
+		"At the pit before 60% of epic monsters",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+func TestBuildInitialSystemPromptSupport(t *testing.T) {
+	a := makeTestAnalysis()
+	a.Support = &analysis.SupportSummary{
+		Games:                  4,
+		GamesWithTimeline:      3,
+		AvgHealShieldPerMinute: 310,
+		AvgCCPerMinute:         1.8,
+		AvgSavesFromDeath:      0.5,
+		AvgControlWards:        3.2,
+		AvgStealthWards:        14,
+		AvgWardsKilled:         4.5,
+		AvgFirstRoamAt:         425,
+		AvgRoamsBefore14:       1.3,
+		RoamSuccessRate:        0.5,
+	}
+
+	prompt := BuildInitialSystemPrompt(a)
+
+	for _, want := range []string{
+		"### Support (4 games, 3 with timeline)",
+		"Heals and shields on allies: 310 per minute, CC: 1.8 seconds per minute, saves from death: 0.5 per game",
+		"Wards per game: 3.2 control, 14.0 stealth, 4.5 cleared",
+		"Roams before 14 min: 1.3 per game, 50% get a kill, first roam at 7:05",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+func TestBuildInitialSystemPromptBenchmarks(t *testing.T) {
+	a := makeTestAnalysis()
+	a.Percentiles = []analysis.MetricPercentile{
+		{Metric: "cs_per_min", Label: "CS/min", Value: 5.1, Percentile: 22, Tier: "GOLD", Role: "JUNGLE", Samples: 340},
+		{Metric: "kda", Label: "KDA", Value: 3.4, Percentile: 81, Tier: "GOLD", Role: "JUNGLE", Samples: 340},
+	}
+
+	prompt := BuildInitialSystemPrompt(a)
+
+	for _, want := range []string{
+		"### Rank Benchmarks (GOLD JUNGLE, percentile of same-role players)",
+		"- CS/min: 5.10 -- percentile 22 of 340 games",
+		"- KDA: 3.40 -- percentile 81 of 340 games",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+func TestBuildInitialSystemPromptSessions(t *testing.T) {
+	a := makeTestAnalysis()
+	a.Sessions = &analysis.SessionSummary{
+		Sessions:           3,
+		AvgGamesPerSession: 2.7,
+		LongestSession:     5,
+		WinRate:            0.5,
+		ByGameIndex: []analysis.SessionStats{
+			{Label: "Game 1", Games: 3, WinRate: 0.67, AvgKDA: 3.2, AvgCSPerMinute: 7.1},
+		},
+		AfterLossStreak: &analysis.SessionStats{Label: "After 2+ losses", Games: 4, WinRate: 0.25, AvgKDA: 1.8, AvgCSPerMinute: 6.2},
+		ByTimeOfDay:     []analysis.SessionStats{{Label: "evening (18-24h)", Games: 8, WinRate: 0.5, AvgKDA: 2.5, AvgCSPerMinute: 6.8}},
+	}
+
+	prompt := BuildInitialSystemPrompt(a)
+
+	for _, want := range []string{
+		"### Play Sessions (3 sessions, 2.7 games on average, longest 5)",
+		"  - Game 1: 3 games, 67% WR, 3.2 KDA, 7.1 CS/min",
+		"- After a losing streak:\n  - After 2+ losses: 4 games, 25% WR, 1.8 KDA, 6.2 CS/min",
+		"- By time of day:\n  - evening (18-24h): 8 games",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+func makeTestARAMAnalysis() *analysis.PlayerAnalysis {
+	a := makeTestAnalysis()
+	a.Queue = "aram"
+	a.Map = "HOWLING_ABYSS"
+	a.RoleBreakdown = nil
+	a.ARAM = &analysis.ARAMSummary{
+		Games:               5,
+		AvgDamageShare:      0.28,
+		AvgDamageTakenShare: 0.18,
+		AvgDeathsPerMinute:  0.35,
+		AvgTimeDeadShare:    0.22,
+		SnowballGames:       4,
+		AvgSnowballCasts:    5.5,
+		SnowballHitRate:     0.45,
+	}
+	return a
+}
+
+func TestBuildARAMSystemPrompt(t *testing.T) {
+	prompt := BuildARAMSystemPrompt(makeTestARAMAnalysis(), nil, "")
+
+	for _, want := range []string{
+		"specializing in ARAM",
+		"- Queue: ARAM",
+		"### Teamfighting (5 games)",
+		"- Damage Share: 28%",
+		"- Deaths/min: 0.35 (22% of the game spent dead)",
+		"- Snowball: 5.5 casts per game, 45% hit (4 games with Mark)",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("ARAM prompt missing %q", want)
+		}
+	}
+	for _, unwanted := range []string{"Vision Score", "CS/min", "Objective Participation", "Role Breakdown", "Previous Session"} {
+		if strings.Contains(prompt, unwanted) {
+			t.Errorf("ARAM prompt should not contain %q", unwanted)
+		}
+	}
+}
+
+func TestBuildARAMSystemPromptFollowUp(t *testing.T) {
+	previous := makeTestARAMAnalysis()
+	previous.ARAM.AvgDeathsPerMinute = 0.5
+
+	prompt := BuildARAMSystemPrompt(makeTestARAMAnalysis(), previous, "Stop diving towers.")
+	for _, want := range []string{"follow-up session", "- Deaths/min: 0.50 -> 0.35 (improved)", "Stop diving towers."} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("ARAM follow-up prompt missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package coaching
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HatiCode/league-buddy/internal/analysis"
+)
+
+// GamePlan asks the LLM for a plan for a game in progress. Game plans are not
+// saved as coaching sessions.
+func (s *Service) GamePlan(ctx context.Context, report *analysis.ScoutingReport) (string, error) {
+	plan, err := s.llm.Complete(ctx, BuildGamePlanSystemPrompt(report), BuildGamePlanUserPrompt())
+	if err != nil {
+		return "", fmt.Errorf("llm complete: %w", err)
+	}
+	return plan, nil
+}
+
+func BuildGamePlanSystemPrompt(r *analysis.ScoutingReport) string {
+	var b strings.Builder
+
+	b.WriteString("You are an expert League of Legends coach. The player is about to play the game below. Use the scouting report to give a short, concrete game plan.\n\n")
+
+	fmt.Fprintf(&b, "## Game\n- Queue %d (%s), %s in\n\n", r.QueueID, r.GameMode, formatGameTime(float64(r.GameLength)))
+	writeScoutTeam(&b, "Player's Team (player listed first)", r.Allies)
+	writeScoutTeam(&b, "Enemy Team", r.Enemies)
+	if o := r.LaneOpponent; o != nil {
+		fmt.Fprintf(&b, "## Lane Opponent\n- %s on %s\n\n", scoutName(*o), o.ChampionName)
+	}
+
+	return b.String()
+}
+
+func BuildGamePlanUserPrompt() string {
+	return "Give me a game plan for this game: how to play my lane against my opponent, which enemies to focus or avoid, and what win condition my team should play for. Keep it under 200 words."
+}
+
+func writeScoutTeam(b *strings.Builder, label string, scouts []analysis.PlayerScout) {
+	fmt.Fprintf(b, "## %s\n", label)
+	for _, s := range scouts {
+		fmt.Fprintf(b, "- %s: %s", scoutName(s), s.ChampionName)
+		if s.MainRole != "" {
+			fmt.Fprintf(b, ", usually %s", s.MainRole)
+		}
+		if s.Tier != "" {
+			fmt.Fprintf(b, ", %s %s %d LP (%dW %dL)", s.Tier, s.Rank, s.LeaguePoints, s.Wins, s.Losses)
+		}
+		if s.ChampionGames > 0 {
+			fmt.Fprintf(b, ", %d recorded games on the champion at %.0f%% WR and %.1f KDA",
+				s.ChampionGames, s.ChampionWinRate*100, s.AvgKDA)
+		}
+		b.WriteString("\n")
+		for _, t := range s.Tendencies {
+			fmt.Fprintf(b, "  - %s\n", t)
+		}
+	}
+	b.WriteString("\n")
+}
+
+func scoutName(s analysis.PlayerScout) string {
+	if s.RiotID != "" {
+		return s.RiotID
+	}
+	return "Unknown player"
+}
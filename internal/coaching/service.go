@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/HatiCode/league-buddy/internal/analysis"
+	"github.com/HatiCode/league-buddy/internal/models"
 	"github.com/HatiCode/league-buddy/internal/store"
 )
 
@@ -18,6 +19,15 @@ type TrendPoint struct {
 	Tier        string                  `json:"tier,omitempty"`
 	Rank        string                  `json:"rank,omitempty"`
 	Averages    analysis.AverageMetrics `json:"averages"`
+	GameFlow    *analysis.CurveSummary  `json:"gameFlow,omitempty"`
+}
+
+// GameFlow is one match's gold curve from the most recent session.
+type GameFlow struct {
+	MatchID      string              `json:"matchId"`
+	ChampionName string              `json:"championName"`
+	Win          bool                `json:"win"`
+	Curve        *analysis.GameCurve `json:"curve"`
 }
 
 // PlayerProgress holds the full trend data across all coaching sessions.
@@ -25,8 +35,12 @@ type PlayerProgress struct {
 	PUUID    string       `json:"puuid"`
 	GameName string       `json:"gameName"`
 	TagLine  string       `json:"tagLine"`
+	Queue    string       `json:"queue"`
 	Sessions int          `json:"sessions"`
 	Trend    []TrendPoint `json:"trend"`
+
+	// RecentGames holds the gold curves of the latest session's matches that had a timeline.
+	RecentGames []GameFlow `json:"recentGames,omitempty"`
 }
 
 // CoachingResponse holds the result of a coaching session.
@@ -56,7 +70,7 @@ func (s *Service) Coach(ctx context.Context, playerAnalysis *analysis.PlayerAnal
 	var previousSession *store.CoachingSession
 	if s.store != nil {
 		var err error
-		previousSession, err = s.store.GetLatestCoachingSession(ctx, playerAnalysis.PUUID)
+		previousSession, err = s.store.GetLatestCoachingSession(ctx, playerAnalysis.PUUID, sessionQueue(playerAnalysis))
 		if err != nil {
 			return nil, fmt.Errorf("get previous session: %w", err)
 		}
@@ -68,6 +82,9 @@ func (s *Service) Coach(ctx context.Context, playerAnalysis *analysis.PlayerAnal
 	}
 
 	user := BuildUserPrompt(isFollowUp)
+	if isARAM(playerAnalysis) {
+		user = BuildARAMUserPrompt(isFollowUp)
+	}
 
 	advice, err := s.llm.Complete(ctx, system, user)
 	if err != nil {
@@ -89,6 +106,9 @@ func (s *Service) Coach(ctx context.Context, playerAnalysis *analysis.PlayerAnal
 
 func (s *Service) buildPrompts(current *analysis.PlayerAnalysis, previous *store.CoachingSession) (string, bool, error) {
 	if previous == nil {
+		if isARAM(current) {
+			return BuildARAMSystemPrompt(current, nil, ""), false, nil
+		}
 		return BuildInitialSystemPrompt(current), false, nil
 	}
 
@@ -97,6 +117,10 @@ func (s *Service) buildPrompts(current *analysis.PlayerAnalysis, previous *store
 		return "", false, fmt.Errorf("unmarshal previous analysis: %w", err)
 	}
 
+	if isARAM(current) {
+		return BuildARAMSystemPrompt(current, &previousAnalysis, previous.Advice), true, nil
+	}
+
 	system := BuildFollowUpSystemPrompt(current, &previousAnalysis, previous.Advice)
 	return system, true, nil
 }
@@ -119,6 +143,7 @@ func (s *Service) saveSession(ctx context.Context, playerAnalysis *analysis.Play
 
 	session := &store.CoachingSession{
 		PUUID:         playerAnalysis.PUUID,
+		Queue:         sessionQueue(playerAnalysis),
 		LatestMatchID: latestMatchID,
 		MatchIDs:      matchIDsJSON,
 		Analysis:      analysisJSON,
@@ -128,19 +153,29 @@ func (s *Service) saveSession(ctx context.Context, playerAnalysis *analysis.Play
 	return s.store.SaveCoachingSession(ctx, session)
 }
 
-// GetProgress loads all coaching sessions for a player and returns trend data.
-func (s *Service) GetProgress(ctx context.Context, puuid string) (*PlayerProgress, error) {
+// sessionQueue is the queue group a session is stored under. Analyses without
+// one predate queue selection, which only covered Ranked Solo/Duo.
+func sessionQueue(a *analysis.PlayerAnalysis) string {
+	if a.Queue == "" {
+		return models.QueueGroupSolo
+	}
+	return a.Queue
+}
+
+// GetProgress loads a player's coaching sessions for a queue group and returns trend data.
+func (s *Service) GetProgress(ctx context.Context, puuid, queue string) (*PlayerProgress, error) {
 	if s.store == nil {
 		return nil, fmt.Errorf("database is required for progress tracking")
 	}
 
-	sessions, err := s.store.GetCoachingSessions(ctx, puuid)
+	sessions, err := s.store.GetCoachingSessions(ctx, puuid, queue)
 	if err != nil {
 		return nil, fmt.Errorf("get coaching sessions: %w", err)
 	}
 
 	progress := &PlayerProgress{
 		PUUID:    puuid,
+		Queue:    queue,
 		Sessions: len(sessions),
 		Trend:    make([]TrendPoint, 0, len(sessions)),
 	}
@@ -166,7 +201,20 @@ func (s *Service) GetProgress(ctx context.Context, puuid string) (*PlayerProgres
 			Tier:        pa.Tier,
 			Rank:        pa.Rank,
 			Averages:    pa.Averages,
+			GameFlow:    pa.Curves,
 		})
+
+		progress.RecentGames = progress.RecentGames[:0]
+		for _, m := range pa.Matches {
+			if m.Curve != nil {
+				progress.RecentGames = append(progress.RecentGames, GameFlow{
+					MatchID:      m.Metrics.MatchID,
+					ChampionName: m.Metrics.ChampionName,
+					Win:          m.Metrics.Win,
+					Curve:        m.Curve,
+				})
+			}
+		}
 	}
 
 	return progress, nil
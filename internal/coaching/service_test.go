@@ -35,16 +35,19 @@ type mockSessionStore struct {
 	getErr        error
 	sessionsErr   error
 	saveErr       error
+	queue         string
 }
 
-func (m *mockSessionStore) GetLatestCoachingSession(_ context.Context, _ string) (*store.CoachingSession, error) {
+func (m *mockSessionStore) GetLatestCoachingSession(_ context.Context, _, queue string) (*store.CoachingSession, error) {
+	m.queue = queue
 	if m.getErr != nil {
 		return nil, m.getErr
 	}
 	return m.latestSession, nil
 }
 
-func (m *mockSessionStore) GetCoachingSessions(_ context.Context, _ string) ([]store.CoachingSession, error) {
+func (m *mockSessionStore) GetCoachingSessions(_ context.Context, _, queue string) ([]store.CoachingSession, error) {
+	m.queue = queue
 	if m.sessionsErr != nil {
 		return nil, m.sessionsErr
 	}
@@ -103,6 +106,47 @@ func TestCoachInitialSession(t *testing.T) {
 	}
 }
 
+func TestCoachStoresSessionsPerQueue(t *testing.T) {
+	st := &mockSessionStore{}
+	svc := NewService(&mockLLM{response: "Flex advice."}, st)
+
+	a := makeTestAnalysis()
+	a.Queue = "flex"
+	if _, err := svc.Coach(context.Background(), a, []string{"EUW1_001"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if st.queue != "flex" {
+		t.Errorf("previous session looked up in queue %q, want flex", st.queue)
+	}
+	if st.savedSession == nil || st.savedSession.Queue != "flex" {
+		t.Errorf("expected the session saved under flex, got %+v", st.savedSession)
+	}
+
+	// Analyses without a queue are solo queue, as before queue selection.
+	a.Queue = ""
+	if _, err := svc.Coach(context.Background(), a, []string{"EUW1_002"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if st.savedSession.Queue != "solo" {
+		t.Errorf("saved queue = %q, want solo", st.savedSession.Queue)
+	}
+}
+
+func TestCoachARAMUsesARAMPrompts(t *testing.T) {
+	llm := &mockLLM{response: "ARAM advice."}
+	svc := NewService(llm, nil)
+
+	if _, err := svc.Coach(context.Background(), makeTestARAMAnalysis(), []string{"EUW1_001"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(llm.system, "specializing in ARAM") {
+		t.Error("expected the ARAM system prompt")
+	}
+	if !strings.Contains(llm.user, "ARAM games") {
+		t.Errorf("expected the ARAM user prompt, got %q", llm.user)
+	}
+}
+
 func TestCoachFollowUpSession(t *testing.T) {
 	previousAnalysis := makeTestAnalysis()
 	previousAnalysis.Averages.KDA = 2.5
@@ -293,6 +337,12 @@ func TestGetProgressMultipleSessions(t *testing.T) {
 	session2Analysis.WinRate = 0.60
 	session2Analysis.Tier = "GOLD"
 	session2Analysis.Rank = "II"
+	session2Analysis.Curves = &analysis.CurveSummary{GamesWithTimeline: 1, GamesAhead: 1, Throws: 1}
+	session2Analysis.Matches[0].Curve = &analysis.GameCurve{
+		Points:  []analysis.CurvePoint{{Minute: 0}, {Minute: 1, TeamGoldDiff: 3500}},
+		MaxLead: 3500,
+		Throw:   true,
+	}
 
 	now := time.Now()
 	st := &mockSessionStore{
@@ -303,7 +353,7 @@ func TestGetProgressMultipleSessions(t *testing.T) {
 	}
 	svc := NewService(nil, st)
 
-	progress, err := svc.GetProgress(context.Background(), "test-puuid")
+	progress, err := svc.GetProgress(context.Background(), "test-puuid", "solo")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -337,13 +387,20 @@ func TestGetProgressMultipleSessions(t *testing.T) {
 	if progress.Trend[1].Averages.KDA != 3.5 {
 		t.Errorf("trend[1].averages.kda = %f, want 3.5", progress.Trend[1].Averages.KDA)
 	}
+
+	if progress.Trend[0].GameFlow != nil || progress.Trend[1].GameFlow == nil || progress.Trend[1].GameFlow.Throws != 1 {
+		t.Errorf("unexpected game flow: %+v, %+v", progress.Trend[0].GameFlow, progress.Trend[1].GameFlow)
+	}
+	if len(progress.RecentGames) != 1 || !progress.RecentGames[0].Curve.Throw {
+		t.Errorf("expected the latest session's curve in recent games, got %+v", progress.RecentGames)
+	}
 }
 
 func TestGetProgressNoSessions(t *testing.T) {
 	st := &mockSessionStore{}
 	svc := NewService(nil, st)
 
-	progress, err := svc.GetProgress(context.Background(), "test-puuid")
+	progress, err := svc.GetProgress(context.Background(), "test-puuid", "solo")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -359,7 +416,7 @@ func TestGetProgressNoSessions(t *testing.T) {
 func TestGetProgressNilStore(t *testing.T) {
 	svc := NewService(nil, nil)
 
-	_, err := svc.GetProgress(context.Background(), "test-puuid")
+	_, err := svc.GetProgress(context.Background(), "test-puuid", "solo")
 	if err == nil {
 		t.Fatal("expected error for nil store")
 	}
@@ -369,7 +426,7 @@ func TestGetProgressStoreError(t *testing.T) {
 	st := &mockSessionStore{sessionsErr: errors.New("connection lost")}
 	svc := NewService(nil, st)
 
-	_, err := svc.GetProgress(context.Background(), "test-puuid")
+	_, err := svc.GetProgress(context.Background(), "test-puuid", "solo")
 	if err == nil {
 		t.Fatal("expected error from store")
 	}
@@ -377,3 +434,52 @@ func TestGetProgressStoreError(t *testing.T) {
 		t.Errorf("error = %q, want to contain 'connection lost'", err.Error())
 	}
 }
+
+func TestGamePlan(t *testing.T) {
+	llm := &mockLLM{response: "Play for the early dragon."}
+	st := &mockSessionStore{}
+	svc := NewService(llm, st)
+
+	opponent := analysis.PlayerScout{
+		RiotID: "Enemy#EUW", ChampionName: "Yasuo", MainRole: "MIDDLE",
+		Tier: "GOLD", Rank: "II", LeaguePoints: 40, Wins: 30, Losses: 25,
+		ChampionGames: 3, ChampionWinRate: 0.33, AvgKDA: 1.8,
+		Tendencies: []string{"Dies often (0.31 per minute) -- punish overextensions"},
+	}
+	report := &analysis.ScoutingReport{
+		QueueID:      420,
+		GameMode:     "CLASSIC",
+		GameLength:   95,
+		Allies:       []analysis.PlayerScout{{RiotID: "Me#EUW", ChampionName: "Ahri", MainRole: "MIDDLE"}},
+		Enemies:      []analysis.PlayerScout{opponent},
+		LaneOpponent: &opponent,
+	}
+
+	plan, err := svc.GamePlan(context.Background(), report)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plan != "Play for the early dragon." {
+		t.Errorf("plan = %q", plan)
+	}
+	if st.savedSession != nil {
+		t.Error("game plans should not be saved as coaching sessions")
+	}
+
+	for _, want := range []string{
+		"- Queue 420 (CLASSIC), 1:35 in",
+		"- Me#EUW: Ahri, usually MIDDLE\n",
+		"- Enemy#EUW: Yasuo, usually MIDDLE, GOLD II 40 LP (30W 25L), 3 recorded games on the champion at 33% WR and 1.8 KDA",
+		"  - Dies often (0.31 per minute) -- punish overextensions",
+		"## Lane Opponent\n- Enemy#EUW on Yasuo",
+	} {
+		if !strings.Contains(llm.system, want) {
+			t.Errorf("system prompt missing %q", want)
+		}
+	}
+
+	llm.err = errors.New("boom")
+	if _, err := svc.GamePlan(context.Background(), report); err == nil {
+		t.Error("expected the LLM error to be returned")
+	}
+}
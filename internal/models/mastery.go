@@ -0,0 +1,16 @@
+package models
+
+// ChampionMastery is a player's mastery of one champion.
+type ChampionMastery struct {
+	PUUID                        string   `json:"puuid"`
+	ChampionID                   int      `json:"championId"`
+	ChampionLevel                int      `json:"championLevel"`
+	ChampionPoints               int      `json:"championPoints"`
+	ChampionPointsSinceLastLevel int64    `json:"championPointsSinceLastLevel"`
+	ChampionPointsUntilNextLevel int64    `json:"championPointsUntilNextLevel"`
+	LastPlayTime                 int64    `json:"lastPlayTime"`
+	TokensEarned                 int      `json:"tokensEarned"`
+	ChampionSeasonMilestone      int      `json:"championSeasonMilestone"`
+	MarkRequiredForNextLevel     int      `json:"markRequiredForNextLevel"`
+	MilestoneGrades              []string `json:"milestoneGrades,omitempty"`
+}
@@ -87,6 +87,13 @@ type Participant struct {
 	TimePlayed                     int         `json:"timePlayed"`
 	TotalTimeSpentDead             int         `json:"totalTimeSpentDead"`
 	LongestTimeSpentLiving         int         `json:"longestTimeSpentLiving"`
+	Item0                          int         `json:"item0"`
+	Item1                          int         `json:"item1"`
+	Item2                          int         `json:"item2"`
+	Item3                          int         `json:"item3"`
+	Item4                          int         `json:"item4"`
+	Item5                          int         `json:"item5"`
+	Item6                          int         `json:"item6"` // trinket slot
 	Summoner1Id                    int         `json:"summoner1Id"`
 	Summoner2Id                    int         `json:"summoner2Id"`
 	Summoner1Casts                 int         `json:"summoner1Casts"`
@@ -134,6 +141,7 @@ type Challenges struct {
 	RiftHeraldTakedowns              int     `json:"riftHeraldTakedowns"`
 	ScuttleCrabKills                 int     `json:"scuttleCrabKills"`
 	SaveAllyFromDeath                int     `json:"saveAllyFromDeath"`
+	SnowballsHit                     int     `json:"snowballsHit"`
 }
 
 // Team represents one side's performance and objectives.
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Map types a queue is played on.
+const (
+	MapSummonersRift = "SUMMONERS_RIFT"
+	MapHowlingAbyss  = "HOWLING_ABYSS"
+)
+
+// Map IDs reported in MatchInfo.MapID.
+const (
+	MapIDSummonersRift = 11
+	MapIDHowlingAbyss  = 12
+)
+
+// Match API queue IDs.
+const (
+	QueueIDNormalDraft = 400
+	QueueIDRankedSolo  = 420
+	QueueIDNormalBlind = 430
+	QueueIDRankedFlex  = 440
+	QueueIDARAM        = 450
+	QueueIDQuickplay   = 490
+)
+
+// Queue describes a match API queue.
+type Queue struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Map  string `json:"map"`
+	// LeagueQueue is the league API queue type for ranked queues, empty otherwise.
+	LeagueQueue string `json:"leagueQueue,omitempty"`
+}
+
+var queues = map[int]Queue{
+	QueueIDNormalDraft: {ID: QueueIDNormalDraft, Name: "Normal Draft", Map: MapSummonersRift},
+	QueueIDRankedSolo:  {ID: QueueIDRankedSolo, Name: "Ranked Solo/Duo", Map: MapSummonersRift, LeagueQueue: QueueRankedSolo},
+	QueueIDNormalBlind: {ID: QueueIDNormalBlind, Name: "Normal Blind", Map: MapSummonersRift},
+	QueueIDRankedFlex:  {ID: QueueIDRankedFlex, Name: "Ranked Flex", Map: MapSummonersRift, LeagueQueue: QueueRankedFlex},
+	QueueIDARAM:        {ID: QueueIDARAM, Name: "ARAM", Map: MapHowlingAbyss},
+	QueueIDQuickplay:   {ID: QueueIDQuickplay, Name: "Quickplay", Map: MapSummonersRift},
+}
+
+// QueueByID looks up a queue in the catalogue.
+func QueueByID(id int) (Queue, bool) {
+	q, ok := queues[id]
+	return q, ok
+}
+
+// RankedQueueIDs returns the catalogue queues with a league standing, in ID order.
+func RankedQueueIDs() []int {
+	var ids []int
+	for _, id := range slices.Sorted(maps.Keys(queues)) {
+		if queues[id].LeagueQueue != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// MapType returns the map the match was played on, from its queue or else its
+// map ID. It is empty for queues and maps outside the catalogue.
+func (i MatchInfo) MapType() string {
+	if q, ok := queues[i.QueueID]; ok {
+		return q.Map
+	}
+	switch i.MapID {
+	case MapIDSummonersRift:
+		return MapSummonersRift
+	case MapIDHowlingAbyss:
+		return MapHowlingAbyss
+	}
+	return ""
+}
+
+// QueueGroup is a named set of queues, as selected by the --queue option.
+type QueueGroup struct {
+	Name     string
+	Label    string
+	QueueIDs []int
+	// LeagueQueue is the ranked queue whose standing describes the player.
+	// Unranked groups use the solo queue rank.
+	LeagueQueue string
+}
+
+// Queue group names.
+const (
+	QueueGroupSolo   = "solo"
+	QueueGroupFlex   = "flex"
+	QueueGroupNormal = "normal"
+	QueueGroupARAM   = "aram"
+	QueueGroupAll    = "all"
+)
+
+var queueGroups = []QueueGroup{
+	{Name: QueueGroupSolo, Label: "Ranked Solo/Duo", QueueIDs: []int{QueueIDRankedSolo}, LeagueQueue: QueueRankedSolo},
+	{Name: QueueGroupFlex, Label: "Ranked Flex", QueueIDs: []int{QueueIDRankedFlex}, LeagueQueue: QueueRankedFlex},
+	{Name: QueueGroupNormal, Label: "Normal games", QueueIDs: []int{QueueIDNormalDraft, QueueIDNormalBlind, QueueIDQuickplay}, LeagueQueue: QueueRankedSolo},
+	{Name: QueueGroupARAM, Label: "ARAM", QueueIDs: []int{QueueIDARAM}, LeagueQueue: QueueRankedSolo},
+	{Name: QueueGroupAll, Label: "Summoner's Rift", QueueIDs: queueIDsOnMap(MapSummonersRift), LeagueQueue: QueueRankedSolo},
+}
+
+// Ranked reports whether every queue in the group is a ranked queue, so the
+// player can be compared against ranked players of their tier.
+func (g QueueGroup) Ranked() bool {
+	for _, id := range g.QueueIDs {
+		if queues[id].LeagueQueue == "" {
+			return false
+		}
+	}
+	return len(g.QueueIDs) > 0
+}
+
+// queueIDsOnMap returns the catalogue queues played on mapType, in ID order.
+// The "all" group is limited to Summoner's Rift because ARAM metrics can't be
+// aggregated with it, and off-catalogue modes such as Arena can't be analyzed at all.
+func queueIDsOnMap(mapType string) []int {
+	var ids []int
+	for _, id := range slices.Sorted(maps.Keys(queues)) {
+		if queues[id].Map == mapType {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// QueueGroupByName looks up a queue group by name, case-insensitively.
+func QueueGroupByName(name string) (QueueGroup, error) {
+	for _, g := range queueGroups {
+		if strings.EqualFold(g.Name, name) {
+			return g, nil
+		}
+	}
+	return QueueGroup{}, fmt.Errorf("unknown queue %q (use solo, flex, normal, aram or all)", name)
+}
@@ -0,0 +1,42 @@
+package models
+
+// CurrentGameInfo is a game in progress, as reported by spectator-v5.
+type CurrentGameInfo struct {
+	GameID            int64                    `json:"gameId"`
+	GameType          string                   `json:"gameType"`
+	GameMode          string                   `json:"gameMode"`
+	GameStartTime     int64                    `json:"gameStartTime"`
+	GameLength        int64                    `json:"gameLength"`
+	MapID             int                      `json:"mapId"`
+	PlatformID        string                   `json:"platformId"`
+	GameQueueConfigID int                      `json:"gameQueueConfigId"`
+	BannedChampions   []BannedChampion         `json:"bannedChampions"`
+	Participants      []CurrentGameParticipant `json:"participants"`
+}
+
+// CurrentGameParticipant is one player in a game in progress.
+type CurrentGameParticipant struct {
+	PUUID         string `json:"puuid"`
+	RiotID        string `json:"riotId"`
+	ChampionID    int    `json:"championId"`
+	TeamID        int    `json:"teamId"`
+	Spell1ID      int    `json:"spell1Id"`
+	Spell2ID      int    `json:"spell2Id"`
+	ProfileIconID int    `json:"profileIconId"`
+	Bot           bool   `json:"bot"`
+	Perks         *Perks `json:"perks,omitempty"`
+}
+
+// Perks are the runes a player took into a game in progress.
+type Perks struct {
+	PerkIDs      []int `json:"perkIds"`
+	PerkStyle    int   `json:"perkStyle"`
+	PerkSubStyle int   `json:"perkSubStyle"`
+}
+
+// BannedChampion is a ban in a game in progress.
+type BannedChampion struct {
+	ChampionID int `json:"championId"`
+	TeamID     int `json:"teamId"`
+	PickTurn   int `json:"pickTurn"`
+}
@@ -33,11 +33,36 @@ type MiniSeries struct {
 	Wins     int    `json:"wins"`
 }
 
+// LeagueList is a whole league, such as the Challenger ladder of a queue.
+type LeagueList struct {
+	LeagueID string       `json:"leagueId"`
+	Tier     string       `json:"tier"`
+	Name     string       `json:"name"`
+	Queue    string       `json:"queue"`
+	Entries  []LeagueItem `json:"entries"`
+}
+
+// LeagueItem is a player's standing within a LeagueList.
+type LeagueItem struct {
+	PUUID        string      `json:"puuid"`
+	Rank         string      `json:"rank"`
+	LeaguePoints int         `json:"leaguePoints"`
+	Wins         int         `json:"wins"`
+	Losses       int         `json:"losses"`
+	HotStreak    bool        `json:"hotStreak"`
+	Veteran      bool        `json:"veteran"`
+	FreshBlood   bool        `json:"freshBlood"`
+	Inactive     bool        `json:"inactive"`
+	MiniSeries   *MiniSeries `json:"miniSeries,omitempty"`
+}
+
 // QueueType constants for ranked queues (league API).
 const (
 	QueueRankedSolo = "RANKED_SOLO_5x5"
 	QueueRankedFlex = "RANKED_FLEX_SR"
 )
 
-// QueueIDRankedSolo is the match API queue ID for Ranked Solo/Duo.
-const QueueIDRankedSolo = 420
+// Tiers lists the ranked tiers from lowest to highest.
+var Tiers = []string{
+	"IRON", "BRONZE", "SILVER", "GOLD", "PLATINUM", "EMERALD", "DIAMOND", "MASTER", "GRANDMASTER", "CHALLENGER",
+}
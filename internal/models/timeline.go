@@ -48,6 +48,8 @@ type TimelineEvent struct {
 	AssistingParticipantIDs []int     `json:"assistingParticipantIds,omitempty"`
 	Position                *Position `json:"position,omitempty"`
 	ItemID                  int       `json:"itemId,omitempty"`
+	BeforeID                int       `json:"beforeId,omitempty"` // ITEM_UNDO: item before the undo
+	AfterID                 int       `json:"afterId,omitempty"`  // ITEM_UNDO: item after the undo
 	SkillSlot               int       `json:"skillSlot,omitempty"`
 	LevelUpType             string    `json:"levelUpType,omitempty"`
 	WardType                string    `json:"wardType,omitempty"`
@@ -60,6 +62,7 @@ type TimelineEvent struct {
 	MonsterSubType          string    `json:"monsterSubType,omitempty"`
 	KillerTeamID            int       `json:"killerTeamId,omitempty"`
 	Bounty                  int       `json:"bounty,omitempty"`
+	ShutdownBounty          int       `json:"shutdownBounty,omitempty"`
 	KillStreakLength        int       `json:"killStreakLength,omitempty"`
 }
 
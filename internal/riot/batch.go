@@ -0,0 +1,74 @@
+package riot
+
+import (
+	"context"
+	"sync"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// DefaultFetchConcurrency is the default number of matches fetched in parallel.
+const DefaultFetchConcurrency = 4
+
+// MatchResult holds the outcome of fetching one match and its timeline.
+// Err is set when the match itself could not be fetched; a missing timeline
+// only sets TimelineErr since analysis can proceed without it.
+type MatchResult struct {
+	MatchID     string
+	Match       *models.Match
+	Timeline    *models.Timeline
+	Err         error
+	TimelineErr error
+}
+
+// FetchMatchesWithTimelines fetches matches and their timelines with a bounded worker pool.
+// Results are returned in the same order as ids, so most-recent-first input stays
+// most-recent-first. Per-match failures are reported in the results and never abort
+// the batch; rate limiting is left to the fetcher's transport.
+func FetchMatchesWithTimelines(ctx context.Context, fetcher MatchFetcher, platform string, ids []string, concurrency int) []MatchResult {
+	if concurrency < 1 {
+		concurrency = DefaultFetchConcurrency
+	}
+
+	results := make([]MatchResult, len(ids))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(ids); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = fetchMatchWithTimeline(ctx, fetcher, platform, ids[i])
+			}
+		}()
+	}
+
+	for i := range ids {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func fetchMatchWithTimeline(ctx context.Context, fetcher MatchFetcher, platform, matchID string) MatchResult {
+	result := MatchResult{MatchID: matchID}
+
+	match, err := fetcher.GetMatch(ctx, platform, matchID)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Match = match
+
+	timeline, err := fetcher.GetMatchTimeline(ctx, platform, matchID)
+	if err != nil {
+		result.TimelineErr = err
+		return result
+	}
+	result.Timeline = timeline
+
+	return result
+}
@@ -0,0 +1,100 @@
+package riot_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+	"github.com/HatiCode/league-buddy/internal/riot"
+)
+
+type fakeMatchFetcher struct {
+	failMatch    map[string]bool
+	failTimeline map[string]bool
+
+	mu          sync.Mutex
+	inFlight    int32
+	maxInFlight int32
+}
+
+func (f *fakeMatchFetcher) GetMatchIDs(_ context.Context, _, _ string, _ riot.MatchIDsOptions) ([]string, error) {
+	return nil, nil
+}
+
+func (f *fakeMatchFetcher) GetMatch(_ context.Context, _, matchID string) (*models.Match, error) {
+	n := atomic.AddInt32(&f.inFlight, 1)
+	defer atomic.AddInt32(&f.inFlight, -1)
+
+	f.mu.Lock()
+	if n > f.maxInFlight {
+		f.maxInFlight = n
+	}
+	f.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	if f.failMatch[matchID] {
+		return nil, riot.ErrNotFound
+	}
+	return &models.Match{Metadata: models.MatchMetadata{MatchID: matchID}}, nil
+}
+
+func (f *fakeMatchFetcher) GetMatchTimeline(_ context.Context, _, matchID string) (*models.Timeline, error) {
+	if f.failTimeline[matchID] {
+		return nil, riot.ErrRateLimited
+	}
+	return &models.Timeline{Metadata: models.TimelineMetadata{MatchID: matchID}}, nil
+}
+
+func TestFetchMatchesWithTimelines_PreservesOrder(t *testing.T) {
+	fetcher := &fakeMatchFetcher{}
+	ids := []string{"EUW1_5", "EUW1_4", "EUW1_3", "EUW1_2", "EUW1_1"}
+
+	results := riot.FetchMatchesWithTimelines(context.Background(), fetcher, riot.PlatformEUW1, ids, 3)
+
+	if len(results) != len(ids) {
+		t.Fatalf("expected %d results, got %d", len(ids), len(results))
+	}
+	for i, r := range results {
+		if r.MatchID != ids[i] {
+			t.Errorf("result %d: expected match ID %s, got %s", i, ids[i], r.MatchID)
+		}
+		if r.Match == nil || r.Match.Metadata.MatchID != ids[i] {
+			t.Errorf("result %d: match does not belong to %s", i, ids[i])
+		}
+		if r.Timeline == nil {
+			t.Errorf("result %d: expected timeline", i)
+		}
+	}
+
+	if fetcher.maxInFlight > 3 {
+		t.Errorf("expected at most 3 concurrent fetches, got %d", fetcher.maxInFlight)
+	}
+}
+
+func TestFetchMatchesWithTimelines_ReportsFailures(t *testing.T) {
+	fetcher := &fakeMatchFetcher{
+		failMatch:    map[string]bool{"EUW1_2": true},
+		failTimeline: map[string]bool{"EUW1_3": true},
+	}
+	ids := []string{"EUW1_3", "EUW1_2", "EUW1_1"}
+
+	results := riot.FetchMatchesWithTimelines(context.Background(), fetcher, riot.PlatformEUW1, ids, 2)
+
+	if results[0].Err != nil || !errors.Is(results[0].TimelineErr, riot.ErrRateLimited) {
+		t.Errorf("EUW1_3: expected timeline error only, got err=%v timelineErr=%v", results[0].Err, results[0].TimelineErr)
+	}
+	if results[0].Match == nil {
+		t.Error("EUW1_3: match should still be returned without its timeline")
+	}
+	if !errors.Is(results[1].Err, riot.ErrNotFound) {
+		t.Errorf("EUW1_2: expected ErrNotFound, got %v", results[1].Err)
+	}
+	if results[2].Err != nil || results[2].Timeline == nil {
+		t.Errorf("EUW1_1: expected full success, got err=%v", results[2].Err)
+	}
+}
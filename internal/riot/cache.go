@@ -0,0 +1,186 @@
+package riot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// CachingFetcher is a read-through cache in front of a MatchFetcher.
+// Finished matches and their timelines never change, so once cached they are
+// served without touching the Riot API. Match ID listings always go upstream.
+type CachingFetcher struct {
+	upstream MatchFetcher
+	cache    MatchCache
+}
+
+// NewCachingFetcher wraps upstream with a read-through cache.
+func NewCachingFetcher(upstream MatchFetcher, cache MatchCache) *CachingFetcher {
+	return &CachingFetcher{
+		upstream: upstream,
+		cache:    cache,
+	}
+}
+
+// GetMatchIDs always queries upstream since a player's match list keeps growing.
+func (f *CachingFetcher) GetMatchIDs(ctx context.Context, platform, puuid string, opts MatchIDsOptions) ([]string, error) {
+	return f.upstream.GetMatchIDs(ctx, platform, puuid, opts)
+}
+
+// GetMatch returns the cached match or fetches and caches it.
+func (f *CachingFetcher) GetMatch(ctx context.Context, platform, matchID string) (*models.Match, error) {
+	if data, err := f.cache.GetRawMatch(ctx, matchID); err == nil && data != nil {
+		var match models.Match
+		if err := json.Unmarshal(data, &match); err == nil {
+			return &match, nil
+		}
+	}
+
+	data, err := f.fetchRaw(ctx, platform, matchID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var match models.Match
+	if err := json.Unmarshal(data, &match); err != nil {
+		return nil, fmt.Errorf("decode match %s: %w", matchID, err)
+	}
+
+	// Caching is best effort: a failed write only costs an API call next time.
+	_ = f.cache.SaveRawMatch(ctx, matchID, data)
+	return &match, nil
+}
+
+// GetMatchTimeline returns the cached timeline or fetches and caches it.
+func (f *CachingFetcher) GetMatchTimeline(ctx context.Context, platform, matchID string) (*models.Timeline, error) {
+	if data, err := f.cache.GetRawTimeline(ctx, matchID); err == nil && data != nil {
+		var timeline models.Timeline
+		if err := json.Unmarshal(data, &timeline); err == nil {
+			return &timeline, nil
+		}
+	}
+
+	data, err := f.fetchRaw(ctx, platform, matchID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeline models.Timeline
+	if err := json.Unmarshal(data, &timeline); err != nil {
+		return nil, fmt.Errorf("decode timeline %s: %w", matchID, err)
+	}
+
+	_ = f.cache.SaveRawTimeline(ctx, matchID, data)
+	return &timeline, nil
+}
+
+// fetchRaw prefers the upstream's raw payload so the cache keeps fields the
+// models don't decode; otherwise it re-encodes the decoded value.
+func (f *CachingFetcher) fetchRaw(ctx context.Context, platform, matchID string, timeline bool) ([]byte, error) {
+	if raw, ok := f.upstream.(RawMatchFetcher); ok {
+		if timeline {
+			return raw.GetMatchTimelineRaw(ctx, platform, matchID)
+		}
+		return raw.GetMatchRaw(ctx, platform, matchID)
+	}
+
+	var value any
+	var err error
+	if timeline {
+		value, err = f.upstream.GetMatchTimeline(ctx, platform, matchID)
+	} else {
+		value, err = f.upstream.GetMatch(ctx, platform, matchID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// FileCache stores raw match and timeline JSON as files in a directory.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache creates a file cache rooted at dir, creating it if needed.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create cache dir: %w", err)
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+// DefaultCacheDir returns the per-user cache directory for match data.
+func DefaultCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "league-buddy", "matches"), nil
+}
+
+func (c *FileCache) GetRawMatch(_ context.Context, matchID string) ([]byte, error) {
+	return c.read(matchID, ".json")
+}
+
+func (c *FileCache) SaveRawMatch(_ context.Context, matchID string, data []byte) error {
+	return c.write(matchID, ".json", data)
+}
+
+func (c *FileCache) GetRawTimeline(_ context.Context, matchID string) ([]byte, error) {
+	return c.read(matchID, ".timeline.json")
+}
+
+func (c *FileCache) SaveRawTimeline(_ context.Context, matchID string, data []byte) error {
+	return c.write(matchID, ".timeline.json", data)
+}
+
+func (c *FileCache) path(matchID, suffix string) (string, error) {
+	if matchID == "" || strings.ContainsAny(matchID, `/\`) || strings.Contains(matchID, "..") {
+		return "", fmt.Errorf("invalid match ID %q", matchID)
+	}
+	return filepath.Join(c.dir, matchID+suffix), nil
+}
+
+func (c *FileCache) read(matchID, suffix string) ([]byte, error) {
+	path, err := c.path(matchID, suffix)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// write stores data atomically so concurrent readers never see a partial file.
+func (c *FileCache) write(matchID, suffix string, data []byte) error {
+	path, err := c.path(matchID, suffix)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, matchID+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
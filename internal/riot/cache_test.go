@@ -0,0 +1,166 @@
+package riot_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+	"github.com/HatiCode/league-buddy/internal/riot"
+)
+
+type memoryCache struct {
+	mu        sync.Mutex
+	matches   map[string][]byte
+	timelines map[string][]byte
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{matches: map[string][]byte{}, timelines: map[string][]byte{}}
+}
+
+func (c *memoryCache) GetRawMatch(_ context.Context, id string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.matches[id], nil
+}
+
+func (c *memoryCache) SaveRawMatch(_ context.Context, id string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.matches[id] = data
+	return nil
+}
+
+func (c *memoryCache) GetRawTimeline(_ context.Context, id string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.timelines[id], nil
+}
+
+func (c *memoryCache) SaveRawTimeline(_ context.Context, id string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.timelines[id] = data
+	return nil
+}
+
+type countingFetcher struct {
+	fakeMatchFetcher
+	matchCalls    int
+	timelineCalls int
+}
+
+func (f *countingFetcher) GetMatch(ctx context.Context, platform, matchID string) (*models.Match, error) {
+	f.matchCalls++
+	return f.fakeMatchFetcher.GetMatch(ctx, platform, matchID)
+}
+
+func (f *countingFetcher) GetMatchTimeline(ctx context.Context, platform, matchID string) (*models.Timeline, error) {
+	f.timelineCalls++
+	return f.fakeMatchFetcher.GetMatchTimeline(ctx, platform, matchID)
+}
+
+func TestCachingFetcher_ServesRepeatReadsFromCache(t *testing.T) {
+	upstream := &countingFetcher{}
+	cache := newMemoryCache()
+	fetcher := riot.NewCachingFetcher(upstream, cache)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		match, err := fetcher.GetMatch(ctx, riot.PlatformEUW1, "EUW1_1")
+		if err != nil {
+			t.Fatalf("GetMatch: %v", err)
+		}
+		if match.Metadata.MatchID != "EUW1_1" {
+			t.Errorf("expected EUW1_1, got %s", match.Metadata.MatchID)
+		}
+		if _, err := fetcher.GetMatchTimeline(ctx, riot.PlatformEUW1, "EUW1_1"); err != nil {
+			t.Fatalf("GetMatchTimeline: %v", err)
+		}
+	}
+
+	if upstream.matchCalls != 1 || upstream.timelineCalls != 1 {
+		t.Errorf("expected one upstream call each, got match=%d timeline=%d", upstream.matchCalls, upstream.timelineCalls)
+	}
+	if cache.matches["EUW1_1"] == nil || cache.timelines["EUW1_1"] == nil {
+		t.Error("expected match and timeline to be cached")
+	}
+}
+
+func TestCachingFetcher_DoesNotCacheFailures(t *testing.T) {
+	upstream := &countingFetcher{fakeMatchFetcher: fakeMatchFetcher{failMatch: map[string]bool{"EUW1_1": true}}}
+	cache := newMemoryCache()
+	fetcher := riot.NewCachingFetcher(upstream, cache)
+
+	if _, err := fetcher.GetMatch(context.Background(), riot.PlatformEUW1, "EUW1_1"); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(cache.matches) != 0 {
+		t.Error("failed fetch should not be cached")
+	}
+}
+
+func TestCachingFetcher_StoresRawAPIPayload(t *testing.T) {
+	// The client's raw payload is cached verbatim, including fields the models drop.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"metadata":{"matchId":"EUW1_1"},"info":{"gameId":1},"extra":true}`)
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("key", riot.WithBaseURL(server.URL))
+	cache := newMemoryCache()
+
+	if _, err := riot.NewCachingFetcher(client, cache).GetMatch(context.Background(), riot.PlatformEUW1, "EUW1_1"); err != nil {
+		t.Fatalf("GetMatch: %v", err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(cache.matches["EUW1_1"], &raw); err != nil {
+		t.Fatalf("cached payload is not JSON: %v", err)
+	}
+	if raw["extra"] != true {
+		t.Errorf("expected raw payload to be cached, got %s", cache.matches["EUW1_1"])
+	}
+}
+
+func TestFileCache_RoundTrip(t *testing.T) {
+	cache, err := riot.NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	ctx := context.Background()
+
+	if data, err := cache.GetRawMatch(ctx, "EUW1_1"); err != nil || data != nil {
+		t.Fatalf("expected miss, got data=%s err=%v", data, err)
+	}
+
+	if err := cache.SaveRawMatch(ctx, "EUW1_1", []byte(`{"a":1}`)); err != nil {
+		t.Fatalf("SaveRawMatch: %v", err)
+	}
+	if err := cache.SaveRawTimeline(ctx, "EUW1_1", []byte(`{"b":2}`)); err != nil {
+		t.Fatalf("SaveRawTimeline: %v", err)
+	}
+
+	if data, _ := cache.GetRawMatch(ctx, "EUW1_1"); string(data) != `{"a":1}` {
+		t.Errorf("unexpected match data %s", data)
+	}
+	if data, _ := cache.GetRawTimeline(ctx, "EUW1_1"); string(data) != `{"b":2}` {
+		t.Errorf("unexpected timeline data %s", data)
+	}
+}
+
+func TestFileCache_RejectsPathTraversal(t *testing.T) {
+	cache, err := riot.NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	if err := cache.SaveRawMatch(context.Background(), "../escape", []byte(`{}`)); err == nil {
+		t.Error("expected error for match ID containing a path")
+	}
+}
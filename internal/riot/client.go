@@ -30,10 +30,35 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	}
 }
 
-// WithRateLimiter adds rate limiting to the client.
+// WithRateLimiter adds rate limiting to the client, sharing one limiter across all routing values.
+// The limiter is reconfigured from Riot's X-App-Rate-Limit headers as responses come in,
+// and methods are limited once Riot advertises X-Method-Rate-Limit headers for them.
 func WithRateLimiter(limiter *ratelimit.Limiter) ClientOption {
 	return func(c *APIClient) {
-		c.httpClient.Transport = ratelimit.NewRoundTripper(limiter, c.httpClient.Transport)
+		c.transport = ratelimit.NewRoundTripper(limiter, c.httpClient.Transport,
+			methodOptions(DefaultEndpointFamilies, false)...)
+		c.httpClient.Transport = c.transport
+	}
+}
+
+// WithEndpointRateLimits adds rate limiting that mirrors how Riot enforces it:
+// appLimits apply separately to each routing value (euw1, europe, ...), and each
+// method gets its own bucket per routing value with the limits declared in families.
+// Both are reconfigured from Riot's rate limit headers as responses come in.
+func WithEndpointRateLimits(appLimits []ratelimit.Limit, families []EndpointFamily) ClientOption {
+	return func(c *APIClient) {
+		opts := append([]ratelimit.RoundTripperOption{ratelimit.WithHostLimits(appLimits...)},
+			methodOptions(families, true)...)
+		c.transport = ratelimit.NewRoundTripper(nil, c.httpClient.Transport, opts...)
+		c.httpClient.Transport = c.transport
+	}
+}
+
+// WithMaxRetries sets how many times a rate limited (429) request is retried
+// after the advertised Retry-After delay. The default is 0 (no retries).
+func WithMaxRetries(n int) ClientOption {
+	return func(c *APIClient) {
+		c.maxRetries = n
 	}
 }
 
@@ -42,6 +67,8 @@ type APIClient struct {
 	apiKey     string
 	baseURL    string
 	httpClient *http.Client
+	transport  *ratelimit.RoundTripper
+	maxRetries int
 }
 
 // NewClient creates a new Riot API client.
@@ -85,16 +112,16 @@ func (c *APIClient) GetSummonerByPUUID(ctx context.Context, region, puuid string
 	return &summoner, nil
 }
 
-// GetMatchIDs fetches recent match IDs for a player. Pass queue=0 for all queues.
-func (c *APIClient) GetMatchIDs(ctx context.Context, platform, puuid string, count, queue int) ([]string, error) {
+// GetMatchIDs fetches a page of match IDs for a player, most recent first.
+func (c *APIClient) GetMatchIDs(ctx context.Context, platform, puuid string, opts MatchIDsOptions) ([]string, error) {
 	if !isValidPlatform(platform) {
 		return nil, ErrInvalidRegion
 	}
 
 	region := PlatformToRegion[platform]
-	path := fmt.Sprintf("/lol/match/v5/matches/by-puuid/%s/ids?count=%s", puuid, strconv.Itoa(count))
-	if queue > 0 {
-		path += "&queue=" + strconv.Itoa(queue)
+	path := fmt.Sprintf("/lol/match/v5/matches/by-puuid/%s/ids", puuid)
+	if query := opts.query().Encode(); query != "" {
+		path += "?" + query
 	}
 
 	var matchIDs []string
@@ -136,6 +163,38 @@ func (c *APIClient) GetMatchTimeline(ctx context.Context, platform, matchID stri
 	return &timeline, nil
 }
 
+// GetMatchRaw fetches full match details without decoding them.
+func (c *APIClient) GetMatchRaw(ctx context.Context, platform, matchID string) (json.RawMessage, error) {
+	if !isValidPlatform(platform) {
+		return nil, ErrInvalidRegion
+	}
+
+	region := PlatformToRegion[platform]
+	path := fmt.Sprintf("/lol/match/v5/matches/%s", matchID)
+
+	var raw json.RawMessage
+	if err := c.getRegional(ctx, region, path, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// GetMatchTimelineRaw fetches timeline data for a match without decoding it.
+func (c *APIClient) GetMatchTimelineRaw(ctx context.Context, platform, matchID string) (json.RawMessage, error) {
+	if !isValidPlatform(platform) {
+		return nil, ErrInvalidRegion
+	}
+
+	region := PlatformToRegion[platform]
+	path := fmt.Sprintf("/lol/match/v5/matches/%s/timeline", matchID)
+
+	var raw json.RawMessage
+	if err := c.getRegional(ctx, region, path, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
 // GetLeagueEntries fetches ranked entries for a player by PUUID.
 func (c *APIClient) GetLeagueEntries(ctx context.Context, region, puuid string) ([]models.LeagueEntry, error) {
 	if !isValidPlatform(region) {
@@ -150,6 +209,119 @@ func (c *APIClient) GetLeagueEntries(ctx context.Context, region, puuid string)
 	return entries, nil
 }
 
+// GetChallengerLeague fetches the Challenger league for a ranked queue.
+func (c *APIClient) GetChallengerLeague(ctx context.Context, platform, queue string) (*models.LeagueList, error) {
+	return c.getLeagueList(ctx, platform, fmt.Sprintf("/lol/league/v4/challengerleagues/by-queue/%s", url.PathEscape(queue)))
+}
+
+// GetGrandmasterLeague fetches the Grandmaster league for a ranked queue.
+func (c *APIClient) GetGrandmasterLeague(ctx context.Context, platform, queue string) (*models.LeagueList, error) {
+	return c.getLeagueList(ctx, platform, fmt.Sprintf("/lol/league/v4/grandmasterleagues/by-queue/%s", url.PathEscape(queue)))
+}
+
+// GetMasterLeague fetches the Master league for a ranked queue.
+func (c *APIClient) GetMasterLeague(ctx context.Context, platform, queue string) (*models.LeagueList, error) {
+	return c.getLeagueList(ctx, platform, fmt.Sprintf("/lol/league/v4/masterleagues/by-queue/%s", url.PathEscape(queue)))
+}
+
+// GetLeagueByID fetches a league and all of its entries.
+func (c *APIClient) GetLeagueByID(ctx context.Context, platform, leagueID string) (*models.LeagueList, error) {
+	return c.getLeagueList(ctx, platform, fmt.Sprintf("/lol/league/v4/leagues/%s", url.PathEscape(leagueID)))
+}
+
+func (c *APIClient) getLeagueList(ctx context.Context, platform, path string) (*models.LeagueList, error) {
+	if !isValidPlatform(platform) {
+		return nil, ErrInvalidRegion
+	}
+
+	var league models.LeagueList
+	if err := c.get(ctx, platform, path, &league); err != nil {
+		return nil, err
+	}
+	return &league, nil
+}
+
+// GetLeagueEntriesByTier fetches one page of ranked entries for a queue, tier and
+// division (I to IV). Pages start at 1; an empty page means there are no more.
+func (c *APIClient) GetLeagueEntriesByTier(ctx context.Context, platform, queue, tier, division string, page int) ([]models.LeagueEntry, error) {
+	if !isValidPlatform(platform) {
+		return nil, ErrInvalidRegion
+	}
+
+	path := fmt.Sprintf("/lol/league/v4/entries/%s/%s/%s", url.PathEscape(queue), url.PathEscape(tier), url.PathEscape(division))
+	if page > 1 {
+		path += "?page=" + strconv.Itoa(page)
+	}
+
+	var entries []models.LeagueEntry
+	if err := c.get(ctx, platform, path, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetChampionMasteries fetches a player's mastery of every champion they have played.
+func (c *APIClient) GetChampionMasteries(ctx context.Context, platform, puuid string) ([]models.ChampionMastery, error) {
+	if !isValidPlatform(platform) {
+		return nil, ErrInvalidRegion
+	}
+
+	path := fmt.Sprintf("/lol/champion-mastery/v4/champion-masteries/by-puuid/%s", puuid)
+	var masteries []models.ChampionMastery
+	if err := c.get(ctx, platform, path, &masteries); err != nil {
+		return nil, err
+	}
+	return masteries, nil
+}
+
+// GetTopChampionMasteries fetches a player's count highest masteries. Riot
+// returns 3 when count is 0.
+func (c *APIClient) GetTopChampionMasteries(ctx context.Context, platform, puuid string, count int) ([]models.ChampionMastery, error) {
+	if !isValidPlatform(platform) {
+		return nil, ErrInvalidRegion
+	}
+
+	path := fmt.Sprintf("/lol/champion-mastery/v4/champion-masteries/by-puuid/%s/top", puuid)
+	if count > 0 {
+		path += "?count=" + strconv.Itoa(count)
+	}
+
+	var masteries []models.ChampionMastery
+	if err := c.get(ctx, platform, path, &masteries); err != nil {
+		return nil, err
+	}
+	return masteries, nil
+}
+
+// GetMasteryScore fetches a player's total mastery score, the sum of their champion levels.
+func (c *APIClient) GetMasteryScore(ctx context.Context, platform, puuid string) (int, error) {
+	if !isValidPlatform(platform) {
+		return 0, ErrInvalidRegion
+	}
+
+	path := fmt.Sprintf("/lol/champion-mastery/v4/scores/by-puuid/%s", puuid)
+	var score int
+	if err := c.get(ctx, platform, path, &score); err != nil {
+		return 0, err
+	}
+	return score, nil
+}
+
+// GetActiveGame fetches the game a player is currently in.
+// It returns ErrNotFound when the player is not in a game.
+func (c *APIClient) GetActiveGame(ctx context.Context, platform, puuid string) (*models.CurrentGameInfo, error) {
+	if !isValidPlatform(platform) {
+		return nil, ErrInvalidRegion
+	}
+
+	path := fmt.Sprintf("/lol/spectator/v5/active-games/by-summoner/%s", puuid)
+	var game models.CurrentGameInfo
+	if err := c.get(ctx, platform, path, &game); err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
 // get performs a GET request to platform-specific endpoints.
 func (c *APIClient) get(ctx context.Context, platform, path string, result any) error {
 	baseURL := c.baseURL
@@ -169,18 +341,39 @@ func (c *APIClient) getRegional(ctx context.Context, region, path string, result
 }
 
 // doRequest executes the HTTP request and handles common responses.
+// Rate limited requests are retried up to maxRetries times after the advertised delay.
 func (c *APIClient) doRequest(ctx context.Context, url string, result any) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return err
-	}
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("X-Riot-Token", c.apiKey)
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
 
-	req.Header.Set("X-Riot-Token", c.apiKey)
+		c.observeRateLimits(req, resp.Header)
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < c.maxRetries {
+			resp.Body.Close()
+			delay := retryDelay(resp.Header, attempt)
+			c.pauseLimiters(req, resp.Header, delay)
+			if err := sleep(ctx, delay); err != nil {
+				return err
+			}
+			continue
+		}
+
+		return decodeResponse(resp, result)
 	}
+}
+
+// decodeResponse maps the status code to an error or decodes the body into result.
+func decodeResponse(resp *http.Response, result any) error {
 	defer resp.Body.Close()
 
 	switch resp.StatusCode {
@@ -139,7 +139,7 @@ func TestGetMatchIDs_Success(t *testing.T) {
 	defer server.Close()
 
 	client := riot.NewClient("test-api-key", riot.WithBaseURL(server.URL))
-	matchIDs, err := client.GetMatchIDs(context.Background(), riot.PlatformEUW1, "puuid-12345", 5, 0)
+	matchIDs, err := client.GetMatchIDs(context.Background(), riot.PlatformEUW1, "puuid-12345", riot.MatchIDsOptions{Count: 5})
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -240,6 +240,233 @@ func TestGetLeagueEntries_Success(t *testing.T) {
 	}
 }
 
+func TestGetChallengerLeague_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/lol/league/v4/challengerleagues/by-queue/RANKED_SOLO_5x5" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"leagueId":"league-1","tier":"CHALLENGER","queue":"RANKED_SOLO_5x5","name":"Ezreal's Legends",
+			"entries":[{"puuid":"puuid-1","rank":"I","leaguePoints":1500,"wins":300,"losses":200}]}`))
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key", riot.WithBaseURL(server.URL))
+	league, err := client.GetChallengerLeague(context.Background(), riot.PlatformEUW1, models.QueueRankedSolo)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if league.LeagueID != "league-1" || league.Tier != "CHALLENGER" {
+		t.Errorf("unexpected league: %+v", league)
+	}
+	if len(league.Entries) != 1 || league.Entries[0].PUUID != "puuid-1" || league.Entries[0].LeaguePoints != 1500 {
+		t.Errorf("unexpected entries: %+v", league.Entries)
+	}
+}
+
+func TestGetLeagueByID_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/lol/league/v4/leagues/league-1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"leagueId":"league-1","tier":"GOLD","entries":[{"puuid":"puuid-1","rank":"II"}]}`))
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key", riot.WithBaseURL(server.URL))
+	league, err := client.GetLeagueByID(context.Background(), riot.PlatformEUW1, "league-1")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if league.Tier != "GOLD" || len(league.Entries) != 1 || league.Entries[0].Rank != "II" {
+		t.Errorf("unexpected league: %+v", league)
+	}
+}
+
+func TestGetLeagueEntriesByTier_Page(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/lol/league/v4/entries/RANKED_SOLO_5x5/GOLD/II" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if page := r.URL.Query().Get("page"); page != "3" {
+			t.Errorf("expected page 3, got %q", page)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]models.LeagueEntry{{PUUID: "puuid-1", Tier: "GOLD", Rank: "II"}})
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key", riot.WithBaseURL(server.URL))
+	entries, err := client.GetLeagueEntriesByTier(context.Background(), riot.PlatformEUW1, models.QueueRankedSolo, "GOLD", "II", 3)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].PUUID != "puuid-1" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestFetchLeagueEntries_StopsAtEmptyPage(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		w.Header().Set("Content-Type", "application/json")
+		if page == "3" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		json.NewEncoder(w).Encode([]models.LeagueEntry{{PUUID: "puuid-page-" + page}, {PUUID: "puuid-page-" + page + "b"}})
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key", riot.WithBaseURL(server.URL))
+	entries, err := riot.FetchLeagueEntries(context.Background(), client, riot.PlatformEUW1, models.QueueRankedSolo, "GOLD", "I", 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Errorf("expected 4 entries from 2 pages, got %d", len(entries))
+	}
+	// The first page is requested without a page parameter.
+	if len(pages) != 3 || pages[0] != "" || pages[1] != "2" {
+		t.Errorf("unexpected pages requested: %q", pages)
+	}
+
+	pages = nil
+	entries, err = riot.FetchLeagueEntries(context.Background(), client, riot.PlatformEUW1, models.QueueRankedSolo, "GOLD", "I", 1)
+	if err != nil || len(entries) != 2 || len(pages) != 1 {
+		t.Errorf("expected a single page, got %d entries over %d requests (err %v)", len(entries), len(pages), err)
+	}
+}
+
+// --- Champion Mastery Tests ---
+
+func TestGetChampionMasteries_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/lol/champion-mastery/v4/champion-masteries/by-puuid/puuid-12345" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"puuid":"puuid-12345","championId":103,"championLevel":12,"championPoints":245000},
+			{"puuid":"puuid-12345","championId":7,"championLevel":5,"championPoints":31000}]`))
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key", riot.WithBaseURL(server.URL))
+	masteries, err := client.GetChampionMasteries(context.Background(), riot.PlatformEUW1, "puuid-12345")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(masteries) != 2 || masteries[0].ChampionID != 103 || masteries[0].ChampionPoints != 245000 {
+		t.Errorf("unexpected masteries: %+v", masteries)
+	}
+}
+
+func TestGetTopChampionMasteries_Count(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/lol/champion-mastery/v4/champion-masteries/by-puuid/puuid-12345/top" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if count := r.URL.Query().Get("count"); count != "5" {
+			t.Errorf("expected count 5, got %q", count)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"championId":103,"championLevel":12}]`))
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key", riot.WithBaseURL(server.URL))
+	masteries, err := client.GetTopChampionMasteries(context.Background(), riot.PlatformEUW1, "puuid-12345", 5)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(masteries) != 1 || masteries[0].ChampionLevel != 12 {
+		t.Errorf("unexpected masteries: %+v", masteries)
+	}
+}
+
+func TestGetMasteryScore_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/lol/champion-mastery/v4/scores/by-puuid/puuid-12345" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`412`))
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key", riot.WithBaseURL(server.URL))
+	score, err := client.GetMasteryScore(context.Background(), riot.PlatformEUW1, "puuid-12345")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if score != 412 {
+		t.Errorf("expected score 412, got %d", score)
+	}
+}
+
+// --- Spectator Tests ---
+
+func TestGetActiveGame_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/lol/spectator/v5/active-games/by-summoner/puuid-12345" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"gameId":7001,"gameQueueConfigId":420,"gameLength":312,
+			"participants":[{"puuid":"puuid-12345","riotId":"Faker#KR1","championId":103,"teamId":100}],
+			"bannedChampions":[{"championId":157,"teamId":200,"pickTurn":1}]}`))
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key", riot.WithBaseURL(server.URL))
+	game, err := client.GetActiveGame(context.Background(), riot.PlatformKR, "puuid-12345")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if game.GameID != 7001 || game.GameQueueConfigID != 420 || game.GameLength != 312 {
+		t.Errorf("unexpected game: %+v", game)
+	}
+	if len(game.Participants) != 1 || game.Participants[0].RiotID != "Faker#KR1" || game.Participants[0].ChampionID != 103 {
+		t.Errorf("unexpected participants: %+v", game.Participants)
+	}
+	if len(game.BannedChampions) != 1 || game.BannedChampions[0].ChampionID != 157 {
+		t.Errorf("unexpected bans: %+v", game.BannedChampions)
+	}
+}
+
+func TestGetActiveGame_NotInGame(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key", riot.WithBaseURL(server.URL))
+	_, err := client.GetActiveGame(context.Background(), riot.PlatformEUW1, "puuid-12345")
+
+	if !errors.Is(err, riot.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
 // --- Rate Limiting Tests ---
 
 func TestClient_RateLimited(t *testing.T) {
@@ -330,3 +557,190 @@ func TestClient_WithRateLimiter(t *testing.T) {
 		t.Errorf("expected 2 requests to server, got %d", requestCount)
 	}
 }
+
+func TestClient_RetriesAfterRetryAfter(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		if requestCount == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.Header().Set("X-Rate-Limit-Type", "application")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&models.Account{PUUID: "test", GameName: "Test", TagLine: "1234"})
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key",
+		riot.WithBaseURL(server.URL),
+		riot.WithMaxRetries(2),
+	)
+	account, err := client.GetAccountByRiotID(context.Background(), riot.RegionEurope, "Test", "1234")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if account.PUUID != "test" {
+		t.Errorf("expected PUUID test, got %s", account.PUUID)
+	}
+	if requestCount != 2 {
+		t.Errorf("expected 2 requests to server, got %d", requestCount)
+	}
+}
+
+func TestClient_RetryBudgetExhausted(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key",
+		riot.WithBaseURL(server.URL),
+		riot.WithMaxRetries(2),
+	)
+	_, err := client.GetAccountByRiotID(context.Background(), riot.RegionEurope, "Test", "1234")
+
+	if err != riot.ErrRateLimited {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+	if requestCount != 3 {
+		t.Errorf("expected 3 requests to server (1 + 2 retries), got %d", requestCount)
+	}
+}
+
+func TestClient_RetryHonoursContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key",
+		riot.WithBaseURL(server.URL),
+		riot.WithMaxRetries(1),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetAccountByRiotID(ctx, riot.RegionEurope, "Test", "1234")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRetryDelay_Backoff(t *testing.T) {
+	tests := []struct {
+		retryAfter string
+		attempt    int
+		want       time.Duration
+	}{
+		{"", 0, time.Second},
+		{"", 3, 8 * time.Second},
+		{"", 5, 30 * time.Second},
+		{"", 64, 30 * time.Second},
+		{"", 1 << 20, 30 * time.Second},
+		{"120", 64, 120 * time.Second},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.retryAfter != "" {
+			header.Set("Retry-After", tt.retryAfter)
+		}
+		if got := riot.RetryDelay(header, tt.attempt); got != tt.want {
+			t.Errorf("Retry-After %q, attempt %d: expected %v, got %v", tt.retryAfter, tt.attempt, tt.want, got)
+		}
+	}
+}
+
+func TestClient_AppliesRateLimitHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-App-Rate-Limit", "1:10,100:120")
+		w.Header().Set("X-App-Rate-Limit-Count", "1:10,1:120")
+		w.Header().Set("X-Method-Rate-Limit", "50:10")
+		w.Header().Set("X-Method-Rate-Limit-Count", "1:10")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&models.Account{PUUID: "test", GameName: "Test", TagLine: "1234"})
+	}))
+	defer server.Close()
+
+	limiter := ratelimit.NewLimiter(
+		ratelimit.WithLimit(500, 10*time.Second),
+	)
+
+	client := riot.NewClient("test-api-key",
+		riot.WithBaseURL(server.URL),
+		riot.WithRateLimiter(limiter),
+	)
+
+	if _, err := client.GetAccountByRiotID(context.Background(), riot.RegionEurope, "Test", "1234"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	limits := limiter.Limits()
+	if len(limits) != 2 || limits[0].Count != 1 || limits[1].Window != 120*time.Second {
+		t.Fatalf("expected limiter reconfigured to 1:10,100:120, got %+v", limits)
+	}
+
+	// The advertised app limit of 1 per 10s is now exhausted
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetAccountByRiotID(ctx, riot.RegionEurope, "Test", "1234")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClient_EndpointRateLimitsPerMethod(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/lol/league/v4/entries/by-puuid/puuid-12345":
+			json.NewEncoder(w).Encode([]models.LeagueEntry{})
+		default:
+			json.NewEncoder(w).Encode(&models.Match{})
+		}
+	}))
+	defer server.Close()
+
+	families := []riot.EndpointFamily{
+		{
+			Name:      "match-v5",
+			Templates: []string{"/lol/match/v5/matches/{matchId}"},
+			Limits:    []ratelimit.Limit{{Count: 1, Window: time.Second}},
+		},
+		{
+			Name:      "league-v4",
+			Templates: []string{"/lol/league/v4/entries/by-puuid/{puuid}"},
+			Limits:    []ratelimit.Limit{{Count: 10, Window: time.Second}},
+		},
+	}
+
+	client := riot.NewClient("test-api-key",
+		riot.WithBaseURL(server.URL),
+		riot.WithEndpointRateLimits([]ratelimit.Limit{{Count: 100, Window: time.Second}}, families),
+	)
+
+	if _, err := client.GetMatch(context.Background(), riot.PlatformEUW1, "EUW1_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// match-v5 is exhausted, but league-v4 has its own bucket
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetLeagueEntries(ctx, riot.PlatformEUW1, "puuid-12345"); err != nil {
+		t.Fatalf("league call should not be throttled by match-v5: %v", err)
+	}
+
+	_, err := client.GetMatch(ctx, riot.PlatformEUW1, "EUW1_2")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
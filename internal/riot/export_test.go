@@ -0,0 +1,4 @@
+package riot
+
+// RetryDelay exposes retryDelay to the external tests.
+var RetryDelay = retryDelay
@@ -2,6 +2,7 @@ package riot
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/HatiCode/league-buddy/internal/models"
 )
@@ -18,16 +19,52 @@ type SummonerFetcher interface {
 
 // MatchFetcher retrieves match data.
 type MatchFetcher interface {
-	GetMatchIDs(ctx context.Context, region, puuid string, count, queue int) ([]string, error)
+	GetMatchIDs(ctx context.Context, region, puuid string, opts MatchIDsOptions) ([]string, error)
 	GetMatch(ctx context.Context, region, matchID string) (*models.Match, error)
 	GetMatchTimeline(ctx context.Context, region, matchID string) (*models.Timeline, error)
 }
 
+// RawMatchFetcher retrieves undecoded match payloads, preserving fields the models don't cover.
+type RawMatchFetcher interface {
+	GetMatchRaw(ctx context.Context, platform, matchID string) (json.RawMessage, error)
+	GetMatchTimelineRaw(ctx context.Context, platform, matchID string) (json.RawMessage, error)
+}
+
+// MatchCache stores raw match and timeline JSON keyed by match ID.
+// Get methods return nil data and no error when nothing is cached.
+type MatchCache interface {
+	GetRawMatch(ctx context.Context, matchID string) ([]byte, error)
+	SaveRawMatch(ctx context.Context, matchID string, data []byte) error
+	GetRawTimeline(ctx context.Context, matchID string) ([]byte, error)
+	SaveRawTimeline(ctx context.Context, matchID string, data []byte) error
+}
+
 // LeagueFetcher retrieves ranked/league information.
 type LeagueFetcher interface {
 	GetLeagueEntries(ctx context.Context, region, puuid string) ([]models.LeagueEntry, error)
 }
 
+// LadderFetcher retrieves whole ranked ladders, for sampling players by tier.
+type LadderFetcher interface {
+	GetChallengerLeague(ctx context.Context, platform, queue string) (*models.LeagueList, error)
+	GetGrandmasterLeague(ctx context.Context, platform, queue string) (*models.LeagueList, error)
+	GetMasterLeague(ctx context.Context, platform, queue string) (*models.LeagueList, error)
+	GetLeagueEntriesByTier(ctx context.Context, platform, queue, tier, division string, page int) ([]models.LeagueEntry, error)
+	GetLeagueByID(ctx context.Context, platform, leagueID string) (*models.LeagueList, error)
+}
+
+// MasteryFetcher retrieves champion mastery.
+type MasteryFetcher interface {
+	GetChampionMasteries(ctx context.Context, platform, puuid string) ([]models.ChampionMastery, error)
+	GetTopChampionMasteries(ctx context.Context, platform, puuid string, count int) ([]models.ChampionMastery, error)
+	GetMasteryScore(ctx context.Context, platform, puuid string) (int, error)
+}
+
+// SpectatorFetcher retrieves games in progress.
+type SpectatorFetcher interface {
+	GetActiveGame(ctx context.Context, platform, puuid string) (*models.CurrentGameInfo, error)
+}
+
 // Client combines all Riot API capabilities.
 // Consumers should prefer the smaller interfaces when possible.
 type Client interface {
@@ -35,4 +72,7 @@ type Client interface {
 	SummonerFetcher
 	MatchFetcher
 	LeagueFetcher
+	LadderFetcher
+	MasteryFetcher
+	SpectatorFetcher
 }
@@ -0,0 +1,25 @@
+package riot
+
+import (
+	"context"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
+
+// FetchLeagueEntries pages through the entries of a queue, tier and division
+// until Riot returns an empty page or maxPages pages have been read.
+// A maxPages of 0 reads every page.
+func FetchLeagueEntries(ctx context.Context, fetcher LadderFetcher, platform, queue, tier, division string, maxPages int) ([]models.LeagueEntry, error) {
+	var all []models.LeagueEntry
+	for page := 1; maxPages <= 0 || page <= maxPages; page++ {
+		entries, err := fetcher.GetLeagueEntriesByTier(ctx, platform, queue, tier, division, page)
+		if err != nil {
+			return all, err
+		}
+		if len(entries) == 0 {
+			break
+		}
+		all = append(all, entries...)
+	}
+	return all, nil
+}
@@ -0,0 +1,198 @@
+package riot
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/HatiCode/league-buddy/pkg/ratelimit"
+)
+
+// Endpoint templates identify Riot API methods for per-method rate limiting.
+const (
+	endpointAccountByRiotID      = "/riot/account/v1/accounts/by-riot-id/{gameName}/{tagLine}"
+	endpointSummonerByPUUID      = "/lol/summoner/v4/summoners/by-puuid/{puuid}"
+	endpointMatchIDsByPUUID      = "/lol/match/v5/matches/by-puuid/{puuid}/ids"
+	endpointMatch                = "/lol/match/v5/matches/{matchId}"
+	endpointMatchTimeline        = "/lol/match/v5/matches/{matchId}/timeline"
+	endpointLeagueEntriesByPUUID = "/lol/league/v4/entries/by-puuid/{puuid}"
+	endpointLeagueEntriesByTier  = "/lol/league/v4/entries/{queue}/{tier}/{division}"
+	endpointChallengerLeague     = "/lol/league/v4/challengerleagues/by-queue/{queue}"
+	endpointGrandmasterLeague    = "/lol/league/v4/grandmasterleagues/by-queue/{queue}"
+	endpointMasterLeague         = "/lol/league/v4/masterleagues/by-queue/{queue}"
+	endpointLeagueByID           = "/lol/league/v4/leagues/{leagueId}"
+	endpointMasteriesByPUUID     = "/lol/champion-mastery/v4/champion-masteries/by-puuid/{puuid}"
+	endpointTopMasteriesByPUUID  = "/lol/champion-mastery/v4/champion-masteries/by-puuid/{puuid}/top"
+	endpointMasteryScoreByPUUID  = "/lol/champion-mastery/v4/scores/by-puuid/{puuid}"
+	endpointActiveGameByPUUID    = "/lol/spectator/v5/active-games/by-summoner/{puuid}"
+)
+
+// Riot rate limit response headers.
+const (
+	headerRetryAfter           = "Retry-After"
+	headerRateLimitType        = "X-Rate-Limit-Type"
+	headerAppRateLimit         = "X-App-Rate-Limit"
+	headerAppRateLimitCount    = "X-App-Rate-Limit-Count"
+	headerMethodRateLimit      = "X-Method-Rate-Limit"
+	headerMethodRateLimitCount = "X-Method-Rate-Limit-Count"
+)
+
+// defaultRetryBackoff is the first retry delay when a 429 carries no Retry-After,
+// which Riot uses for limits enforced by the underlying service.
+const defaultRetryBackoff = time.Second
+
+// maxRetryBackoff caps the doubling retry delay.
+const maxRetryBackoff = 30 * time.Second
+
+// EndpointFamily groups Riot API methods that share declared method rate limits.
+type EndpointFamily struct {
+	Name      string
+	Templates []string
+	Limits    []ratelimit.Limit
+}
+
+// DefaultEndpointFamilies declares method limits for every endpoint the client calls.
+// Riot applies these per routing value, and advertises the exact values for a key
+// in X-Method-Rate-Limit headers, which override them at runtime.
+var DefaultEndpointFamilies = []EndpointFamily{
+	{
+		Name:      "account-v1",
+		Templates: []string{endpointAccountByRiotID},
+		Limits:    []ratelimit.Limit{{Count: 1000, Window: time.Minute}},
+	},
+	{
+		Name:      "summoner-v4",
+		Templates: []string{endpointSummonerByPUUID},
+		Limits:    []ratelimit.Limit{{Count: 1600, Window: time.Minute}},
+	},
+	{
+		Name:      "match-v5",
+		Templates: []string{endpointMatchIDsByPUUID, endpointMatchTimeline, endpointMatch},
+		Limits:    []ratelimit.Limit{{Count: 2000, Window: 10 * time.Second}},
+	},
+	{
+		Name:      "league-v4",
+		Templates: []string{endpointLeagueEntriesByPUUID},
+		Limits:    []ratelimit.Limit{{Count: 100, Window: time.Minute}},
+	},
+	{
+		Name:      "league-v4-apex",
+		Templates: []string{endpointChallengerLeague, endpointGrandmasterLeague, endpointMasterLeague},
+		Limits:    []ratelimit.Limit{{Count: 30, Window: 10 * time.Second}, {Count: 500, Window: 10 * time.Minute}},
+	},
+	{
+		Name:      "league-v4-entries",
+		Templates: []string{endpointLeagueEntriesByTier},
+		Limits:    []ratelimit.Limit{{Count: 50, Window: 10 * time.Second}},
+	},
+	{
+		Name:      "league-v4-leagues",
+		Templates: []string{endpointLeagueByID},
+		Limits:    []ratelimit.Limit{{Count: 500, Window: 10 * time.Second}},
+	},
+	{
+		Name:      "champion-mastery-v4",
+		Templates: []string{endpointMasteriesByPUUID, endpointTopMasteriesByPUUID, endpointMasteryScoreByPUUID},
+		Limits:    []ratelimit.Limit{{Count: 20000, Window: 10 * time.Second}},
+	},
+	{
+		Name:      "spectator-v5",
+		Templates: []string{endpointActiveGameByPUUID},
+		Limits:    []ratelimit.Limit{{Count: 20000, Window: 10 * time.Second}},
+	},
+}
+
+// methodOptions registers every endpoint template with the rate limiting transport.
+// Without declareLimits, methods are only limited once Riot advertises their limits.
+func methodOptions(families []EndpointFamily, declareLimits bool) []ratelimit.RoundTripperOption {
+	var opts []ratelimit.RoundTripperOption
+	for _, f := range families {
+		var limits []ratelimit.Limit
+		if declareLimits {
+			limits = f.Limits
+		}
+		for _, tmpl := range f.Templates {
+			opts = append(opts, ratelimit.WithMethodLimits(tmpl, limits...))
+		}
+	}
+	return opts
+}
+
+// observeRateLimits reconfigures the limiters that applied to req from response headers.
+// It is a no-op when the client has no rate limiting transport.
+func (c *APIClient) observeRateLimits(req *http.Request, header http.Header) {
+	if c.transport == nil {
+		return
+	}
+	app, method := c.transport.Limiters(req)
+
+	if limits, err := ratelimit.ParseLimits(header.Get(headerAppRateLimit)); err == nil && len(limits) > 0 && app != nil {
+		app.SetLimits(limits)
+		if counts, err := ratelimit.ParseLimits(header.Get(headerAppRateLimitCount)); err == nil {
+			app.SyncCounts(counts)
+		}
+	}
+
+	if limits, err := ratelimit.ParseLimits(header.Get(headerMethodRateLimit)); err == nil && len(limits) > 0 && method != nil {
+		method.SetLimits(limits)
+		if counts, err := ratelimit.ParseLimits(header.Get(headerMethodRateLimitCount)); err == nil {
+			method.SyncCounts(counts)
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying a rate limited request.
+// Retry-After wins when present; otherwise the delay doubles with each attempt
+// up to maxRetryBackoff.
+func retryDelay(header http.Header, attempt int) time.Duration {
+	if v := header.Get(headerRetryAfter); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	delay := defaultRetryBackoff
+	for range attempt {
+		if delay >= maxRetryBackoff {
+			break
+		}
+		delay *= 2
+	}
+	return min(delay, maxRetryBackoff)
+}
+
+// pauseLimiters makes concurrent requests back off along with the one being retried.
+func (c *APIClient) pauseLimiters(req *http.Request, header http.Header, delay time.Duration) {
+	if c.transport == nil {
+		return
+	}
+	app, method := c.transport.Limiters(req)
+
+	switch header.Get(headerRateLimitType) {
+	case "application":
+		if app != nil {
+			app.PauseFor(delay)
+		}
+	case "method":
+		if method != nil {
+			method.PauseFor(delay)
+		}
+	}
+}
+
+// sleep waits for d or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
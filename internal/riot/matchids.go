@@ -0,0 +1,143 @@
+package riot
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxMatchIDsPerPage is the largest page match-v5 returns for a match ID listing.
+const MaxMatchIDsPerPage = 100
+
+// defaultMatchIDsCount is the page size match-v5 uses when no count is given.
+const defaultMatchIDsCount = 20
+
+// Match types accepted by MatchIDsOptions.Type.
+const (
+	MatchTypeRanked   = "ranked"
+	MatchTypeNormal   = "normal"
+	MatchTypeTourney  = "tourney"
+	MatchTypeTutorial = "tutorial"
+)
+
+// MatchIDsOptions filters a match ID listing. Zero values are left out of the
+// request, so Riot's defaults apply (20 IDs starting from the most recent match).
+type MatchIDsOptions struct {
+	Start     int       // Offset into the most-recent-first listing
+	Count     int       // Page size, at most MaxMatchIDsPerPage
+	Queue     int       // Queue ID, e.g. models.QueueIDRankedSolo
+	Type      string    // One of the MatchType constants
+	StartTime time.Time // Only matches that started at or after this time
+	EndTime   time.Time // Only matches that started before this time
+}
+
+func (o MatchIDsOptions) query() url.Values {
+	q := url.Values{}
+	if o.Start > 0 {
+		q.Set("start", strconv.Itoa(o.Start))
+	}
+	if o.Count > 0 {
+		q.Set("count", strconv.Itoa(o.Count))
+	}
+	if o.Queue > 0 {
+		q.Set("queue", strconv.Itoa(o.Queue))
+	}
+	if o.Type != "" {
+		q.Set("type", o.Type)
+	}
+	if !o.StartTime.IsZero() {
+		q.Set("startTime", strconv.FormatInt(o.StartTime.Unix(), 10))
+	}
+	if !o.EndTime.IsZero() {
+		q.Set("endTime", strconv.FormatInt(o.EndTime.Unix(), 10))
+	}
+	return q
+}
+
+// GetAllMatchIDs pages through every match ID matching opts, most recent first.
+// opts.Start and opts.Count are managed here and ignored.
+func GetAllMatchIDs(ctx context.Context, fetcher MatchFetcher, platform, puuid string, opts MatchIDsOptions) ([]string, error) {
+	var all []string
+	opts.Count = MaxMatchIDsPerPage
+
+	for opts.Start = 0; ; opts.Start += MaxMatchIDsPerPage {
+		page, err := fetcher.GetMatchIDs(ctx, platform, puuid, opts)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, page...)
+		if len(page) < MaxMatchIDsPerPage {
+			return all, nil
+		}
+	}
+}
+
+// GetMatchIDsInQueues lists the most recent match IDs across queues. match-v5
+// filters on a single queue, so each queue is listed separately and the results
+// are merged most recent first and capped at opts.Count. No queues lists every queue.
+func GetMatchIDsInQueues(ctx context.Context, fetcher MatchFetcher, platform, puuid string, queues []int, opts MatchIDsOptions) ([]string, error) {
+	if len(queues) == 0 {
+		return fetcher.GetMatchIDs(ctx, platform, puuid, opts)
+	}
+
+	var lists [][]string
+	for _, q := range queues {
+		opts.Queue = q
+		ids, err := fetcher.GetMatchIDs(ctx, platform, puuid, opts)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, ids)
+	}
+
+	limit := opts.Count
+	if limit == 0 {
+		limit = defaultMatchIDsCount
+	}
+	merged := mergeMatchIDs(lists)
+	if len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged, nil
+}
+
+// GetAllMatchIDsInQueues pages through every match ID matching opts in each
+// queue, merged most recent first. No queues lists every queue.
+func GetAllMatchIDsInQueues(ctx context.Context, fetcher MatchFetcher, platform, puuid string, queues []int, opts MatchIDsOptions) ([]string, error) {
+	if len(queues) == 0 {
+		return GetAllMatchIDs(ctx, fetcher, platform, puuid, opts)
+	}
+
+	var lists [][]string
+	for _, q := range queues {
+		opts.Queue = q
+		ids, err := GetAllMatchIDs(ctx, fetcher, platform, puuid, opts)
+		lists = append(lists, ids)
+		if err != nil {
+			return mergeMatchIDs(lists), err
+		}
+	}
+	return mergeMatchIDs(lists), nil
+}
+
+// mergeMatchIDs combines listings most recent first. Game IDs grow over time
+// on a platform, so IDs are ordered by their numeric suffix.
+func mergeMatchIDs(lists [][]string) []string {
+	var merged []string
+	for _, ids := range lists {
+		merged = append(merged, ids...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return gameNumber(merged[i]) > gameNumber(merged[j])
+	})
+	return merged
+}
+
+// gameNumber extracts 7012345678 from "EUW1_7012345678", or 0 if malformed.
+func gameNumber(matchID string) int64 {
+	n, _ := strconv.ParseInt(matchID[strings.LastIndex(matchID, "_")+1:], 10, 64)
+	return n
+}
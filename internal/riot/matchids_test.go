@@ -0,0 +1,167 @@
+package riot_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/league-buddy/internal/riot"
+)
+
+func TestGetMatchIDs_EncodesOptions(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		want := map[string]string{
+			"start":     "100",
+			"count":     "100",
+			"queue":     "420",
+			"type":      "ranked",
+			"startTime": fmt.Sprint(since.Unix()),
+			"endTime":   fmt.Sprint(until.Unix()),
+		}
+		for k, v := range want {
+			if q.Get(k) != v {
+				t.Errorf("expected %s=%s, got %q", k, v, q.Get(k))
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]string{})
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key", riot.WithBaseURL(server.URL))
+	_, err := client.GetMatchIDs(context.Background(), riot.PlatformEUW1, "puuid-12345", riot.MatchIDsOptions{
+		Start:     100,
+		Count:     100,
+		Queue:     420,
+		Type:      riot.MatchTypeRanked,
+		StartTime: since,
+		EndTime:   until,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetMatchIDs_OmitsZeroOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("expected no query, got %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]string{})
+	}))
+	defer server.Close()
+
+	client := riot.NewClient("test-api-key", riot.WithBaseURL(server.URL))
+	if _, err := client.GetMatchIDs(context.Background(), riot.PlatformEUW1, "puuid-12345", riot.MatchIDsOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+type pagedFetcher struct {
+	fakeMatchFetcher
+	total   int
+	failAt  int
+	starts  []int
+	lastOpt riot.MatchIDsOptions
+}
+
+func (f *pagedFetcher) GetMatchIDs(_ context.Context, _, _ string, opts riot.MatchIDsOptions) ([]string, error) {
+	f.starts = append(f.starts, opts.Start)
+	f.lastOpt = opts
+	if f.failAt > 0 && opts.Start >= f.failAt {
+		return nil, riot.ErrRateLimited
+	}
+
+	var page []string
+	for i := opts.Start; i < f.total && len(page) < opts.Count; i++ {
+		page = append(page, fmt.Sprintf("EUW1_%d", f.total-i))
+	}
+	return page, nil
+}
+
+func TestGetAllMatchIDs_PagesUntilShortPage(t *testing.T) {
+	fetcher := &pagedFetcher{total: 250}
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	ids, err := riot.GetAllMatchIDs(context.Background(), fetcher, riot.PlatformEUW1, "puuid", riot.MatchIDsOptions{StartTime: since, Count: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 250 {
+		t.Errorf("expected 250 IDs, got %d", len(ids))
+	}
+	if fmt.Sprint(fetcher.starts) != "[0 100 200]" {
+		t.Errorf("unexpected page starts %v", fetcher.starts)
+	}
+	if fetcher.lastOpt.Count != riot.MaxMatchIDsPerPage || !fetcher.lastOpt.StartTime.Equal(since) {
+		t.Errorf("expected full pages with filters kept, got %+v", fetcher.lastOpt)
+	}
+}
+
+func TestGetAllMatchIDs_ReturnsPartialResultsOnError(t *testing.T) {
+	fetcher := &pagedFetcher{total: 250, failAt: 100}
+
+	ids, err := riot.GetAllMatchIDs(context.Background(), fetcher, riot.PlatformEUW1, "puuid", riot.MatchIDsOptions{})
+	if !errors.Is(err, riot.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if len(ids) != 100 {
+		t.Errorf("expected the first page to be returned, got %d IDs", len(ids))
+	}
+}
+
+type queuedFetcher struct {
+	fakeMatchFetcher
+	byQueue map[int][]string
+	queues  []int
+}
+
+func (f *queuedFetcher) GetMatchIDs(_ context.Context, _, _ string, opts riot.MatchIDsOptions) ([]string, error) {
+	f.queues = append(f.queues, opts.Queue)
+	ids := f.byQueue[opts.Queue]
+	if opts.Count > 0 && len(ids) > opts.Count {
+		ids = ids[:opts.Count]
+	}
+	return ids, nil
+}
+
+func TestGetMatchIDsInQueues_MergesMostRecentFirst(t *testing.T) {
+	fetcher := &queuedFetcher{byQueue: map[int][]string{
+		400: {"EUW1_109", "EUW1_105", "EUW1_101"},
+		430: {"EUW1_110", "EUW1_102"},
+		490: {"EUW1_107"},
+	}}
+
+	ids, err := riot.GetMatchIDsInQueues(context.Background(), fetcher, riot.PlatformEUW1, "puuid", []int{400, 430, 490}, riot.MatchIDsOptions{Count: 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(ids) != "[EUW1_110 EUW1_109 EUW1_107 EUW1_105]" {
+		t.Errorf("unexpected IDs %v", ids)
+	}
+	if fmt.Sprint(fetcher.queues) != "[400 430 490]" {
+		t.Errorf("expected one listing per queue, got %v", fetcher.queues)
+	}
+}
+
+func TestGetMatchIDsInQueues_NoQueuesListsEverything(t *testing.T) {
+	fetcher := &queuedFetcher{byQueue: map[int][]string{0: {"EUW1_2", "EUW1_1"}}}
+
+	ids, err := riot.GetMatchIDsInQueues(context.Background(), fetcher, riot.PlatformEUW1, "puuid", nil, riot.MatchIDsOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 2 || fmt.Sprint(fetcher.queues) != "[0]" {
+		t.Errorf("expected a single unfiltered listing, got %v from %v", ids, fetcher.queues)
+	}
+}
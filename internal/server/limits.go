@@ -0,0 +1,37 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/HatiCode/league-buddy/pkg/ratelimit"
+)
+
+// DefaultClientLimits allow short bursts while keeping one client from
+// exhausting the Riot API key or the LLM budget.
+var DefaultClientLimits = []ratelimit.Limit{
+	{Count: 5, Window: time.Second},
+	{Count: 60, Window: time.Minute},
+}
+
+// clientRateLimit rate limits requests with a separate limiter per client IP.
+// Rejected requests get a JSON error like every other response.
+func clientRateLimit(limits []ratelimit.Limit) func(http.Handler) http.Handler {
+	return ratelimit.Middleware(nil,
+		ratelimit.WithKeyLimits(clientIP, limits...),
+		ratelimit.WithRejectHandler(func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, http.StatusTooManyRequests, errorResponse{Error: "rate limit exceeded"})
+		}),
+	)
+}
+
+// clientIP is the request's remote address without the port. Forwarding headers
+// are ignored since they can be spoofed; behind a proxy all clients share one limit.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
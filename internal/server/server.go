@@ -0,0 +1,425 @@
+// Package server exposes player analysis, coaching and match data over a JSON REST API.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HatiCode/league-buddy/internal/analysis"
+	"github.com/HatiCode/league-buddy/internal/coaching"
+	"github.com/HatiCode/league-buddy/internal/models"
+	"github.com/HatiCode/league-buddy/internal/riot"
+	"github.com/HatiCode/league-buddy/internal/store"
+	"github.com/HatiCode/league-buddy/pkg/ratelimit"
+)
+
+const (
+	// defaultMatchCount is how many recent matches are analyzed without ?count=.
+	defaultMatchCount = 10
+
+	// benchmarkMatchLimit caps how many stored matches feed the rank benchmarks.
+	benchmarkMatchLimit = 500
+
+	// benchmarkTTL is how long built benchmarks are reused before the stored
+	// matches are read again. Building them analyzes every participant of every
+	// match, far too much work to repeat per request.
+	benchmarkTTL = 15 * time.Minute
+)
+
+// PlayerFetcher resolves Riot IDs and ranked standings.
+type PlayerFetcher interface {
+	riot.AccountFetcher
+	riot.LeagueFetcher
+}
+
+// Config holds the server's dependencies.
+type Config struct {
+	Players PlayerFetcher
+	Matches riot.MatchFetcher
+	// Store is optional; without it there are no benchmarks, coaching history or progress.
+	Store store.Store
+	// LLM is optional; without it the coach endpoint returns 503.
+	LLM   coaching.LLMClient
+	Rules *analysis.RuleSet
+
+	Platform string
+	Region   string
+
+	// ClientLimits are enforced per client IP; empty uses DefaultClientLimits.
+	ClientLimits []ratelimit.Limit
+	// Concurrency bounds parallel match fetches per request.
+	Concurrency int
+	// ErrorLog receives internal errors; nil uses the standard logger.
+	ErrorLog *log.Logger
+}
+
+// Server serves the REST API:
+//
+//	GET  /players/{riotId}/analysis   ?queue=solo&count=10
+//	POST /players/{riotId}/coach      ?queue=solo&count=10
+//	GET  /players/{riotId}/progress   ?queue=solo
+//	GET  /matches/{id}
+//	GET  /matches/{id}/timeline
+//
+// Riot IDs are gameName#tagLine with the # escaped as %23.
+type Server struct {
+	cfg     Config
+	handler http.Handler
+
+	// benchmarksMu is held while building, so concurrent requests wait for one build.
+	benchmarksMu    sync.Mutex
+	benchmarks      *analysis.Benchmarks
+	benchmarksBuilt time.Time
+}
+
+// New builds a server from cfg.
+func New(cfg Config) *Server {
+	if len(cfg.ClientLimits) == 0 {
+		cfg.ClientLimits = DefaultClientLimits
+	}
+	if cfg.ErrorLog == nil {
+		cfg.ErrorLog = log.Default()
+	}
+
+	s := &Server{cfg: cfg}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /players/{riotId}/analysis", s.handleAnalysis)
+	mux.HandleFunc("POST /players/{riotId}/coach", s.handleCoach)
+	mux.HandleFunc("GET /players/{riotId}/progress", s.handleProgress)
+	mux.HandleFunc("GET /matches/{id}", s.handleMatch)
+	mux.HandleFunc("GET /matches/{id}/timeline", s.handleTimeline)
+	s.handler = clientRateLimit(cfg.ClientLimits)(mux)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// playerRequest is a resolved player plus the query options shared by player endpoints.
+type playerRequest struct {
+	account *models.Account
+	queue   models.QueueGroup
+	count   int
+}
+
+func (s *Server) parsePlayerRequest(r *http.Request) (*playerRequest, error) {
+	parts := strings.SplitN(r.PathValue("riotId"), "#", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, badRequest("invalid Riot ID, expected gameName#tagLine with # escaped as %%23")
+	}
+
+	queueName := r.URL.Query().Get("queue")
+	if queueName == "" {
+		queueName = models.QueueGroupSolo
+	}
+	queue, err := models.QueueGroupByName(queueName)
+	if err != nil {
+		return nil, badRequest("%v", err)
+	}
+
+	count := defaultMatchCount
+	if c := r.URL.Query().Get("count"); c != "" {
+		count, err = strconv.Atoi(c)
+		if err != nil || count < 1 || count > riot.MaxMatchIDsPerPage {
+			return nil, badRequest("count must be between 1 and %d", riot.MaxMatchIDsPerPage)
+		}
+	}
+
+	account, err := s.cfg.Players.GetAccountByRiotID(r.Context(), s.cfg.Region, parts[0], parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("get account: %w", err)
+	}
+	return &playerRequest{account: account, queue: queue, count: count}, nil
+}
+
+// analyzePlayer analyzes the player's recent matches in the requested queue,
+// leaving out those in skip. It returns the analysis and the analyzed match IDs,
+// most recent first.
+func (s *Server) analyzePlayer(ctx context.Context, p *playerRequest, skip map[string]bool) (*analysis.PlayerAnalysis, []string, error) {
+	entries, err := s.cfg.Players.GetLeagueEntries(ctx, s.cfg.Platform, p.account.PUUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get league entries: %w", err)
+	}
+	var league *models.LeagueEntry
+	for i := range entries {
+		if entries[i].QueueType == p.queue.LeagueQueue {
+			league = &entries[i]
+			break
+		}
+	}
+
+	allIDs, err := riot.GetMatchIDsInQueues(ctx, s.cfg.Matches, s.cfg.Platform, p.account.PUUID, p.queue.QueueIDs, riot.MatchIDsOptions{Count: p.count})
+	if err != nil {
+		return nil, nil, fmt.Errorf("get match IDs: %w", err)
+	}
+	var ids []string
+	for _, id := range allIDs {
+		if !skip[id] {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		if len(allIDs) > 0 {
+			return nil, nil, &httpError{status: http.StatusConflict, message: "no new matches since the last coaching session"}
+		}
+		return nil, nil, &httpError{status: http.StatusNotFound, message: fmt.Sprintf("no %s matches found for this player", p.queue.Label)}
+	}
+
+	var matches []models.Match
+	var matchIDs []string
+	timelines := make(map[string]*models.Timeline)
+	var fetchErr error
+	for _, res := range riot.FetchMatchesWithTimelines(ctx, s.cfg.Matches, s.cfg.Platform, ids, s.cfg.Concurrency) {
+		if res.Err != nil {
+			fetchErr = res.Err
+			continue
+		}
+		matches = append(matches, *res.Match)
+		matchIDs = append(matchIDs, res.MatchID)
+		if res.Timeline != nil {
+			timelines[res.MatchID] = res.Timeline
+		}
+	}
+	if len(matches) == 0 {
+		return nil, nil, fmt.Errorf("fetch matches: %w", fetchErr)
+	}
+
+	// Benchmarks come from ranked Summoner's Rift games and only fit those queues.
+	var benchmarks *analysis.Benchmarks
+	if p.queue.Ranked() {
+		if benchmarks, err = s.loadBenchmarks(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+	pa, err := analysis.AnalyzePlayer(analysis.PlayerAnalysisParams{
+		PUUID:      p.account.PUUID,
+		GameName:   p.account.GameName,
+		TagLine:    p.account.TagLine,
+		Matches:    matches,
+		Timelines:  timelines,
+		League:     league,
+		Queue:      p.queue.Name,
+		Benchmarks: benchmarks,
+		Rules:      s.cfg.Rules,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("analyze matches: %w", err)
+	}
+	return pa, matchIDs, nil
+}
+
+// loadBenchmarks returns rank benchmarks built from stored matches, rebuilding
+// them once they are older than benchmarkTTL. Without a store it returns nil so
+// insights fall back to fixed thresholds.
+func (s *Server) loadBenchmarks(ctx context.Context) (*analysis.Benchmarks, error) {
+	if s.cfg.Store == nil {
+		return nil, nil
+	}
+
+	s.benchmarksMu.Lock()
+	defer s.benchmarksMu.Unlock()
+	if !s.benchmarksBuilt.IsZero() && time.Since(s.benchmarksBuilt) < benchmarkTTL {
+		return s.benchmarks, nil
+	}
+
+	stored, err := s.cfg.Store.GetTieredFullMatches(ctx, benchmarkMatchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("load benchmark matches: %w", err)
+	}
+	matches := make([]analysis.BenchmarkMatch, len(stored))
+	for i, m := range stored {
+		matches[i] = analysis.BenchmarkMatch{Tier: m.Tier, Match: m.Match}
+	}
+	s.benchmarks = analysis.BuildBenchmarks(matches)
+	s.benchmarksBuilt = time.Now()
+	return s.benchmarks, nil
+}
+
+func (s *Server) handleAnalysis(w http.ResponseWriter, r *http.Request) {
+	p, err := s.parsePlayerRequest(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	pa, _, err := s.analyzePlayer(r.Context(), p, nil)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, pa)
+}
+
+// coachResponse mirrors the coach command's output.
+type coachResponse struct {
+	Player   coachPlayer                `json:"player"`
+	Coaching *coaching.CoachingResponse `json:"coaching"`
+}
+
+type coachPlayer struct {
+	RiotID  string  `json:"riotId"`
+	Queue   string  `json:"queue"`
+	Tier    string  `json:"tier,omitempty"`
+	Rank    string  `json:"rank,omitempty"`
+	WinRate float64 `json:"winRate"`
+}
+
+func (s *Server) handleCoach(w http.ResponseWriter, r *http.Request) {
+	if s.cfg.LLM == nil {
+		s.writeError(w, &httpError{status: http.StatusServiceUnavailable, message: "coaching is disabled: no LLM API key configured"})
+		return
+	}
+	p, err := s.parsePlayerRequest(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	// Like the coach command, only matches newer than the last session are coached.
+	var previousIDs map[string]bool
+	if s.cfg.Store != nil {
+		prev, err := s.cfg.Store.GetLatestCoachingSession(r.Context(), p.account.PUUID, p.queue.Name)
+		if err != nil {
+			s.writeError(w, fmt.Errorf("get previous session: %w", err))
+			return
+		}
+		if prev != nil {
+			var ids []string
+			if err := json.Unmarshal(prev.MatchIDs, &ids); err == nil {
+				previousIDs = make(map[string]bool, len(ids))
+				for _, id := range ids {
+					previousIDs[id] = true
+				}
+			}
+		}
+	}
+
+	pa, matchIDs, err := s.analyzePlayer(r.Context(), p, previousIDs)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	resp, err := coaching.NewService(s.cfg.LLM, s.cfg.Store).Coach(r.Context(), pa, matchIDs)
+	if err != nil {
+		s.writeError(w, fmt.Errorf("coaching: %w", err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, coachResponse{
+		Player: coachPlayer{
+			RiotID:  p.account.GameName + "#" + p.account.TagLine,
+			Queue:   p.queue.Name,
+			Tier:    pa.Tier,
+			Rank:    pa.Rank,
+			WinRate: pa.WinRate,
+		},
+		Coaching: resp,
+	})
+}
+
+func (s *Server) handleProgress(w http.ResponseWriter, r *http.Request) {
+	if s.cfg.Store == nil {
+		s.writeError(w, &httpError{status: http.StatusServiceUnavailable, message: "progress tracking requires a database"})
+		return
+	}
+	p, err := s.parsePlayerRequest(r)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	progress, err := coaching.NewService(nil, s.cfg.Store).GetProgress(r.Context(), p.account.PUUID, p.queue.Name)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+	if progress.GameName == "" {
+		progress.GameName, progress.TagLine = p.account.GameName, p.account.TagLine
+	}
+	writeJSON(w, http.StatusOK, progress)
+}
+
+func (s *Server) handleMatch(w http.ResponseWriter, r *http.Request) {
+	match, err := s.cfg.Matches.GetMatch(r.Context(), s.cfg.Platform, r.PathValue("id"))
+	if err != nil {
+		s.writeError(w, fmt.Errorf("get match: %w", err))
+		return
+	}
+	writeJSON(w, http.StatusOK, match)
+}
+
+func (s *Server) handleTimeline(w http.ResponseWriter, r *http.Request) {
+	timeline, err := s.cfg.Matches.GetMatchTimeline(r.Context(), s.cfg.Platform, r.PathValue("id"))
+	if err != nil {
+		s.writeError(w, fmt.Errorf("get timeline: %w", err))
+		return
+	}
+	writeJSON(w, http.StatusOK, timeline)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// errorResponse is the body of every error response.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// httpError is a request-level failure with its own status code.
+type httpError struct {
+	status  int
+	message string
+}
+
+func (e *httpError) Error() string { return e.message }
+
+func badRequest(format string, args ...any) error {
+	return &httpError{status: http.StatusBadRequest, message: fmt.Sprintf(format, args...)}
+}
+
+// statusFor maps sentinel errors from the Riot client, store and analysis to HTTP statuses.
+func statusFor(err error) int {
+	var he *httpError
+	switch {
+	case errors.As(err, &he):
+		return he.status
+	case errors.Is(err, riot.ErrNotFound), errors.Is(err, store.ErrNotFound), errors.Is(err, analysis.ErrParticipantNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, riot.ErrInvalidRegion), errors.Is(err, analysis.ErrMixedMaps):
+		return http.StatusBadRequest
+	case errors.Is(err, analysis.ErrMatchTooShort):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, riot.ErrRateLimited):
+		// The server's Riot API key is throttled, not the client.
+		return http.StatusServiceUnavailable
+	case errors.Is(err, riot.ErrUnauthorized):
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+func (s *Server) writeError(w http.ResponseWriter, err error) {
+	status := statusFor(err)
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		// Internal errors can carry upstream URLs or SQL; log them, don't return them.
+		s.cfg.ErrorLog.Printf("internal error: %v", err)
+		message = http.StatusText(status)
+	}
+	if errors.Is(err, riot.ErrRateLimited) {
+		w.Header().Set("Retry-After", "10")
+	}
+	writeJSON(w, status, errorResponse{Error: message})
+}
@@ -0,0 +1,314 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+	"github.com/HatiCode/league-buddy/internal/riot"
+	"github.com/HatiCode/league-buddy/internal/server"
+	"github.com/HatiCode/league-buddy/internal/store"
+	"github.com/HatiCode/league-buddy/pkg/ratelimit"
+)
+
+const testPUUID = "puuid-faker"
+
+type fakePlayers struct{}
+
+func (fakePlayers) GetAccountByRiotID(_ context.Context, _, gameName, tagLine string) (*models.Account, error) {
+	if gameName != "Faker" || tagLine != "KR1" {
+		return nil, riot.ErrNotFound
+	}
+	return &models.Account{PUUID: testPUUID, GameName: gameName, TagLine: tagLine}, nil
+}
+
+func (fakePlayers) GetLeagueEntries(_ context.Context, _, _ string) ([]models.LeagueEntry, error) {
+	return []models.LeagueEntry{
+		{QueueType: models.QueueRankedFlex, Tier: "SILVER", Rank: "I"},
+		{QueueType: models.QueueRankedSolo, Tier: "GOLD", Rank: "II"},
+	}, nil
+}
+
+type fakeMatches struct {
+	byQueue     map[int][]string
+	matches     map[string]*models.Match
+	timelineErr error
+}
+
+func (f *fakeMatches) GetMatchIDs(_ context.Context, _, _ string, opts riot.MatchIDsOptions) ([]string, error) {
+	return f.byQueue[opts.Queue], nil
+}
+
+func (f *fakeMatches) GetMatch(_ context.Context, _, matchID string) (*models.Match, error) {
+	m, ok := f.matches[matchID]
+	if !ok {
+		return nil, riot.ErrNotFound
+	}
+	return m, nil
+}
+
+func (f *fakeMatches) GetMatchTimeline(_ context.Context, _, _ string) (*models.Timeline, error) {
+	if f.timelineErr != nil {
+		return nil, f.timelineErr
+	}
+	return nil, riot.ErrNotFound
+}
+
+type fakeLLM struct{}
+
+func (fakeLLM) Complete(_ context.Context, _, _ string) (string, error) {
+	return "Ward more.", nil
+}
+
+// fakeStore implements the store methods the server uses; others panic.
+type fakeStore struct {
+	store.Store
+	sessions       []store.CoachingSession
+	benchmarkLoads int
+}
+
+func (s *fakeStore) GetTieredFullMatches(_ context.Context, _ int) ([]store.TieredMatch, error) {
+	s.benchmarkLoads++
+	return nil, nil
+}
+
+func (s *fakeStore) GetLatestCoachingSession(_ context.Context, puuid, queue string) (*store.CoachingSession, error) {
+	for i := len(s.sessions) - 1; i >= 0; i-- {
+		if s.sessions[i].PUUID == puuid && s.sessions[i].Queue == queue {
+			return &s.sessions[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeStore) GetCoachingSessions(_ context.Context, puuid, queue string) ([]store.CoachingSession, error) {
+	var out []store.CoachingSession
+	for _, session := range s.sessions {
+		if session.PUUID == puuid && session.Queue == queue {
+			out = append(out, session)
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeStore) SaveCoachingSession(_ context.Context, session *store.CoachingSession) error {
+	session.CreatedAt = time.Now()
+	s.sessions = append(s.sessions, *session)
+	return nil
+}
+
+func makeMatch(matchID string, queueID int, win bool) *models.Match {
+	return &models.Match{
+		Metadata: models.MatchMetadata{MatchID: matchID},
+		Info: models.MatchInfo{
+			GameDuration: 1800,
+			QueueID:      queueID,
+			Participants: []models.Participant{
+				{PUUID: testPUUID, ChampionName: "Ahri", TeamPosition: "MIDDLE", TeamID: 100, Kills: 5, Deaths: 2, Assists: 7, Win: win},
+				{PUUID: "enemy", ChampionName: "Zed", TeamPosition: "MIDDLE", TeamID: 200, Win: !win},
+			},
+		},
+	}
+}
+
+func newTestServer(cfg server.Config) (*server.Server, *fakeMatches) {
+	matches := &fakeMatches{
+		byQueue: map[int][]string{
+			models.QueueIDRankedSolo: {"KR_3", "KR_2"},
+			models.QueueIDARAM:       {"KR_4"},
+		},
+		matches: map[string]*models.Match{
+			"KR_2": makeMatch("KR_2", models.QueueIDRankedSolo, false),
+			"KR_3": makeMatch("KR_3", models.QueueIDRankedSolo, true),
+			"KR_4": makeMatch("KR_4", models.QueueIDARAM, true),
+		},
+	}
+	cfg.Players = fakePlayers{}
+	cfg.Matches = matches
+	cfg.Platform = riot.PlatformKR
+	if cfg.ClientLimits == nil {
+		// Every httptest request shares one RemoteAddr.
+		cfg.ClientLimits = []ratelimit.Limit{{Count: 1000, Window: time.Second}}
+	}
+	return server.New(cfg), matches
+}
+
+func do(t *testing.T, h http.Handler, method, target string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("%s %s: expected a JSON response, got %q: %s", method, target, ct, rec.Body)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("%s %s: invalid JSON: %v", method, target, err)
+	}
+	return rec, body
+}
+
+func TestAnalysis(t *testing.T) {
+	srv, _ := newTestServer(server.Config{})
+
+	rec, body := do(t, srv, http.MethodGet, "/players/Faker%23KR1/analysis")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %v", rec.Code, body)
+	}
+	if body["puuid"] != testPUUID || body["totalMatches"] != 2.0 || body["tier"] != "GOLD" || body["queue"] != "solo" {
+		t.Errorf("unexpected analysis: puuid=%v totalMatches=%v tier=%v queue=%v", body["puuid"], body["totalMatches"], body["tier"], body["queue"])
+	}
+
+	rec, body = do(t, srv, http.MethodGet, "/players/Faker%23KR1/analysis?queue=aram")
+	if rec.Code != http.StatusOK || body["map"] != models.MapHowlingAbyss {
+		t.Errorf("expected an ARAM analysis, got %d: map=%v", rec.Code, body["map"])
+	}
+}
+
+func TestAnalysis_ReusesBenchmarks(t *testing.T) {
+	st := &fakeStore{}
+	srv, _ := newTestServer(server.Config{Store: st})
+
+	for range 3 {
+		if rec, body := do(t, srv, http.MethodGet, "/players/Faker%23KR1/analysis"); rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %v", rec.Code, body)
+		}
+	}
+	if st.benchmarkLoads != 1 {
+		t.Errorf("expected benchmarks to be built once, got %d loads", st.benchmarkLoads)
+	}
+}
+
+func TestErrorStatuses(t *testing.T) {
+	srv, matches := newTestServer(server.Config{})
+	matches.timelineErr = riot.ErrRateLimited
+
+	tests := []struct {
+		method, target string
+		status         int
+		errContains    string
+	}{
+		{http.MethodGet, "/players/Nobody%23EUW/analysis", http.StatusNotFound, "not found"},
+		{http.MethodGet, "/players/Faker/analysis", http.StatusBadRequest, "gameName#tagLine"},
+		{http.MethodGet, "/players/Faker%23KR1/analysis?queue=urf", http.StatusBadRequest, "unknown queue"},
+		{http.MethodGet, "/players/Faker%23KR1/analysis?count=0", http.StatusBadRequest, "count"},
+		{http.MethodGet, "/players/Faker%23KR1/analysis?queue=flex", http.StatusNotFound, "no Ranked Flex matches"},
+		{http.MethodGet, "/players/Faker%23KR1/analysis?queue=normal", http.StatusNotFound, "no Normal games matches"},
+		{http.MethodGet, "/matches/KR_404", http.StatusNotFound, "not found"},
+		{http.MethodGet, "/matches/KR_2/timeline", http.StatusServiceUnavailable, "rate limited"},
+		{http.MethodPost, "/players/Faker%23KR1/coach", http.StatusServiceUnavailable, "no LLM"},
+		{http.MethodGet, "/players/Faker%23KR1/progress", http.StatusServiceUnavailable, "database"},
+	}
+	for _, tt := range tests {
+		rec, body := do(t, srv, tt.method, tt.target)
+		if rec.Code != tt.status {
+			t.Errorf("%s %s: expected %d, got %d: %v", tt.method, tt.target, tt.status, rec.Code, body)
+		}
+		if msg, _ := body["error"].(string); !strings.Contains(msg, tt.errContains) {
+			t.Errorf("%s %s: expected an error containing %q, got %q", tt.method, tt.target, tt.errContains, msg)
+		}
+	}
+}
+
+func TestAnalysis_AllQueuesSkipsARAM(t *testing.T) {
+	st := &fakeStore{}
+	srv, _ := newTestServer(server.Config{Store: st})
+
+	rec, body := do(t, srv, http.MethodGet, "/players/Faker%23KR1/analysis?queue=all")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %v", rec.Code, body)
+	}
+	if body["totalMatches"] != 2.0 || body["map"] != models.MapSummonersRift {
+		t.Errorf("expected the two Summoner's Rift matches, got totalMatches=%v map=%v", body["totalMatches"], body["map"])
+	}
+	if st.benchmarkLoads != 0 {
+		t.Errorf("expected no ranked benchmarks for unranked queues, got %d loads", st.benchmarkLoads)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	srv, _ := newTestServer(server.Config{})
+
+	rec, body := do(t, srv, http.MethodGet, "/matches/KR_3")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %v", rec.Code, body)
+	}
+	if metadata, _ := body["metadata"].(map[string]any); metadata["matchId"] != "KR_3" {
+		t.Errorf("unexpected match: %v", body)
+	}
+}
+
+func TestCoachAndProgress(t *testing.T) {
+	st := &fakeStore{}
+	srv, _ := newTestServer(server.Config{LLM: fakeLLM{}, Store: st})
+
+	rec, body := do(t, srv, http.MethodPost, "/players/Faker%23KR1/coach")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %v", rec.Code, body)
+	}
+	coaching, _ := body["coaching"].(map[string]any)
+	if coaching["advice"] != "Ward more." || coaching["newMatches"] != 2.0 {
+		t.Errorf("unexpected coaching: %v", body)
+	}
+	if len(st.sessions) != 1 || st.sessions[0].Queue != "solo" {
+		t.Fatalf("expected one solo session saved, got %+v", st.sessions)
+	}
+
+	// Both matches were coached, so there is nothing new.
+	rec, body = do(t, srv, http.MethodPost, "/players/Faker%23KR1/coach")
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 without new matches, got %d: %v", rec.Code, body)
+	}
+
+	rec, body = do(t, srv, http.MethodGet, "/players/Faker%23KR1/progress")
+	if rec.Code != http.StatusOK || body["sessions"] != 1.0 || body["gameName"] != "Faker" {
+		t.Errorf("unexpected progress, got %d: %v", rec.Code, body)
+	}
+
+	rec, body = do(t, srv, http.MethodGet, "/players/Faker%23KR1/progress?queue=flex")
+	if rec.Code != http.StatusOK || body["sessions"] != 0.0 {
+		t.Errorf("expected no flex sessions, got %d: %v", rec.Code, body)
+	}
+}
+
+func TestClientRateLimitPerIP(t *testing.T) {
+	srv, _ := newTestServer(server.Config{ClientLimits: []ratelimit.Limit{{Count: 1, Window: time.Minute}}})
+
+	request := func(ip string) int {
+		req := httptest.NewRequest(http.MethodGet, "/matches/KR_3", nil)
+		req.RemoteAddr = ip + ":52000"
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := request("10.0.0.1"); code != http.StatusOK {
+		t.Fatalf("first request: expected 200, got %d", code)
+	}
+	if code := request("10.0.0.1"); code != http.StatusTooManyRequests {
+		t.Errorf("second request from the same IP: expected 429, got %d", code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/matches/KR_3", nil)
+	req.RemoteAddr = "10.0.0.1:52000"
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	var body map[string]any
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("rate limited request: expected a JSON response, got %q", ct)
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body["error"] != "rate limit exceeded" {
+		t.Errorf("rate limited request: expected a JSON error, got %q (%v)", rec.Body, err)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("rate limited request: expected a Retry-After header")
+	}
+	if code := request("10.0.0.2"); code != http.StatusOK {
+		t.Errorf("request from another IP: expected 200, got %d", code)
+	}
+}
@@ -73,6 +73,7 @@ type SummonerMatch struct {
 type CoachingSession struct {
 	ID            int64     `db:"id"`
 	PUUID         string    `db:"puuid"`
+	Queue         string    `db:"queue"`
 	LatestMatchID string    `db:"latest_match_id"`
 	MatchIDs      []byte    `db:"match_ids"`
 	Analysis      []byte    `db:"analysis"`
@@ -74,3 +74,55 @@ func ParticipantsFromAPI(m *models.Match) []Participant {
 
 	return participants
 }
+
+// MatchToAPI rebuilds a Riot API match from stored entities.
+// Only the fields persisted in the store are populated.
+func MatchToAPI(m *Match, participants []Participant) *models.Match {
+	match := &models.Match{
+		Metadata: models.MatchMetadata{
+			MatchID:      m.MatchID,
+			Participants: make([]string, 0, len(participants)),
+		},
+		Info: models.MatchInfo{
+			PlatformID:       m.Platform,
+			QueueID:          m.QueueID,
+			GameMode:         m.GameMode,
+			GameDuration:     m.GameDuration,
+			GameVersion:      m.GameVersion,
+			GameEndTimestamp: m.GameEndedAt.UnixMilli(),
+			Participants:     make([]models.Participant, 0, len(participants)),
+		},
+	}
+
+	for _, p := range participants {
+		match.Metadata.Participants = append(match.Metadata.Participants, p.PUUID)
+		match.Info.Participants = append(match.Info.Participants, models.Participant{
+			PUUID:                       p.PUUID,
+			RiotIdGameName:              p.SummonerName,
+			ChampionID:                  p.ChampionID,
+			ChampionName:                p.ChampionName,
+			TeamID:                      p.TeamID,
+			TeamPosition:                p.TeamPosition,
+			Win:                         p.Win,
+			Kills:                       p.Kills,
+			Deaths:                      p.Deaths,
+			Assists:                     p.Assists,
+			TotalMinionsKilled:          p.TotalMinionsKilled,
+			NeutralMinionsKilled:        p.NeutralMinionsKilled,
+			VisionScore:                 p.VisionScore,
+			WardsPlaced:                 p.WardsPlaced,
+			WardsKilled:                 p.WardsKilled,
+			DetectorWardsPlaced:         p.DetectorWardsPlaced,
+			TotalDamageDealtToChampions: p.DamageDealt,
+			TotalDamageTaken:            p.DamageTaken,
+			GoldEarned:                  p.GoldEarned,
+			DragonKills:                 p.DragonKills,
+			BaronKills:                  p.BaronKills,
+			TurretKills:                 p.TurretKills,
+			FirstBloodKill:              p.FirstBloodKill,
+			FirstBloodAssist:            p.FirstBloodAssist,
+		})
+	}
+
+	return match
+}
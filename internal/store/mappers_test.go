@@ -2,6 +2,7 @@ package store_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/HatiCode/league-buddy/internal/models"
 	"github.com/HatiCode/league-buddy/internal/store"
@@ -174,3 +175,49 @@ func TestParticipantsFromAPI(t *testing.T) {
 		t.Error("expected FirstBloodKill to be true")
 	}
 }
+
+func TestMatchToAPI(t *testing.T) {
+	endedAt := time.UnixMilli(1700001800000)
+	match := &store.Match{
+		MatchID:      "EUW1_12345",
+		Platform:     "EUW1",
+		QueueID:      420,
+		GameMode:     "CLASSIC",
+		GameDuration: 1800,
+		GameVersion:  "13.24.1",
+		GameEndedAt:  endedAt,
+	}
+	participants := []store.Participant{
+		{PUUID: "puuid-1", SummonerName: "Player1", ChampionName: "Ahri", TeamID: 100, TeamPosition: "MIDDLE", Win: true, Kills: 10, DamageDealt: 25000},
+		{PUUID: "puuid-2", SummonerName: "Player2", ChampionName: "Jinx", TeamID: 200, TeamPosition: "BOTTOM"},
+	}
+
+	result := store.MatchToAPI(match, participants)
+
+	if result.Metadata.MatchID != "EUW1_12345" {
+		t.Errorf("expected MatchID EUW1_12345, got %s", result.Metadata.MatchID)
+	}
+	if result.Info.QueueID != 420 {
+		t.Errorf("expected QueueID 420, got %d", result.Info.QueueID)
+	}
+	if result.Info.GameEndTimestamp != endedAt.UnixMilli() {
+		t.Errorf("expected GameEndTimestamp %d, got %d", endedAt.UnixMilli(), result.Info.GameEndTimestamp)
+	}
+	if len(result.Info.Participants) != 2 {
+		t.Fatalf("expected 2 participants, got %d", len(result.Info.Participants))
+	}
+
+	p1 := result.Info.Participants[0]
+	if p1.RiotIdGameName != "Player1" {
+		t.Errorf("expected RiotIdGameName Player1, got %s", p1.RiotIdGameName)
+	}
+	if p1.TotalDamageDealtToChampions != 25000 {
+		t.Errorf("expected TotalDamageDealtToChampions 25000, got %d", p1.TotalDamageDealtToChampions)
+	}
+	if !p1.Win {
+		t.Error("expected Win to be true")
+	}
+	if result.Metadata.Participants[1] != "puuid-2" {
+		t.Errorf("expected second metadata participant puuid-2, got %s", result.Metadata.Participants[1])
+	}
+}
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Raw Riot API payloads, keyed by match ID, used as a read-through cache
+
+CREATE TABLE raw_matches (
+    match_id     VARCHAR(20) PRIMARY KEY,
+    raw_match    JSONB,
+    raw_timeline JSONB,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS raw_matches;
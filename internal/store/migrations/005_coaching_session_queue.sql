@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- Sessions before this migration were all Ranked Solo/Duo.
+ALTER TABLE coaching_sessions ADD COLUMN queue VARCHAR(16) NOT NULL DEFAULT 'solo';
+
+DROP INDEX IF EXISTS idx_coaching_sessions_puuid_created;
+CREATE INDEX idx_coaching_sessions_puuid_queue_created ON coaching_sessions (puuid, queue, created_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_coaching_sessions_puuid_queue_created;
+CREATE INDEX idx_coaching_sessions_puuid_created ON coaching_sessions (puuid, created_at DESC);
+ALTER TABLE coaching_sessions DROP COLUMN queue;
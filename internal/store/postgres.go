@@ -3,10 +3,14 @@ package store
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+
+	"github.com/HatiCode/league-buddy/internal/models"
 
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // PostgresStore implements Store using PostgreSQL.
@@ -119,6 +123,21 @@ func (s *PostgresStore) GetMatchesForSummoner(ctx context.Context, summonerID in
 	return matches, nil
 }
 
+func (s *PostgresStore) GetMatchesForPUUID(ctx context.Context, puuid string) ([]Match, error) {
+	var matches []Match
+	err := s.db.SelectContext(ctx, &matches, `
+		SELECT m.id, m.match_id, m.platform, m.queue_id, m.game_mode, m.game_duration, m.game_version, m.game_ended_at, m.created_at
+		FROM matches m
+		JOIN participants p ON m.id = p.match_id
+		WHERE p.puuid = $1
+		ORDER BY m.game_ended_at DESC
+	`, puuid)
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
 func (s *PostgresStore) GetParticipants(ctx context.Context, matchID int64) ([]Participant, error) {
 	var participants []Participant
 	err := s.db.SelectContext(ctx, &participants, `
@@ -222,17 +241,195 @@ func (s *PostgresStore) UnlinkOldestMatches(ctx context.Context, summonerID int6
 	return err
 }
 
+// --- Raw match cache operations ---
+
+func (s *PostgresStore) GetRawMatch(ctx context.Context, matchID string) ([]byte, error) {
+	return s.getRaw(ctx, "raw_match", matchID)
+}
+
+func (s *PostgresStore) SaveRawMatch(ctx context.Context, matchID string, data []byte) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO raw_matches (match_id, raw_match, created_at, updated_at)
+		VALUES ($1, $2, NOW(), NOW())
+		ON CONFLICT (match_id) DO UPDATE SET
+			raw_match = EXCLUDED.raw_match,
+			updated_at = NOW()
+	`, matchID, data)
+	return err
+}
+
+func (s *PostgresStore) GetRawTimeline(ctx context.Context, matchID string) ([]byte, error) {
+	return s.getRaw(ctx, "raw_timeline", matchID)
+}
+
+func (s *PostgresStore) SaveRawTimeline(ctx context.Context, matchID string, data []byte) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO raw_matches (match_id, raw_timeline, created_at, updated_at)
+		VALUES ($1, $2, NOW(), NOW())
+		ON CONFLICT (match_id) DO UPDATE SET
+			raw_timeline = EXCLUDED.raw_timeline,
+			updated_at = NOW()
+	`, matchID, data)
+	return err
+}
+
+// getRaw reads one raw JSON column; column is always a trusted constant.
+func (s *PostgresStore) getRaw(ctx context.Context, column, matchID string) ([]byte, error) {
+	var data []byte
+	err := s.db.GetContext(ctx, &data, `SELECT `+column+` FROM raw_matches WHERE match_id = $1`, matchID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// --- Full match operations ---
+
+// SaveFullMatch stores match only when no payload is stored for it yet. The match
+// cache usually got there first with Riot's verbatim response, which keeps fields
+// models.Match doesn't decode, so it must not be replaced by this re-encoded copy.
+func (s *PostgresStore) SaveFullMatch(ctx context.Context, match *models.Match) error {
+	data, err := json.Marshal(match)
+	if err != nil {
+		return fmt.Errorf("encode match: %w", err)
+	}
+	return s.saveRawIfAbsent(ctx, "raw_match", match.Metadata.MatchID, data)
+}
+
+func (s *PostgresStore) GetFullMatch(ctx context.Context, matchID string) (*models.Match, error) {
+	data, err := s.GetRawMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	var match models.Match
+	if err := json.Unmarshal(data, &match); err != nil {
+		return nil, fmt.Errorf("decode match %s: %w", matchID, err)
+	}
+	return &match, nil
+}
+
+// GetFullMatchesForPUUID returns every stored full match the player took part in, most recent first.
+func (s *PostgresStore) GetFullMatchesForPUUID(ctx context.Context, puuid string) ([]models.Match, error) {
+	var rows [][]byte
+	err := s.db.SelectContext(ctx, &rows, `
+		SELECT r.raw_match FROM raw_matches r
+		JOIN matches m ON m.match_id = r.match_id
+		JOIN participants p ON p.match_id = m.id
+		WHERE p.puuid = $1 AND r.raw_match IS NOT NULL
+		ORDER BY m.game_ended_at DESC
+	`, puuid)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]models.Match, 0, len(rows))
+	for _, data := range rows {
+		var match models.Match
+		if err := json.Unmarshal(data, &match); err != nil {
+			return nil, fmt.Errorf("decode match: %w", err)
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// GetTieredFullMatches returns up to limit of the most recent ranked Summoner's Rift
+// full matches, each with the highest tier among the tracked summoners linked to it.
+// A limit of zero or less returns all of them.
+func (s *PostgresStore) GetTieredFullMatches(ctx context.Context, limit int) ([]TieredMatch, error) {
+	var rows []struct {
+		Tier     string `db:"tier"`
+		RawMatch []byte `db:"raw_match"`
+	}
+	// Tier names don't sort by rank, so the highest is picked by its position in
+	// models.Tiers ($1); tiers not in the list are ignored.
+	query := `
+		SELECT COALESCE(($1::text[])[MAX(array_position($1::text[], UPPER(s.tier)))], '') AS tier, r.raw_match
+		FROM raw_matches r
+		JOIN matches m ON m.match_id = r.match_id
+		LEFT JOIN summoner_matches sm ON sm.match_id = m.id
+		LEFT JOIN summoners s ON s.id = sm.summoner_id
+		WHERE r.raw_match IS NOT NULL AND m.queue_id = ANY($2)
+		GROUP BY r.match_id, m.game_ended_at
+		ORDER BY m.game_ended_at DESC
+	`
+	args := []any{pq.Array(models.Tiers), pq.Array(models.RankedQueueIDs())}
+	if limit > 0 {
+		query += " LIMIT $3"
+		args = append(args, limit)
+	}
+	if err := s.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	matches := make([]TieredMatch, 0, len(rows))
+	for _, row := range rows {
+		var match models.Match
+		if err := json.Unmarshal(row.RawMatch, &match); err != nil {
+			return nil, fmt.Errorf("decode match: %w", err)
+		}
+		matches = append(matches, TieredMatch{Tier: row.Tier, Match: match})
+	}
+	return matches, nil
+}
+
+// SaveTimeline stores timeline only when none is stored yet, for the same reason as SaveFullMatch.
+func (s *PostgresStore) SaveTimeline(ctx context.Context, matchID string, timeline *models.Timeline) error {
+	data, err := json.Marshal(timeline)
+	if err != nil {
+		return fmt.Errorf("encode timeline: %w", err)
+	}
+	return s.saveRawIfAbsent(ctx, "raw_timeline", matchID, data)
+}
+
+// saveRawIfAbsent fills one raw JSON column unless it already holds a payload;
+// column is always a trusted constant.
+func (s *PostgresStore) saveRawIfAbsent(ctx context.Context, column, matchID string, data []byte) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO raw_matches (match_id, `+column+`, created_at, updated_at)
+		VALUES ($1, $2, NOW(), NOW())
+		ON CONFLICT (match_id) DO UPDATE SET
+			`+column+` = EXCLUDED.`+column+`,
+			updated_at = NOW()
+		WHERE raw_matches.`+column+` IS NULL
+	`, matchID, data)
+	return err
+}
+
+func (s *PostgresStore) GetTimeline(ctx context.Context, matchID string) (*models.Timeline, error) {
+	data, err := s.GetRawTimeline(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	var timeline models.Timeline
+	if err := json.Unmarshal(data, &timeline); err != nil {
+		return nil, fmt.Errorf("decode timeline %s: %w", matchID, err)
+	}
+	return &timeline, nil
+}
+
 // --- Coaching session operations ---
 
-func (s *PostgresStore) GetLatestCoachingSession(ctx context.Context, puuid string) (*CoachingSession, error) {
+func (s *PostgresStore) GetLatestCoachingSession(ctx context.Context, puuid, queue string) (*CoachingSession, error) {
 	var session CoachingSession
 	err := s.db.GetContext(ctx, &session, `
-		SELECT id, puuid, latest_match_id, match_ids, analysis, advice, created_at
+		SELECT id, puuid, queue, latest_match_id, match_ids, analysis, advice, created_at
 		FROM coaching_sessions
-		WHERE puuid = $1
+		WHERE puuid = $1 AND queue = $2
 		ORDER BY created_at DESC
 		LIMIT 1
-	`, puuid)
+	`, puuid, queue)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -242,14 +439,14 @@ func (s *PostgresStore) GetLatestCoachingSession(ctx context.Context, puuid stri
 	return &session, nil
 }
 
-func (s *PostgresStore) GetCoachingSessions(ctx context.Context, puuid string) ([]CoachingSession, error) {
+func (s *PostgresStore) GetCoachingSessions(ctx context.Context, puuid, queue string) ([]CoachingSession, error) {
 	var sessions []CoachingSession
 	err := s.db.SelectContext(ctx, &sessions, `
-		SELECT id, puuid, latest_match_id, match_ids, analysis, advice, created_at
+		SELECT id, puuid, queue, latest_match_id, match_ids, analysis, advice, created_at
 		FROM coaching_sessions
-		WHERE puuid = $1
+		WHERE puuid = $1 AND queue = $2
 		ORDER BY created_at ASC
-	`, puuid)
+	`, puuid, queue)
 	if err != nil {
 		return nil, err
 	}
@@ -258,22 +455,45 @@ func (s *PostgresStore) GetCoachingSessions(ctx context.Context, puuid string) (
 
 func (s *PostgresStore) SaveCoachingSession(ctx context.Context, session *CoachingSession) error {
 	return s.db.QueryRowxContext(ctx, `
-		INSERT INTO coaching_sessions (puuid, latest_match_id, match_ids, analysis, advice, created_at)
-		VALUES ($1, $2, $3, $4, $5, NOW())
+		INSERT INTO coaching_sessions (puuid, queue, latest_match_id, match_ids, analysis, advice, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
 		RETURNING id, created_at
-	`, session.PUUID, session.LatestMatchID, session.MatchIDs, session.Analysis, session.Advice).
+	`, session.PUUID, session.Queue, session.LatestMatchID, session.MatchIDs, session.Analysis, session.Advice).
 		Scan(&session.ID, &session.CreatedAt)
 }
 
 // --- Cleanup operations ---
 
+// DeleteOrphanedMatches removes matches no summoner is linked to, along with their
+// stored raw payloads.
 func (s *PostgresStore) DeleteOrphanedMatches(ctx context.Context) (int64, error) {
-	result, err := s.db.ExecContext(ctx, `
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM raw_matches
+		WHERE match_id IN (
+			SELECT match_id FROM matches
+			WHERE id NOT IN (SELECT DISTINCT match_id FROM summoner_matches)
+		)
+	`)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, `
 		DELETE FROM matches
 		WHERE id NOT IN (SELECT DISTINCT match_id FROM summoner_matches)
 	`)
 	if err != nil {
 		return 0, err
 	}
-	return result.RowsAffected()
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return deleted, tx.Commit()
 }
@@ -2,10 +2,14 @@ package store_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/HatiCode/league-buddy/internal/models"
 	"github.com/HatiCode/league-buddy/internal/store"
 )
 
@@ -195,6 +199,47 @@ func TestPostgres_SaveMatchAndParticipants(t *testing.T) {
 	}
 }
 
+func TestPostgres_GetMatchesForPUUID(t *testing.T) {
+	dsn := skipIfNoDatabase(t)
+	ctx := context.Background()
+
+	db, err := store.NewPostgresStore(ctx, dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer db.Close()
+
+	ts := time.Now().Format("20060102150405")
+	puuid := "by-puuid-test-" + ts
+	for i := 0; i < 2; i++ {
+		match := &store.Match{
+			MatchID:      "BYPUUID_" + ts + "_" + string(rune('A'+i)),
+			Platform:     "EUW1",
+			QueueID:      420,
+			GameMode:     "CLASSIC",
+			GameDuration: 1800,
+			GameEndedAt:  time.Now().Add(time.Duration(i) * time.Minute),
+		}
+		participants := []store.Participant{
+			{PUUID: puuid, SummonerName: "Player1", ChampionName: "Ahri", TeamID: 100, TeamPosition: "MIDDLE"},
+		}
+		if err := db.SaveMatch(ctx, match, participants); err != nil {
+			t.Fatalf("SaveMatch failed: %v", err)
+		}
+	}
+
+	matches, err := db.GetMatchesForPUUID(ctx, puuid)
+	if err != nil {
+		t.Fatalf("GetMatchesForPUUID failed: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].MatchID != "BYPUUID_"+ts+"_B" {
+		t.Errorf("expected most recent match first, got %s", matches[0].MatchID)
+	}
+}
+
 func TestPostgres_LinkSummonerMatch(t *testing.T) {
 	dsn := skipIfNoDatabase(t)
 	ctx := context.Background()
@@ -335,6 +380,9 @@ func TestPostgres_DeleteOrphanedMatches(t *testing.T) {
 	if err != nil {
 		t.Fatalf("SaveMatch failed: %v", err)
 	}
+	if err := db.SaveRawMatch(ctx, match.MatchID, []byte(`{"info":{}}`)); err != nil {
+		t.Fatalf("SaveRawMatch failed: %v", err)
+	}
 
 	// Run cleanup
 	deleted, err := db.DeleteOrphanedMatches(ctx)
@@ -350,4 +398,275 @@ func TestPostgres_DeleteOrphanedMatches(t *testing.T) {
 	if err != store.ErrNotFound {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
+	if data, err := db.GetRawMatch(ctx, match.MatchID); err != nil || data != nil {
+		t.Errorf("expected raw match to be deleted, got data=%s err=%v", data, err)
+	}
+}
+
+func TestPostgres_RawMatchCache(t *testing.T) {
+	dsn := skipIfNoDatabase(t)
+	ctx := context.Background()
+
+	db, err := store.NewPostgresStore(ctx, dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer db.Close()
+
+	matchID := "RAW_" + time.Now().Format("20060102150405")
+
+	data, err := db.GetRawMatch(ctx, matchID)
+	if err != nil || data != nil {
+		t.Fatalf("expected miss, got data=%s err=%v", data, err)
+	}
+
+	if err := db.SaveRawTimeline(ctx, matchID, []byte(`{"frames":[]}`)); err != nil {
+		t.Fatalf("SaveRawTimeline failed: %v", err)
+	}
+	if data, err := db.GetRawMatch(ctx, matchID); err != nil || data != nil {
+		t.Errorf("expected match miss when only timeline is stored, got data=%s err=%v", data, err)
+	}
+
+	if err := db.SaveRawMatch(ctx, matchID, []byte(`{"info":{}}`)); err != nil {
+		t.Fatalf("SaveRawMatch failed: %v", err)
+	}
+	if data, err := db.GetRawMatch(ctx, matchID); err != nil || len(data) == 0 {
+		t.Errorf("expected stored match, got data=%s err=%v", data, err)
+	}
+	if data, err := db.GetRawTimeline(ctx, matchID); err != nil || len(data) == 0 {
+		t.Errorf("expected timeline to survive match upsert, got data=%s err=%v", data, err)
+	}
+}
+
+func TestPostgres_FullMatchRoundTrip(t *testing.T) {
+	dsn := skipIfNoDatabase(t)
+	ctx := context.Background()
+
+	db, err := store.NewPostgresStore(ctx, dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer db.Close()
+
+	ts := time.Now().Format("20060102150405")
+	puuid := "full-match-test-" + ts
+	apiMatch := &models.Match{
+		Metadata: models.MatchMetadata{MatchID: "FULL_" + ts, Participants: []string{puuid}},
+		Info: models.MatchInfo{
+			GameDuration:     1800,
+			GameEndTimestamp: time.Now().UnixMilli(),
+			QueueID:          420,
+			Participants: []models.Participant{
+				{PUUID: puuid, ChampionName: "Ahri", TeamID: 100, Challenges: &models.Challenges{KillParticipation: 0.6}},
+			},
+			Teams: []models.Team{{TeamID: 100, Win: true, Bans: []models.Ban{{ChampionID: 157, PickTurn: 1}}}},
+		},
+	}
+
+	if _, err := db.GetFullMatch(ctx, apiMatch.Metadata.MatchID); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if err := db.SaveMatch(ctx, store.MatchFromAPI(apiMatch), store.ParticipantsFromAPI(apiMatch)); err != nil {
+		t.Fatalf("SaveMatch failed: %v", err)
+	}
+	if err := db.SaveFullMatch(ctx, apiMatch); err != nil {
+		t.Fatalf("SaveFullMatch failed: %v", err)
+	}
+	timeline := &models.Timeline{Info: models.TimelineInfo{FrameInterval: 60000}}
+	if err := db.SaveTimeline(ctx, apiMatch.Metadata.MatchID, timeline); err != nil {
+		t.Fatalf("SaveTimeline failed: %v", err)
+	}
+
+	got, err := db.GetFullMatch(ctx, apiMatch.Metadata.MatchID)
+	if err != nil {
+		t.Fatalf("GetFullMatch failed: %v", err)
+	}
+	if got.Info.Participants[0].Challenges == nil || got.Info.Participants[0].Challenges.KillParticipation != 0.6 {
+		t.Error("expected challenges to survive the round trip")
+	}
+	if len(got.Info.Teams) != 1 || len(got.Info.Teams[0].Bans) != 1 {
+		t.Error("expected teams and bans to survive the round trip")
+	}
+
+	gotTimeline, err := db.GetTimeline(ctx, apiMatch.Metadata.MatchID)
+	if err != nil {
+		t.Fatalf("GetTimeline failed: %v", err)
+	}
+	if gotTimeline.Info.FrameInterval != 60000 {
+		t.Errorf("expected frame interval 60000, got %d", gotTimeline.Info.FrameInterval)
+	}
+
+	byPUUID, err := db.GetFullMatchesForPUUID(ctx, puuid)
+	if err != nil {
+		t.Fatalf("GetFullMatchesForPUUID failed: %v", err)
+	}
+	if len(byPUUID) != 1 || byPUUID[0].Metadata.MatchID != apiMatch.Metadata.MatchID {
+		t.Errorf("expected the saved match for %s, got %d matches", puuid, len(byPUUID))
+	}
+}
+
+func TestPostgres_SaveFullMatchKeepsRawPayload(t *testing.T) {
+	dsn := skipIfNoDatabase(t)
+	ctx := context.Background()
+
+	db, err := store.NewPostgresStore(ctx, dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer db.Close()
+
+	matchID := "KEEP_" + time.Now().Format("20060102150405")
+	raw := `{"metadata":{"matchId":"` + matchID + `"},"info":{"participants":[{"perks":{"styles":[]}}]}}`
+	if err := db.SaveRawMatch(ctx, matchID, []byte(raw)); err != nil {
+		t.Fatalf("SaveRawMatch failed: %v", err)
+	}
+	if err := db.SaveRawTimeline(ctx, matchID, []byte(`{"info":{"frameInterval":60000,"unknown":1}}`)); err != nil {
+		t.Fatalf("SaveRawTimeline failed: %v", err)
+	}
+
+	match, err := db.GetFullMatch(ctx, matchID)
+	if err != nil {
+		t.Fatalf("GetFullMatch failed: %v", err)
+	}
+	if err := db.SaveFullMatch(ctx, match); err != nil {
+		t.Fatalf("SaveFullMatch failed: %v", err)
+	}
+	if err := db.SaveTimeline(ctx, matchID, &models.Timeline{}); err != nil {
+		t.Fatalf("SaveTimeline failed: %v", err)
+	}
+
+	data, err := db.GetRawMatch(ctx, matchID)
+	if err != nil || !strings.Contains(string(data), `"perks"`) {
+		t.Errorf("expected the verbatim match to be kept, got data=%s err=%v", data, err)
+	}
+	data, err = db.GetRawTimeline(ctx, matchID)
+	if err != nil || !strings.Contains(string(data), `"unknown"`) {
+		t.Errorf("expected the verbatim timeline to be kept, got data=%s err=%v", data, err)
+	}
+}
+
+func TestPostgres_TieredFullMatches(t *testing.T) {
+	dsn := skipIfNoDatabase(t)
+	ctx := context.Background()
+
+	db, err := store.NewPostgresStore(ctx, dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer db.Close()
+
+	ts := time.Now().Format("20060102150405")
+
+	// SILVER sorts after PLATINUM alphabetically, so this catches name ordering.
+	var summoners []*store.Summoner
+	for i, tier := range []string{"SILVER", "PLATINUM", "GOLD"} {
+		puuid := fmt.Sprintf("tiered-match-test-%s-%d", ts, i)
+		if err := db.UpsertSummoner(ctx, &store.Summoner{PUUID: puuid, GameName: fmt.Sprintf("Tiered%s%d", ts[8:], i), TagLine: "EUW", Platform: "euw1", Tier: tier, Rank: "II"}); err != nil {
+			t.Fatalf("UpsertSummoner failed: %v", err)
+		}
+		summoner, err := db.GetSummonerByPUUID(ctx, puuid)
+		if err != nil {
+			t.Fatalf("GetSummonerByPUUID failed: %v", err)
+		}
+		summoners = append(summoners, summoner)
+	}
+
+	saveLinked := func(matchID string, queueID int) {
+		t.Helper()
+		apiMatch := &models.Match{
+			Metadata: models.MatchMetadata{MatchID: matchID},
+			Info: models.MatchInfo{
+				GameDuration:     1800,
+				GameEndTimestamp: time.Now().UnixMilli(),
+				QueueID:          queueID,
+				Participants:     []models.Participant{{PUUID: summoners[0].PUUID, ChampionName: "Ahri", TeamID: 100}},
+			},
+		}
+		if err := db.SaveMatch(ctx, store.MatchFromAPI(apiMatch), store.ParticipantsFromAPI(apiMatch)); err != nil {
+			t.Fatalf("SaveMatch failed: %v", err)
+		}
+		if err := db.SaveFullMatch(ctx, apiMatch); err != nil {
+			t.Fatalf("SaveFullMatch failed: %v", err)
+		}
+		saved, err := db.GetMatchByRiotID(ctx, matchID)
+		if err != nil {
+			t.Fatalf("GetMatchByRiotID failed: %v", err)
+		}
+		for _, summoner := range summoners {
+			if err := db.LinkSummonerMatch(ctx, summoner.ID, saved.ID); err != nil {
+				t.Fatalf("LinkSummonerMatch failed: %v", err)
+			}
+		}
+	}
+	rankedID, normalID := "TIER_"+ts, "TIERN_"+ts
+	saveLinked(rankedID, models.QueueIDRankedSolo)
+	saveLinked(normalID, models.QueueIDNormalDraft)
+
+	tiered, err := db.GetTieredFullMatches(ctx, 0)
+	if err != nil {
+		t.Fatalf("GetTieredFullMatches failed: %v", err)
+	}
+	found := false
+	for _, m := range tiered {
+		switch m.Match.Metadata.MatchID {
+		case rankedID:
+			found = true
+			if m.Tier != "PLATINUM" {
+				t.Errorf("expected the highest tier PLATINUM, got %q", m.Tier)
+			}
+		case normalID:
+			t.Errorf("expected normal game %s to be left out", normalID)
+		}
+	}
+	if !found {
+		t.Errorf("expected %s among tiered matches", rankedID)
+	}
+}
+
+func TestPostgres_CoachingSessionsPerQueue(t *testing.T) {
+	dsn := skipIfNoDatabase(t)
+	ctx := context.Background()
+
+	db, err := store.NewPostgresStore(ctx, dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer db.Close()
+
+	puuid := "coaching-queue-test-" + time.Now().Format("20060102150405")
+	for _, queue := range []string{"solo", "flex", "solo"} {
+		session := &store.CoachingSession{
+			PUUID:         puuid,
+			Queue:         queue,
+			LatestMatchID: "EUW1_1",
+			MatchIDs:      []byte(`["EUW1_1"]`),
+			Analysis:      []byte(`{}`),
+			Advice:        "advice for " + queue,
+		}
+		if err := db.SaveCoachingSession(ctx, session); err != nil {
+			t.Fatalf("SaveCoachingSession failed: %v", err)
+		}
+	}
+
+	solo, err := db.GetCoachingSessions(ctx, puuid, "solo")
+	if err != nil {
+		t.Fatalf("GetCoachingSessions failed: %v", err)
+	}
+	if len(solo) != 2 {
+		t.Errorf("expected 2 solo sessions, got %d", len(solo))
+	}
+
+	latest, err := db.GetLatestCoachingSession(ctx, puuid, "flex")
+	if err != nil {
+		t.Fatalf("GetLatestCoachingSession failed: %v", err)
+	}
+	if latest == nil || latest.Queue != "flex" || latest.Advice != "advice for flex" {
+		t.Errorf("expected the flex session, got %+v", latest)
+	}
+
+	none, err := db.GetLatestCoachingSession(ctx, puuid, "aram")
+	if err != nil || none != nil {
+		t.Errorf("expected no aram session, got %+v, %v", none, err)
+	}
 }
@@ -1,6 +1,10 @@
 package store
 
-import "context"
+import (
+	"context"
+
+	"github.com/HatiCode/league-buddy/internal/models"
+)
 
 // SummonerReader retrieves summoner data.
 type SummonerReader interface {
@@ -23,6 +27,7 @@ type SummonerRepository interface {
 type MatchReader interface {
 	GetMatchByRiotID(ctx context.Context, matchID string) (*Match, error)
 	GetMatchesForSummoner(ctx context.Context, summonerID int64) ([]Match, error)
+	GetMatchesForPUUID(ctx context.Context, puuid string) ([]Match, error)
 	GetParticipants(ctx context.Context, matchID int64) ([]Participant, error)
 	GetParticipantByPUUID(ctx context.Context, matchID int64, puuid string) (*Participant, error)
 }
@@ -40,10 +45,40 @@ type MatchRepository interface {
 	MatchWriter
 }
 
-// CoachingSessionReader retrieves coaching session data.
+// RawMatchCache stores raw Riot API match and timeline JSON keyed by match ID.
+// Get methods return nil data and no error when nothing is stored.
+type RawMatchCache interface {
+	GetRawMatch(ctx context.Context, matchID string) ([]byte, error)
+	SaveRawMatch(ctx context.Context, matchID string, data []byte) error
+	GetRawTimeline(ctx context.Context, matchID string) ([]byte, error)
+	SaveRawTimeline(ctx context.Context, matchID string, data []byte) error
+}
+
+// FullMatchRepository persists complete Riot API matches and timelines, including
+// the challenges, items, runes, teams and events the match tables leave out,
+// so analysis can be re-run from the database alone. Save methods never replace a
+// payload that is already stored.
+type FullMatchRepository interface {
+	SaveFullMatch(ctx context.Context, match *models.Match) error
+	GetFullMatch(ctx context.Context, matchID string) (*models.Match, error)
+	GetFullMatchesForPUUID(ctx context.Context, puuid string) ([]models.Match, error)
+	GetTieredFullMatches(ctx context.Context, limit int) ([]TieredMatch, error)
+	SaveTimeline(ctx context.Context, matchID string, timeline *models.Timeline) error
+	GetTimeline(ctx context.Context, matchID string) (*models.Timeline, error)
+}
+
+// TieredMatch is a stored ranked match with the highest tier among the tracked
+// summoners who played in it. Tier is empty when none of them has a known rank.
+type TieredMatch struct {
+	Tier  string
+	Match models.Match
+}
+
+// CoachingSessionReader retrieves coaching session data. Sessions are kept
+// per queue group (see models.QueueGroup), so each queue has its own history.
 type CoachingSessionReader interface {
-	GetLatestCoachingSession(ctx context.Context, puuid string) (*CoachingSession, error)
-	GetCoachingSessions(ctx context.Context, puuid string) ([]CoachingSession, error)
+	GetLatestCoachingSession(ctx context.Context, puuid, queue string) (*CoachingSession, error)
+	GetCoachingSessions(ctx context.Context, puuid, queue string) ([]CoachingSession, error)
 }
 
 // CoachingSessionWriter persists coaching session data.
@@ -66,6 +101,8 @@ type CleanupService interface {
 type Store interface {
 	SummonerRepository
 	MatchRepository
+	RawMatchCache
+	FullMatchRepository
 	CoachingSessionRepository
 	CleanupService
 }
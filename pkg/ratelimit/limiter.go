@@ -2,10 +2,19 @@ package ratelimit
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
+// Limit describes a rate limit of Count requests per Window.
+type Limit struct {
+	Count  int
+	Window time.Duration
+}
+
 // rule defines a rate limit: max requests within a time window.
 type rule struct {
 	limit  int
@@ -20,8 +29,9 @@ type bucket struct {
 
 // Limiter enforces rate limits using a sliding window algorithm.
 type Limiter struct {
-	mu      sync.Mutex
-	buckets []*bucket
+	mu          sync.Mutex
+	buckets     []*bucket
+	pausedUntil time.Time
 }
 
 // Option configures a Limiter.
@@ -50,16 +60,22 @@ func NewLimiter(opts ...Option) *Limiter {
 
 // Wait blocks until a request is allowed or the context is done.
 func (l *Limiter) Wait(ctx context.Context) error {
+	_, err := l.wait(ctx)
+	return err
+}
+
+// wait is Wait returning the time the slot was acquired, for release.
+func (l *Limiter) wait(ctx context.Context) (time.Time, error) {
 	for {
-		if l.TryAcquire() {
-			return nil
+		if at, ok := l.acquire(); ok {
+			return at, nil
 		}
 
 		waitDuration := l.nextAvailable()
 
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return time.Time{}, ctx.Err()
 		case <-time.After(waitDuration):
 		}
 	}
@@ -68,15 +84,24 @@ func (l *Limiter) Wait(ctx context.Context) error {
 // TryAcquire attempts to acquire a slot without blocking.
 // Returns true if allowed, false if rate limited.
 func (l *Limiter) TryAcquire() bool {
+	_, ok := l.acquire()
+	return ok
+}
+
+// acquire is TryAcquire returning the time the slot was acquired.
+func (l *Limiter) acquire() (time.Time, bool) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
 	now := time.Now()
+	if now.Before(l.pausedUntil) {
+		return time.Time{}, false
+	}
 
 	for _, b := range l.buckets {
 		l.pruneExpired(b, now)
 		if len(b.timestamps) >= b.rule.limit {
-			return false
+			return time.Time{}, false
 		}
 	}
 
@@ -84,7 +109,22 @@ func (l *Limiter) TryAcquire() bool {
 		b.timestamps = append(b.timestamps, now)
 	}
 
-	return true
+	return now, true
+}
+
+// release gives back a slot acquired at the given time, in every bucket still counting it.
+func (l *Limiter) release(at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, b := range l.buckets {
+		for i := len(b.timestamps) - 1; i >= 0; i-- {
+			if b.timestamps[i].Equal(at) {
+				b.timestamps = append(b.timestamps[:i], b.timestamps[i+1:]...)
+				break
+			}
+		}
+	}
 }
 
 // pruneExpired removes timestamps outside the window.
@@ -106,6 +146,9 @@ func (l *Limiter) nextAvailable() time.Duration {
 
 	now := time.Now()
 	var maxWait time.Duration
+	if now.Before(l.pausedUntil) {
+		maxWait = l.pausedUntil.Sub(now)
+	}
 
 	for _, b := range l.buckets {
 		l.pruneExpired(b, now)
@@ -124,3 +167,118 @@ func (l *Limiter) nextAvailable() time.Duration {
 
 	return maxWait
 }
+
+// Limits returns the currently configured rules.
+func (l *Limiter) Limits() []Limit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limits := make([]Limit, 0, len(l.buckets))
+	for _, b := range l.buckets {
+		limits = append(limits, Limit{Count: b.rule.limit, Window: b.rule.window})
+	}
+	return limits
+}
+
+// SetLimits replaces the configured rules at runtime.
+// Requests already recorded for a window that is kept still count against it.
+func (l *Limiter) SetLimits(limits []Limit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if sameLimits(l.buckets, limits) {
+		return
+	}
+
+	existing := make(map[time.Duration]*bucket, len(l.buckets))
+	for _, b := range l.buckets {
+		existing[b.rule.window] = b
+	}
+
+	buckets := make([]*bucket, 0, len(limits))
+	for _, lim := range limits {
+		b := &bucket{
+			rule:       rule{limit: lim.Count, window: lim.Window},
+			timestamps: make([]time.Time, 0, lim.Count),
+		}
+		if old, ok := existing[lim.Window]; ok {
+			b.timestamps = append(b.timestamps, old.timestamps...)
+		}
+		buckets = append(buckets, b)
+	}
+	l.buckets = buckets
+}
+
+func sameLimits(buckets []*bucket, limits []Limit) bool {
+	if len(buckets) != len(limits) {
+		return false
+	}
+	for i, b := range buckets {
+		if b.rule.limit != limits[i].Count || b.rule.window != limits[i].Window {
+			return false
+		}
+	}
+	return true
+}
+
+// SyncCounts aligns local bookkeeping with request counts reported by the server.
+// Each count is matched to the rule with the same window; when the server has seen
+// more requests than we recorded (e.g. other processes sharing the key), the
+// missing requests are recorded as happening now.
+func (l *Limiter) SyncCounts(counts []Limit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for _, c := range counts {
+		for _, b := range l.buckets {
+			if b.rule.window != c.Window {
+				continue
+			}
+			l.pruneExpired(b, now)
+			for len(b.timestamps) < c.Count {
+				b.timestamps = append(b.timestamps, now)
+			}
+		}
+	}
+}
+
+// PauseFor blocks all acquisitions for the given duration, e.g. after the
+// server answered with a Retry-After header.
+func (l *Limiter) PauseFor(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := time.Now().Add(d)
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// ParseLimits parses a rate limit header of comma-separated "count:seconds"
+// pairs, such as Riot's "20:1,100:120".
+func ParseLimits(header string) ([]Limit, error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return nil, nil
+	}
+
+	pairs := strings.Split(header, ",")
+	limits := make([]Limit, 0, len(pairs))
+	for _, pair := range pairs {
+		count, seconds, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q", pair)
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit count %q: %w", count, err)
+		}
+		secs, err := strconv.Atoi(seconds)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit window %q: %w", seconds, err)
+		}
+		limits = append(limits, Limit{Count: n, Window: time.Duration(secs) * time.Second})
+	}
+	return limits, nil
+}
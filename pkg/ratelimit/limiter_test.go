@@ -194,3 +194,89 @@ func TestLimiter_RiotAPILimits(t *testing.T) {
 		t.Errorf("request 21 should be rate limited: %v", err)
 	}
 }
+
+func TestLimiter_SetLimits(t *testing.T) {
+	limiter := ratelimit.NewLimiter(
+		ratelimit.WithLimit(5, time.Second),
+	)
+
+	limiter.Wait(context.Background())
+	limiter.Wait(context.Background())
+
+	// Lower the limit at runtime: the 2 recorded requests still count
+	limiter.SetLimits([]ratelimit.Limit{{Count: 2, Window: time.Second}})
+
+	if limiter.TryAcquire() {
+		t.Error("TryAcquire should fail after lowering the limit below recorded requests")
+	}
+
+	limits := limiter.Limits()
+	if len(limits) != 1 || limits[0].Count != 2 {
+		t.Errorf("expected a single 2/s limit, got %+v", limits)
+	}
+}
+
+func TestLimiter_SyncCounts(t *testing.T) {
+	limiter := ratelimit.NewLimiter(
+		ratelimit.WithLimit(3, time.Second),
+	)
+
+	// Server reports 3 requests already made in this window
+	limiter.SyncCounts([]ratelimit.Limit{{Count: 3, Window: time.Second}})
+
+	if limiter.TryAcquire() {
+		t.Error("TryAcquire should fail once server-reported count reaches the limit")
+	}
+}
+
+func TestLimiter_PauseFor(t *testing.T) {
+	limiter := ratelimit.NewLimiter(
+		ratelimit.WithLimit(10, time.Second),
+	)
+
+	limiter.PauseFor(50 * time.Millisecond)
+
+	if limiter.TryAcquire() {
+		t.Error("TryAcquire should fail while paused")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatalf("Wait should succeed after pause: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Wait returned after %v, expected to honour the pause", elapsed)
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	limits, err := ratelimit.ParseLimits("20:1,100:120")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []ratelimit.Limit{
+		{Count: 20, Window: time.Second},
+		{Count: 100, Window: 120 * time.Second},
+	}
+	if len(limits) != len(expected) {
+		t.Fatalf("expected %d limits, got %d", len(expected), len(limits))
+	}
+	for i := range expected {
+		if limits[i] != expected[i] {
+			t.Errorf("limit %d: expected %+v, got %+v", i, expected[i], limits[i])
+		}
+	}
+
+	if _, err := ratelimit.ParseLimits("20-1"); err == nil {
+		t.Error("expected error for malformed header")
+	}
+
+	empty, err := ratelimit.ParseLimits("")
+	if err != nil || len(empty) != 0 {
+		t.Errorf("expected no limits for empty header, got %+v (%v)", empty, err)
+	}
+}
@@ -2,15 +2,73 @@ package ratelimit
 
 import (
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 )
 
+// maxIdleKeys is how many per-key limiters Middleware keeps before dropping idle ones.
+const maxIdleKeys = 1024
+
+// MiddlewareOption configures Middleware.
+type MiddlewareOption func(*middleware)
+
+type middleware struct {
+	limiter *Limiter
+	reject  http.HandlerFunc
+
+	key       func(*http.Request) string
+	keyLimits []Limit
+	keyIdle   time.Duration
+
+	mu   sync.Mutex
+	keys map[string]*keyedLimiter
+}
+
+type keyedLimiter struct {
+	limiter  *Limiter
+	lastSeen time.Time
+}
+
+// WithKeyLimits gives each request key, such as the client IP, its own Limiter
+// with these limits.
+func WithKeyLimits(key func(*http.Request) string, limits ...Limit) MiddlewareOption {
+	return func(m *middleware) {
+		m.key = key
+		m.keyLimits = limits
+		for _, l := range limits {
+			m.keyIdle = max(m.keyIdle, l.Window)
+		}
+	}
+}
+
+// WithRejectHandler replaces the plain text 429 response written for rejected
+// requests. Retry-After is already set when reject is called.
+func WithRejectHandler(reject http.HandlerFunc) MiddlewareOption {
+	return func(m *middleware) {
+		m.reject = reject
+	}
+}
+
 // Middleware wraps an http.Handler with rate limiting.
-func Middleware(limiter *Limiter) func(http.Handler) http.Handler {
+// limiter is shared by all requests and may be nil when key limits are used.
+func Middleware(limiter *Limiter, opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	m := &middleware{
+		limiter: limiter,
+		reject: func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		},
+		keys: make(map[string]*keyedLimiter),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if !limiter.TryAcquire() {
+			if !m.allow(r) {
 				w.Header().Set("Retry-After", "1")
-				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+				m.reject(w, r)
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -18,28 +76,224 @@ func Middleware(limiter *Limiter) func(http.Handler) http.Handler {
 	}
 }
 
+// allow takes a slot from the key limiter and then the shared one. If the shared
+// limiter rejects the request, the key slot is released since it is never served.
+func (m *middleware) allow(r *http.Request) bool {
+	var keyed *Limiter
+	var at time.Time
+	if m.key != nil {
+		keyed = m.keyLimiter(m.key(r))
+		var ok bool
+		if at, ok = keyed.acquire(); !ok {
+			return false
+		}
+	}
+	if m.limiter != nil && !m.limiter.TryAcquire() {
+		if keyed != nil {
+			keyed.release(at)
+		}
+		return false
+	}
+	return true
+}
+
+// keyLimiter returns the limiter for key, creating it on first use.
+func (m *middleware) keyLimiter(key string) *Limiter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	kl, ok := m.keys[key]
+	if !ok {
+		if len(m.keys) >= maxIdleKeys {
+			m.pruneIdle(now)
+		}
+		kl = &keyedLimiter{limiter: newLimiterWith(m.keyLimits)}
+		m.keys[key] = kl
+	}
+	kl.lastSeen = now
+	return kl.limiter
+}
+
+// pruneIdle drops limiters whose windows have all expired, so they hold no state worth keeping.
+func (m *middleware) pruneIdle(now time.Time) {
+	for key, kl := range m.keys {
+		if now.Sub(kl.lastSeen) > m.keyIdle {
+			delete(m.keys, key)
+		}
+	}
+}
+
 // RoundTripper wraps an http.RoundTripper with rate limiting.
 // Use this to rate limit outgoing HTTP requests (e.g., Riot API client).
+//
+// Requests can be limited by a single shared Limiter, by one Limiter per
+// request host (see WithHostLimits), and by one Limiter per (host, path
+// template) pair (see WithMethodLimits). All that apply must allow a request.
 type RoundTripper struct {
 	limiter *Limiter
 	next    http.RoundTripper
+
+	hostLimits []Limit
+	methods    []methodRule
+
+	mu             sync.Mutex
+	hostLimiters   map[string]*Limiter
+	methodLimiters map[methodKey]*Limiter
+}
+
+// methodRule declares limits for request paths matching a template.
+type methodRule struct {
+	template string
+	segments []string
+	limits   []Limit
+}
+
+type methodKey struct {
+	host     string
+	template string
+}
+
+// RoundTripperOption configures a RoundTripper.
+type RoundTripperOption func(*RoundTripper)
+
+// WithHostLimits gives each request host its own Limiter with these limits.
+func WithHostLimits(limits ...Limit) RoundTripperOption {
+	return func(rt *RoundTripper) {
+		rt.hostLimits = limits
+	}
+}
+
+// WithMethodLimits gives each (host, template) pair its own Limiter with these limits.
+// Template segments in braces, such as "/lol/match/v5/matches/{matchId}", match any
+// single path segment. Limits may be empty and raised later through Limiters.
+func WithMethodLimits(template string, limits ...Limit) RoundTripperOption {
+	return func(rt *RoundTripper) {
+		rt.methods = append(rt.methods, methodRule{
+			template: template,
+			segments: splitPath(template),
+			limits:   limits,
+		})
+	}
 }
 
 // NewRoundTripper creates a rate-limited RoundTripper.
-func NewRoundTripper(limiter *Limiter, next http.RoundTripper) *RoundTripper {
+// limiter is shared by all requests and may be nil when host limits are used.
+func NewRoundTripper(limiter *Limiter, next http.RoundTripper, opts ...RoundTripperOption) *RoundTripper {
 	if next == nil {
 		next = http.DefaultTransport
 	}
-	return &RoundTripper{
-		limiter: limiter,
-		next:    next,
+	rt := &RoundTripper{
+		limiter:        limiter,
+		next:           next,
+		hostLimiters:   make(map[string]*Limiter),
+		methodLimiters: make(map[methodKey]*Limiter),
+	}
+	for _, opt := range opts {
+		opt(rt)
 	}
+	return rt
 }
 
 // RoundTrip implements http.RoundTripper.
+//
+// The method limiter is waited on first, so a request queued behind its method's
+// limit holds no application slot meanwhile. If a later wait fails, the slots
+// already taken are released since the request is never sent.
 func (rt *RoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
-	if err := rt.limiter.Wait(r.Context()); err != nil {
-		return nil, err
+	host, method := rt.keyedLimiters(r)
+
+	type slot struct {
+		limiter *Limiter
+		at      time.Time
+	}
+	var held []slot
+	for _, l := range []*Limiter{method, host, rt.limiter} {
+		if l == nil {
+			continue
+		}
+		at, err := l.wait(r.Context())
+		if err != nil {
+			for _, s := range held {
+				s.limiter.release(s.at)
+			}
+			return nil, err
+		}
+		held = append(held, slot{limiter: l, at: at})
 	}
+
 	return rt.next.RoundTrip(r)
 }
+
+// Limiters returns the application and method limiters that apply to r, so callers
+// can reconfigure them from server feedback. The application limiter is the shared
+// limiter if one was given, otherwise the per-host limiter. Either may be nil.
+func (rt *RoundTripper) Limiters(r *http.Request) (app *Limiter, method *Limiter) {
+	host, method := rt.keyedLimiters(r)
+	if rt.limiter != nil {
+		return rt.limiter, method
+	}
+	return host, method
+}
+
+// keyedLimiters returns (creating on first use) the per-host and per-method limiters for r.
+func (rt *RoundTripper) keyedLimiters(r *http.Request) (host *Limiter, method *Limiter) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.hostLimits != nil {
+		host = rt.hostLimiters[r.URL.Host]
+		if host == nil {
+			host = newLimiterWith(rt.hostLimits)
+			rt.hostLimiters[r.URL.Host] = host
+		}
+	}
+
+	segments := splitPath(r.URL.Path)
+	for _, m := range rt.methods {
+		if !matchSegments(m.segments, segments) {
+			continue
+		}
+		key := methodKey{host: r.URL.Host, template: m.template}
+		method = rt.methodLimiters[key]
+		if method == nil {
+			method = newLimiterWith(m.limits)
+			rt.methodLimiters[key] = method
+		}
+		break
+	}
+
+	return host, method
+}
+
+func newLimiterWith(limits []Limit) *Limiter {
+	opts := make([]Option, 0, len(limits))
+	for _, lim := range limits {
+		opts = append(opts, WithLimit(lim.Count, lim.Window))
+	}
+	return NewLimiter(opts...)
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// matchSegments reports whether path segments match a template, where "{...}"
+// template segments match any non-empty segment.
+func matchSegments(template, path []string) bool {
+	if len(template) != len(path) {
+		return false
+	}
+	for i, seg := range template {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			if path[i] == "" {
+				return false
+			}
+			continue
+		}
+		if seg != path[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -96,3 +96,184 @@ func TestRoundTripper_RateLimits(t *testing.T) {
 		t.Error("expected timeout error")
 	}
 }
+
+func TestRoundTripper_HostLimitsAreIndependent(t *testing.T) {
+	serverA := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer serverA.Close()
+	serverB := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer serverB.Close()
+
+	client := &http.Client{
+		Transport: ratelimit.NewRoundTripper(nil, nil,
+			ratelimit.WithHostLimits(ratelimit.Limit{Count: 1, Window: time.Second}),
+		),
+	}
+
+	resp, err := client.Get(serverA.URL)
+	if err != nil {
+		t.Fatalf("first request to A failed: %v", err)
+	}
+	resp.Body.Close()
+
+	// A is exhausted, B has its own bucket
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, serverB.URL, nil)
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("request to B should not be limited by A: %v", err)
+	}
+	resp.Body.Close()
+
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, serverA.URL, nil)
+	if _, err := client.Do(req); err == nil {
+		t.Error("expected second request to A to be rate limited")
+	}
+}
+
+func TestRoundTripper_MethodLimitsByTemplate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	rt := ratelimit.NewRoundTripper(nil, nil,
+		ratelimit.WithMethodLimits("/matches/{id}", ratelimit.Limit{Count: 1, Window: time.Second}),
+	)
+	client := &http.Client{Transport: rt}
+
+	resp, err := client.Get(server.URL + "/matches/1")
+	if err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	// Unmatched paths are not limited by the method bucket
+	resp, err = client.Get(server.URL + "/leagues/1")
+	if err != nil {
+		t.Fatalf("unmatched path should not be limited: %v", err)
+	}
+	resp.Body.Close()
+
+	// Another ID shares the template's bucket
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/matches/2", nil)
+	if _, err := client.Do(req); err == nil {
+		t.Error("expected second request on the same template to be rate limited")
+	}
+
+	app, method := rt.Limiters(req)
+	if app != nil {
+		t.Error("expected no app limiter without shared or host limits")
+	}
+	if method == nil {
+		t.Error("expected method limiter for matching template")
+	}
+}
+
+func TestRoundTripper_MethodWaitKeepsAppQuota(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	rt := ratelimit.NewRoundTripper(nil, nil,
+		ratelimit.WithHostLimits(ratelimit.Limit{Count: 2, Window: time.Minute}),
+		ratelimit.WithMethodLimits("/matches/{id}", ratelimit.Limit{Count: 1, Window: time.Minute}),
+	)
+	client := &http.Client{Transport: rt}
+
+	resp, err := client.Get(server.URL + "/matches/1")
+	if err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	// Times out on the method limit, which must not spend the host's second slot.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/matches/2", nil)
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected second request on the same template to be rate limited")
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/leagues/1", nil)
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("expected the host's second slot to be free: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestMiddleware_KeyLimitsAndRejectHandler(t *testing.T) {
+	byClient := func(r *http.Request) string { return r.Header.Get("X-Client") }
+	reject := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"slow down"}`))
+	}
+	handler := ratelimit.Middleware(nil,
+		ratelimit.WithKeyLimits(byClient, ratelimit.Limit{Count: 1, Window: time.Minute}),
+		ratelimit.WithRejectHandler(reject),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := func(client string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Client", client)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := request("a"); rec.Code != http.StatusOK {
+		t.Fatalf("first request from a: expected 200, got %d", rec.Code)
+	}
+	rec := request("a")
+	if rec.Code != http.StatusTooManyRequests || rec.Body.String() != `{"error":"slow down"}` {
+		t.Errorf("second request from a: expected the reject handler's 429, got %d %q", rec.Code, rec.Body)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After header")
+	}
+	if rec := request("b"); rec.Code != http.StatusOK {
+		t.Errorf("first request from b: expected 200, got %d", rec.Code)
+	}
+}
+
+func TestMiddleware_SharedRejectReleasesKeySlot(t *testing.T) {
+	shared := ratelimit.NewLimiter(ratelimit.WithLimit(1, time.Minute))
+	handler := ratelimit.Middleware(shared,
+		ratelimit.WithKeyLimits(func(r *http.Request) string { return r.Header.Get("X-Client") }, ratelimit.Limit{Count: 1, Window: time.Minute}),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := func(client string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Client", client)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	request("a")
+	if code := request("b"); code != http.StatusTooManyRequests {
+		t.Fatalf("expected the shared limit to reject b, got %d", code)
+	}
+
+	// Once the shared limit allows it, b still has its own slot.
+	shared.SetLimits([]ratelimit.Limit{{Count: 10, Window: time.Minute}})
+	if code := request("b"); code != http.StatusOK {
+		t.Errorf("expected b's key slot to be released, got %d", code)
+	}
+}
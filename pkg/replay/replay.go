@@ -0,0 +1,212 @@
+// Package replay records HTTP responses to disk and serves them back, so API
+// clients can run without network access.
+package replay
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNoFixture is returned when no recorded response exists for a request.
+var ErrNoFixture = errors.New("no recorded response")
+
+// maxNameLen keeps fixture file names within common filesystem limits.
+const maxNameLen = 120
+
+// fixture is a recorded response as stored on disk. JSON bodies are stored
+// verbatim so fixtures stay readable and editable; anything else is stored as a string.
+type fixture struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Status int             `json:"status"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body"`
+	Text   bool            `json:"text,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that saves every response it passes through.
+type Recorder struct {
+	dir  string
+	next http.RoundTripper
+}
+
+// NewRecorder creates a Recorder that writes fixtures to dir, creating it if needed.
+// If next is nil, http.DefaultTransport is used.
+func NewRecorder(dir string, next http.RoundTripper) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create fixture dir: %w", err)
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{dir: dir, next: next}, nil
+}
+
+// RoundTrip implements http.RoundTripper. Rate limited (429) responses are
+// passed through without being recorded since they are transient.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return resp, nil
+	}
+
+	f := fixture{
+		Method: method(req),
+		URL:    Key(req),
+		Status: resp.StatusCode,
+		Header: resp.Header,
+		Body:   body,
+	}
+	if !json.Valid(body) {
+		f.Body, _ = json.Marshal(string(body))
+		f.Text = true
+	}
+	if err := r.write(FileName(req), &f); err != nil {
+		return nil, fmt.Errorf("record response: %w", err)
+	}
+	return resp, nil
+}
+
+// write stores f atomically so a concurrent replay never reads a partial fixture.
+func (r *Recorder) write(name string, f *fixture) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(r.dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(r.dir, name))
+}
+
+// Replayer is an http.RoundTripper that serves recorded responses and never
+// touches the network.
+type Replayer struct {
+	dir string
+}
+
+// NewReplayer creates a Replayer serving fixtures from dir.
+func NewReplayer(dir string) (*Replayer, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("open fixture dir: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("fixture path %s is not a directory", dir)
+	}
+	return &Replayer{dir: dir}, nil
+}
+
+// RoundTrip implements http.RoundTripper. It returns ErrNoFixture when the
+// request was never recorded.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	data, err := os.ReadFile(filepath.Join(r.dir, FileName(req)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoFixture, method(req), Key(req))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("decode fixture for %s: %w", Key(req), err)
+	}
+
+	body := []byte(f.Body)
+	if f.Text {
+		var s string
+		if err := json.Unmarshal(f.Body, &s); err != nil {
+			return nil, fmt.Errorf("decode fixture body for %s: %w", Key(req), err)
+		}
+		body = []byte(s)
+	}
+
+	header := f.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Status, http.StatusText(f.Status)),
+		StatusCode:    f.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// Key identifies a request by path and query. Query parameters are sorted, so
+// parameter order does not matter; the host is ignored so fixtures recorded
+// against one base URL replay against another.
+func Key(req *http.Request) string {
+	key := req.URL.Path
+	if q := req.URL.Query(); len(q) > 0 {
+		key += "?" + q.Encode()
+	}
+	return key
+}
+
+// FileName returns the fixture file name for a request: a readable form of the
+// method and key, plus a hash so distinct keys never collide.
+func FileName(req *http.Request) string {
+	m := method(req)
+	key := m + " " + Key(req)
+	sum := sha256.Sum256([]byte(key))
+
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.TrimPrefix(Key(req), "/"))
+	if len(name) > maxNameLen {
+		name = name[:maxNameLen]
+	}
+	return strings.ToLower(m) + "_" + name + "-" + hex.EncodeToString(sum[:4]) + ".json"
+}
+
+// method returns the request method, which is empty for client GET requests.
+func method(req *http.Request) string {
+	if req.Method == "" {
+		return http.MethodGet
+	}
+	return req.Method
+}
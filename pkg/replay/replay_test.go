@@ -0,0 +1,130 @@
+package replay_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/HatiCode/league-buddy/pkg/replay"
+)
+
+func TestRecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		switch r.URL.Path {
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"count":`+r.URL.Query().Get("count")+`}`)
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, "not found")
+		}
+	}))
+	defer server.Close()
+
+	recorder, err := replay.NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	recording := &http.Client{Transport: recorder}
+
+	for _, path := range []string{"/json?count=5&start=0", "/missing"} {
+		resp, err := recording.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		_, _ = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+
+	replayer, err := replay.NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("NewReplayer: %v", err)
+	}
+	replaying := &http.Client{Transport: replayer}
+
+	// Different host and query order still match the recording.
+	resp, err := replaying.Get("http://example.invalid/json?start=0&count=5")
+	if err != nil {
+		t.Fatalf("replay GET: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, body); err != nil || compact.String() != `{"count":5}` {
+		t.Errorf("unexpected body %q", body)
+	}
+	if resp.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("expected recorded headers, got %v", resp.Header)
+	}
+
+	resp, err = replaying.Get("http://example.invalid/missing")
+	if err != nil {
+		t.Fatalf("replay GET: %v", err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound || string(body) != "not found" {
+		t.Errorf("expected recorded 404 text body, got %d %q", resp.StatusCode, body)
+	}
+
+	if hits != 2 {
+		t.Errorf("expected replay to skip the network, server saw %d requests", hits)
+	}
+}
+
+func TestReplayer_MissingFixture(t *testing.T) {
+	replayer, err := replay.NewReplayer(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewReplayer: %v", err)
+	}
+
+	_, err = (&http.Client{Transport: replayer}).Get("http://example.invalid/nothing")
+	if !errors.Is(err, replay.ErrNoFixture) {
+		t.Errorf("expected ErrNoFixture, got %v", err)
+	}
+}
+
+func TestRecorder_SkipsRateLimitedResponses(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	recorder, err := replay.NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	resp, err := (&http.Client{Transport: recorder}).Get(server.URL + "/limited")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("expected no fixtures, got %d", len(entries))
+	}
+}
+
+func TestFileName_IsReadableAndDistinct(t *testing.T) {
+	a, _ := http.NewRequest(http.MethodGet, "https://europe.api.riotgames.com/lol/match/v5/matches/EUW1_1", nil)
+	b, _ := http.NewRequest(http.MethodGet, "https://europe.api.riotgames.com/lol/match/v5/matches/EUW1_1/timeline", nil)
+
+	nameA, nameB := replay.FileName(a), replay.FileName(b)
+	if nameA == nameB {
+		t.Fatal("expected distinct file names")
+	}
+	if !strings.HasPrefix(nameA, "get_lol_match_v5_matches_EUW1_1-") || strings.ContainsAny(nameA, `/\?`) {
+		t.Errorf("unexpected file name %q", nameA)
+	}
+}